@@ -0,0 +1,226 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// AggregateCountKey is the field key holding the number of entries an
+// aggregated summary stands for.
+const AggregateCountKey = "count"
+
+// DefaultAggregateWindow is the window of NewAggregator when
+// AggregatorOptions.Window is unset.
+const DefaultAggregateWindow = 10 * time.Second
+
+// DefaultAggregateGroups is the group limit of NewAggregator when
+// AggregatorOptions.MaxGroups is unset.
+const DefaultAggregateGroups = 1000
+
+// AggregatorOptions configures NewAggregator.
+type AggregatorOptions struct {
+	// Window is how long entries are collected before their summaries are
+	// written. It defaults to DefaultAggregateWindow.
+	Window time.Duration
+
+	// PassLevel, when set, is the level at and above which entries are
+	// written immediately rather than aggregated, as in
+	// PassLevel: new(velo.WarnLevel). It defaults to ErrorLevel.
+	PassLevel *Level
+
+	// MaxGroups caps the number of distinct groups collected in a window.
+	// Entries that would start a group beyond it are written immediately.
+	// It defaults to DefaultAggregateGroups.
+	MaxGroups int
+}
+
+// NewAggregator returns a Core that collapses structurally identical entries
+// into periodic summaries written to next.
+//
+// Entries below PassLevel are grouped by level and message for a window.
+// When the window closes, each group is written as a single entry carrying
+// the time and fields of its first entry, the sample, followed by the number
+// of entries in the group under AggregateCountKey. Entries at or above
+// PassLevel go straight to next. Sync and closing the Logger that uses the
+// Core write the pending summaries early.
+//
+// Group entries by a constant message, with the details in fields, so that
+// a job logging the same warning a million times writes one line per window:
+//
+//	core := velo.NewAggregator(velo.NewCore(os.Stderr), velo.AggregatorOptions{Window: time.Minute})
+//	logger := velo.NewWithCore(core)
+//
+// The sample keeps its field values by reference, like an asynchronous
+// Logger does until the entry is written.
+func NewAggregator(next Core, o AggregatorOptions) Core {
+	if o.Window <= 0 {
+		o.Window = DefaultAggregateWindow
+	}
+	pass := ErrorLevel
+	if o.PassLevel != nil {
+		pass = *o.PassLevel
+	}
+	if o.MaxGroups <= 0 {
+		o.MaxGroups = DefaultAggregateGroups
+	}
+	return &aggregatorCore{next: next, agg: &aggregator{opts: o, pass: pass, index: make(map[aggregateKey]int)}}
+}
+
+// aggregatorCore is a view of an aggregator writing its summaries to next.
+// Views created by With share the aggregator and its window.
+type aggregatorCore struct {
+	next Core
+	agg  *aggregator
+}
+
+// aggregator collects the groups of every view in the current window.
+type aggregator struct {
+	opts AggregatorOptions
+	pass Level // the PassLevel in effect
+
+	mu     sync.Mutex
+	groups []*aggregateGroup
+	index  map[aggregateKey]int
+	timer  *time.Timer
+	closed bool
+}
+
+type aggregateKey struct {
+	view  *aggregatorCore
+	level Level
+	msg   string
+}
+
+// aggregateGroup is a copy of the first entry of a group and the number of
+// entries seen.
+type aggregateGroup struct {
+	next       Core
+	count      int
+	level      Level
+	time       time.Time
+	msg        string
+	prefix     string
+	caller     string
+	callerFile string
+	callerFunc string
+	callerLine int
+	sequence   uint64
+	fields     []Field
+}
+
+func (c *aggregatorCore) Enabled(level Level) bool {
+	return c.next.Enabled(level)
+}
+
+func (c *aggregatorCore) With(fields []Field) Core {
+	return &aggregatorCore{next: c.next.With(fields), agg: c.agg}
+}
+
+func (c *aggregatorCore) Write(e *Entry) error {
+	if e.Level >= c.agg.pass || !c.agg.add(c, e) {
+		return c.next.Write(e)
+	}
+	return nil
+}
+
+// Sync writes the pending summaries and flushes next.
+func (c *aggregatorCore) Sync() error {
+	return errors.Join(c.agg.flush(), c.next.Sync())
+}
+
+func (c *aggregatorCore) close() {
+	c.agg.mu.Lock()
+	c.agg.closed = true
+	c.agg.mu.Unlock()
+	c.agg.flush()
+	closeCore(c.next)
+}
+
+// add counts e in its group, reporting false when e must be written directly
+// because the group limit is reached or the aggregator is closed.
+func (a *aggregator) add(view *aggregatorCore, e *Entry) bool {
+	key := aggregateKey{view: view, level: e.Level, msg: e.Message}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if i, ok := a.index[key]; ok {
+		a.groups[i].count++
+		return true
+	}
+	if a.closed || len(a.groups) >= a.opts.MaxGroups {
+		return false
+	}
+
+	g := &aggregateGroup{
+		next:       view.next,
+		count:      1,
+		level:      e.Level,
+		time:       e.Time,
+		msg:        e.Message,
+		prefix:     e.Prefix,
+		caller:     e.Caller,
+		callerFile: e.CallerFile,
+		callerFunc: e.CallerFunc,
+		callerLine: e.CallerLine,
+		sequence:   e.Sequence,
+	}
+	g.fields = appendKeyVals(make([]Field, 0, len(e.Fields)/2+len(e.TypedFields)+1), e.Fields)
+	g.fields = append(g.fields, e.TypedFields...)
+	a.index[key] = len(a.groups)
+	a.groups = append(a.groups, g)
+	if a.timer == nil {
+		a.timer = time.AfterFunc(a.opts.Window, func() { a.flush() })
+	}
+	return true
+}
+
+// flush writes a summary for every group of the current window, in the order
+// the groups started, and opens a new window.
+func (a *aggregator) flush() error {
+	a.mu.Lock()
+	groups := a.groups
+	a.groups = nil
+	clear(a.index)
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	a.mu.Unlock()
+
+	var errs []error
+	e := getEntry()
+	defer putEntry(e)
+	for _, g := range groups {
+		if !g.next.Enabled(g.level) {
+			continue
+		}
+		e.Level, e.Time, e.Message, e.Prefix = g.level, g.time, g.msg, g.prefix
+		e.Caller, e.CallerFile, e.CallerFunc, e.CallerLine = g.caller, g.callerFile, g.callerFunc, g.callerLine
+		e.Sequence = g.sequence
+		e.TypedFields = append(append(e.TypedFields[:0], g.fields...), Int(AggregateCountKey, g.count))
+		if err := g.next.Write(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// countCore counts the entries written to it at each level.
+type countCore struct {
+	mu     sync.Mutex
+	counts map[Level]int
+}
+
+func (c *countCore) Enabled(Level) bool { return true }
+func (c *countCore) With([]Field) Core  { return c }
+func (c *countCore) Sync() error        { return nil }
+func (c *countCore) Write(e *Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[Level]int)
+	}
+	c.counts[e.Level]++
+	return nil
+}
+
+func (c *countCore) count(level Level) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[level]
+}
+
+func TestAggregatorPassLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		pass  *Level
+		level Level
+		want  int
+	}{
+		{"default warn", nil, WarnLevel, 0},
+		{"default error", nil, ErrorLevel, 3},
+		{"info", new(InfoLevel), InfoLevel, 3},
+		{"info debug", new(InfoLevel), DebugLevel, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &countCore{}
+			core := NewAggregator(next, AggregatorOptions{Window: time.Hour, PassLevel: tt.pass})
+			l := NewWithCore(core, WithLevel(DebugLevel))
+			for range 3 {
+				l.Log(tt.level, "repeated")
+			}
+			if got := next.count(tt.level); got != tt.want {
+				t.Errorf("%d entries written before the window closed, want %d", got, tt.want)
+			}
+			l.Close()
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package benchmarks
+
+import (
+	"testing"
+
+	"velo"
+)
+
+// TestAllocations guards the allocation-free fast paths measured by the
+// benchmarks.
+func TestAllocations(t *testing.T) {
+	fields := veloFields()
+	tests := []struct {
+		name   string
+		logger func() *velo.Logger
+		log    func(*velo.Logger)
+	}{
+		{
+			name:   "TenFields",
+			logger: func() *velo.Logger { return newVelo(_sink) },
+			log:    func(l *velo.Logger) { l.InfoFields(_message, fields...) },
+		},
+		{
+			name:   "WithContext",
+			logger: func() *velo.Logger { return newVelo(_sink).WithFields(fields...) },
+			log:    func(l *velo.Logger) { l.Info(_message) },
+		},
+		{
+			name:   "StyledText",
+			logger: func() *velo.Logger { return velo.New(_sink, velo.WithColor(velo.ColorAlways)) },
+			log: func(l *velo.Logger) {
+				l.InfoFields(_message, velo.String("user", _oneUser), velo.Int("attempt", 3), velo.Bool("ok", true))
+			},
+		},
+		{
+			name:   "Caller",
+			logger: func() *velo.Logger { return newVelo(_sink, velo.WithCaller()) },
+			log:    func(l *velo.Logger) { l.InfoFields(_message, velo.String("user", _oneUser)) },
+		},
+		{
+			name:   "Build",
+			logger: func() *velo.Logger { return newVelo(_sink) },
+			log: func(l *velo.Logger) {
+				l.Build(velo.InfoLevel).Str("user", _oneUser).Int("attempt", 3).Bool("ok", true).Msg(_message)
+			},
+		},
+		{
+			name: "Async",
+			logger: func() *velo.Logger {
+				l := newVelo(_sink, velo.WithAsync(1024, velo.OverflowBlock))
+				// Entries waiting in the queue each hold a buffer, so the
+				// pools first need as many as the queue can hold.
+				for range 2048 {
+					l.InfoFields(_message, fields...)
+				}
+				l.Sync()
+				return l
+			},
+			log: func(l *velo.Logger) { l.InfoFields(_message, fields...) },
+		},
+		{
+			name:   "Disabled",
+			logger: func() *velo.Logger { return newVelo(_sink, velo.WithLevel(velo.InfoLevel)) },
+			log:    func(l *velo.Logger) { l.DebugFields(_message, fields...) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := tt.logger()
+			defer logger.Close()
+			if n := testing.AllocsPerRun(100, func() { tt.log(logger) }); n != 0 {
+				t.Errorf("got %v allocations per entry, want 0", n)
+			}
+		})
+	}
+}
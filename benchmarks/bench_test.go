@@ -0,0 +1,291 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package benchmarks
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/diode"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"velo"
+)
+
+func BenchmarkTenFields(b *testing.B) {
+	b.Run("velo", func(b *testing.B) {
+		logger := newVelo(_sink)
+		defer logger.Close()
+		fields := veloFields()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.InfoFields(_message, fields...)
+			}
+		})
+	})
+	b.Run("velo/keyvals", func(b *testing.B) {
+		logger := newVelo(_sink)
+		defer logger.Close()
+		kvs := keyvals()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message, kvs...)
+			}
+		})
+	})
+	b.Run("zap", func(b *testing.B) {
+		logger := newZap(zapcore.AddSync(_sink))
+		fields := zapFields()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message, fields...)
+			}
+		})
+	})
+	b.Run("zerolog", func(b *testing.B) {
+		logger := newZerolog(_sink)
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				zerologFields(logger.Info()).Msg(_message)
+			}
+		})
+	})
+	b.Run("slog", func(b *testing.B) {
+		logger := newSlog(_sink, false)
+		ctx := context.Background()
+		attrs := slogAttrs()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.LogAttrs(ctx, slog.LevelInfo, _message, attrs...)
+			}
+		})
+	})
+	b.Run("charmbracelet/log", func(b *testing.B) {
+		logger := newCharm(_sink, false)
+		kvs := keyvals()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message, kvs...)
+			}
+		})
+	})
+}
+
+func BenchmarkWithContext(b *testing.B) {
+	b.Run("velo", func(b *testing.B) {
+		base := newVelo(_sink)
+		defer base.Close()
+		logger := base.WithFields(veloFields()...)
+		defer logger.Close()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message)
+			}
+		})
+	})
+	b.Run("zap", func(b *testing.B) {
+		logger := newZap(zapcore.AddSync(_sink)).With(zapFields()...)
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message)
+			}
+		})
+	})
+	b.Run("zerolog", func(b *testing.B) {
+		logger := zerologContext(newZerolog(_sink).With()).Logger()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info().Msg(_message)
+			}
+		})
+	})
+	b.Run("slog", func(b *testing.B) {
+		logger := newSlog(_sink, false).With(slogArgs()...)
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message)
+			}
+		})
+	})
+	b.Run("charmbracelet/log", func(b *testing.B) {
+		logger := newCharm(_sink, false).With(keyvals()...)
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message)
+			}
+		})
+	})
+}
+
+func BenchmarkCaller(b *testing.B) {
+	b.Run("velo", func(b *testing.B) {
+		logger := newVelo(_sink, velo.WithCaller())
+		defer logger.Close()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.InfoFields(_message, velo.String("user", _oneUser))
+			}
+		})
+	})
+	b.Run("zap", func(b *testing.B) {
+		logger := newZap(zapcore.AddSync(_sink), zap.AddCaller())
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message, zap.String("user", _oneUser))
+			}
+		})
+	})
+	b.Run("zerolog", func(b *testing.B) {
+		logger := newZerolog(_sink).With().Caller().Logger()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info().Str("user", _oneUser).Msg(_message)
+			}
+		})
+	})
+	b.Run("slog", func(b *testing.B) {
+		logger := newSlog(_sink, true)
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message, slog.String("user", _oneUser))
+			}
+		})
+	})
+	b.Run("charmbracelet/log", func(b *testing.B) {
+		logger := newCharm(_sink, true)
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message, "user", _oneUser)
+			}
+		})
+	})
+}
+
+func BenchmarkAsync(b *testing.B) {
+	b.Run("velo", func(b *testing.B) {
+		logger := newVelo(_sink, velo.WithAsync(8192, velo.OverflowBlock))
+		defer logger.Close()
+		fields := veloFields()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.InfoFields(_message, fields...)
+			}
+		})
+	})
+	b.Run("zap", func(b *testing.B) {
+		ws := &zapcore.BufferedWriteSyncer{WS: zapcore.AddSync(_sink)}
+		defer ws.Stop()
+		logger := newZap(ws)
+		fields := zapFields()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info(_message, fields...)
+			}
+		})
+	})
+	b.Run("zerolog", func(b *testing.B) {
+		w := diode.NewWriter(_sink, 8192, 10*time.Millisecond, func(int) {})
+		defer w.Close()
+		logger := newZerolog(w)
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				zerologFields(logger.Info()).Msg(_message)
+			}
+		})
+	})
+}
+
+func BenchmarkDisabled(b *testing.B) {
+	b.Run("velo", func(b *testing.B) {
+		logger := newVelo(_sink, velo.WithLevel(velo.InfoLevel))
+		defer logger.Close()
+		fields := veloFields()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.DebugFields(_message, fields...)
+			}
+		})
+	})
+	b.Run("zap", func(b *testing.B) {
+		logger := newZap(zapcore.AddSync(_sink)).WithOptions(zap.IncreaseLevel(zap.InfoLevel))
+		fields := zapFields()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Debug(_message, fields...)
+			}
+		})
+	})
+	b.Run("zerolog", func(b *testing.B) {
+		logger := newZerolog(_sink).Level(zerolog.InfoLevel)
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				zerologFields(logger.Debug()).Msg(_message)
+			}
+		})
+	})
+	b.Run("slog", func(b *testing.B) {
+		logger := newSlog(_sink, false)
+		ctx := context.Background()
+		attrs := slogAttrs()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.LogAttrs(ctx, slog.LevelDebug, _message, attrs...)
+			}
+		})
+	})
+	b.Run("charmbracelet/log", func(b *testing.B) {
+		logger := newCharm(_sink, false)
+		kvs := keyvals()
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Debug(_message, kvs...)
+			}
+		})
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package benchmarks compares velo with other popular structured loggers.
+//
+// The benchmarks live in a separate module so that velo itself does not
+// depend on the loggers it is compared with. Every logger writes JSON to a
+// writer that discards its input, so the results measure encoding and
+// dispatch rather than I/O. It is not io.Discard, which some loggers detect
+// in order to skip formatting altogether. Fields are built once per
+// benchmark, so the results leave out the cost of constructing them.
+// Run them from this directory with:
+//
+//	go test -bench . -benchmem
+//
+// The scenarios are:
+//
+//   - TenFields: a message with ten fields of common types attached at the
+//     call site.
+//   - WithContext: a message without fields from a Logger carrying the same
+//     ten fields, attached once with With.
+//   - Caller: a message with one field and the caller reported.
+//   - Async: TenFields written through each library's buffered or
+//     asynchronous writer, for the libraries that have one.
+//   - Disabled: a message with ten fields below the Logger's level.
+//
+// TestAllocations fails if velo's fast paths, including caller reporting
+// and the asynchronous worker, start allocating, so that `go test` in this
+// module catches regressions without reading benchmark output.
+package benchmarks
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package benchmarks
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	charm "github.com/charmbracelet/log"
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"velo"
+)
+
+const _message = "Test logging, but use a somewhat realistic message length."
+
+// _sink discards everything written to it. The benchmarks write there rather
+// than to io.Discard, which some loggers, such as charmbracelet/log, detect
+// in order to skip formatting altogether.
+var _sink io.Writer = discard{}
+
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+
+var (
+	_tenInts    = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	_tenStrings = []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	_tenTimes   = []time.Time{
+		time.Unix(0, 0), time.Unix(1, 0), time.Unix(2, 0), time.Unix(3, 0), time.Unix(4, 0),
+		time.Unix(5, 0), time.Unix(6, 0), time.Unix(7, 0), time.Unix(8, 0), time.Unix(9, 0),
+	}
+	_oneTime = time.Unix(1700000000, 0)
+	_oneUser = "01HZX5K3Q8W7N2V6B4M9C1D0EF"
+	_fail    = errors.New("fail")
+)
+
+// Each library logs the same ten fields: an int, a slice of ints, a string, a
+// slice of strings, a time, a slice of times, a user ID, a duration, a bool,
+// and an error.
+
+func veloFields() []velo.Field {
+	return []velo.Field{
+		velo.Int("int", _tenInts[0]),
+		velo.Ints("ints", _tenInts),
+		velo.String("string", _tenStrings[0]),
+		velo.Strings("strings", _tenStrings),
+		velo.Time("time", _oneTime),
+		velo.Times("times", _tenTimes),
+		velo.String("user", _oneUser),
+		velo.Duration("duration", time.Second),
+		velo.Bool("ok", true),
+		velo.Err(_fail),
+	}
+}
+
+func zapFields() []zap.Field {
+	return []zap.Field{
+		zap.Int("int", _tenInts[0]),
+		zap.Ints("ints", _tenInts),
+		zap.String("string", _tenStrings[0]),
+		zap.Strings("strings", _tenStrings),
+		zap.Time("time", _oneTime),
+		zap.Times("times", _tenTimes),
+		zap.String("user", _oneUser),
+		zap.Duration("duration", time.Second),
+		zap.Bool("ok", true),
+		zap.Error(_fail),
+	}
+}
+
+func zerologFields(e *zerolog.Event) *zerolog.Event {
+	return e.
+		Int("int", _tenInts[0]).
+		Ints("ints", _tenInts).
+		Str("string", _tenStrings[0]).
+		Strs("strings", _tenStrings).
+		Time("time", _oneTime).
+		Times("times", _tenTimes).
+		Str("user", _oneUser).
+		Dur("duration", time.Second).
+		Bool("ok", true).
+		Err(_fail)
+}
+
+func zerologContext(c zerolog.Context) zerolog.Context {
+	return c.
+		Int("int", _tenInts[0]).
+		Ints("ints", _tenInts).
+		Str("string", _tenStrings[0]).
+		Strs("strings", _tenStrings).
+		Time("time", _oneTime).
+		Times("times", _tenTimes).
+		Str("user", _oneUser).
+		Dur("duration", time.Second).
+		Bool("ok", true).
+		Err(_fail)
+}
+
+func slogAttrs() []slog.Attr {
+	return []slog.Attr{
+		slog.Int("int", _tenInts[0]),
+		slog.Any("ints", _tenInts),
+		slog.String("string", _tenStrings[0]),
+		slog.Any("strings", _tenStrings),
+		slog.Time("time", _oneTime),
+		slog.Any("times", _tenTimes),
+		slog.String("user", _oneUser),
+		slog.Duration("duration", time.Second),
+		slog.Bool("ok", true),
+		slog.Any("error", _fail),
+	}
+}
+
+func slogArgs() []any {
+	attrs := slogAttrs()
+	args := make([]any, len(attrs))
+	for i, a := range attrs {
+		args[i] = a
+	}
+	return args
+}
+
+func keyvals() []any {
+	return []any{
+		"int", _tenInts[0],
+		"ints", _tenInts,
+		"string", _tenStrings[0],
+		"strings", _tenStrings,
+		"time", _oneTime,
+		"times", _tenTimes,
+		"user", _oneUser,
+		"duration", time.Second,
+		"ok", true,
+		"error", _fail,
+	}
+}
+
+// Constructors for loggers writing JSON with an RFC 3339 timestamp to w.
+
+func newVelo(w io.Writer, opts ...velo.Option) *velo.Logger {
+	opts = append([]velo.Option{
+		velo.WithFormatter(velo.JSONFormatter),
+		velo.WithTimestamp(time.RFC3339),
+	}, opts...)
+	return velo.New(w, opts...)
+}
+
+func newZap(w zapcore.WriteSyncer, opts ...zap.Option) *zap.Logger {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = zapcore.RFC3339TimeEncoder
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(cfg), w, zap.DebugLevel)
+	return zap.New(core, opts...)
+}
+
+func newZerolog(w io.Writer) zerolog.Logger {
+	return zerolog.New(w).With().Timestamp().Logger()
+}
+
+func newSlog(w io.Writer, addSource bool) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{AddSource: addSource}))
+}
+
+func newCharm(w io.Writer, reportCaller bool) *charm.Logger {
+	return charm.NewWithOptions(w, charm.Options{
+		Formatter:       charm.JSONFormatter,
+		ReportTimestamp: true,
+		TimeFormat:      time.RFC3339,
+		ReportCaller:    reportCaller,
+	})
+}
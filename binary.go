@@ -0,0 +1,662 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"time"
+)
+
+// The BinaryFormatter writes each entry as one self-contained record:
+//
+//	uvarint  length of the rest of the record
+//	byte     format version, binaryVersion
+//	byte     level
+//	byte     flags, saying which of the optional parts below are present
+//	int64    time as Unix nanoseconds, little endian       (binaryTime)
+//	string   prefix                                        (binaryPrefix)
+//	string   caller, string file, uvarint line, string func (binaryCaller)
+//	uvarint  sequence number                               (binarySequence)
+//	string   message
+//
+// Fields follow until the end of the record, each a key and a tagged value.
+// Strings are a uvarint length followed by their bytes. A key is a uvarint
+// index into _binaryKeys, or zero followed by the key as a string.
+const binaryVersion = 1
+
+const (
+	binaryTime byte = 1 << iota
+	binaryPrefix
+	binaryCaller
+	binarySequence
+)
+
+// Value tags. Signed integers and durations are zigzag varints, floats and
+// times fixed eight bytes, and values with no compact form, such as objects
+// and slices, carry their JSON encoding as a string.
+const (
+	binaryNull byte = iota
+	binaryString
+	binaryInt
+	binaryUint
+	binaryFloat
+	binaryFalse
+	binaryTrue
+	binaryTimeValue
+	binaryDuration
+	binaryJSON
+)
+
+// _binaryKeys is the dictionary of keys encoded as a single byte. Records
+// refer to keys by position, so entries may only ever be appended.
+var _binaryKeys = [...]string{
+	"error", "err", "caller", StacktraceKey, GoroutinesKey, TruncatedMessageKey,
+	SequenceKey, HostnameKey, PIDKey, ServiceKey, ServiceVersionKey,
+	"id", "user", "user_id", "request_id", "trace_id", "span_id",
+	"method", "path", "url", "status", "code", "duration", "latency",
+	"elapsed", "count", "size", "bytes", "attempt", "addr", "host", "port",
+	"name", "component", "key", "value", "type", "reason", "file", "line",
+	"func", "event", "action", "result", "remote_addr", "user_agent",
+	CorrelationKey, NameKey,
+}
+
+var _binaryKeyIndex = func() map[string]uint64 {
+	m := make(map[string]uint64, len(_binaryKeys))
+	for i, k := range _binaryKeys {
+		m[k] = uint64(i + 1)
+	}
+	return m
+}()
+
+// errBinaryRecord reports a record that does not follow the binary layout.
+var errBinaryRecord = errors.New("velo: malformed binary record")
+
+// _maxBinaryRecord bounds the record length a BinaryDecoder accepts, so that
+// a corrupt length prefix cannot make it allocate without limit.
+const _maxBinaryRecord = 64 << 20
+
+// formatLogBinary encodes a log entry as a binary record directly onto a
+// pooled buffer, bypassing the Entry struct like formatLogJSON.
+func formatLogBinary(b *buffer, base *baseFields, cfg *loggerConfig, level Level, msg string, callFields []any, callTypedFields []Field, ctxFields []Field, ci *callerInfo, t time.Time) {
+	var flags byte
+	if !t.IsZero() {
+		flags |= binaryTime
+	}
+	if cfg.prefix != "" {
+		flags |= binaryPrefix
+	}
+	if ci != nil && ci.formatted != "" {
+		flags |= binaryCaller
+	}
+	if cfg.sequence != nil {
+		flags |= binarySequence
+	}
+
+	start := len(b.B)
+	b.B = append(b.B, binaryVersion, byte(level), flags)
+	if flags&binaryTime != 0 {
+		b.B = binary.LittleEndian.AppendUint64(b.B, uint64(t.UnixNano()))
+	}
+	if flags&binaryPrefix != 0 {
+		b.B = appendBinaryString(b.B, cfg.prefix)
+	}
+	if flags&binaryCaller != 0 {
+		b.B = appendBinaryString(b.B, ci.formatted)
+		b.B = appendBinaryString(b.B, ci.file)
+		b.B = binary.AppendUvarint(b.B, uint64(max(ci.line, 0)))
+		b.B = appendBinaryString(b.B, ci.fn)
+	}
+	if flags&binarySequence != 0 {
+		b.B = binary.AppendUvarint(b.B, cfg.sequence.Add(1))
+	}
+
+	origLen := len(msg)
+	msg, truncated := truncateMessage(msg, cfg.maxMessageBytes)
+	if truncated {
+		b.B = binary.AppendUvarint(b.B, uint64(len(msg)+len(TruncationMarker)))
+		b.B = append(b.B, msg...)
+		b.B = append(b.B, TruncationMarker...)
+	} else {
+		b.B = appendBinaryString(b.B, msg)
+	}
+
+	ff := binaryFormat(cfg.timeLocation)
+	if cfg.name != "" {
+		b.B = appendBinaryKey(b.B, NameKey)
+		b.B = append(b.B, binaryString)
+		b.B = appendBinaryString(b.B, cfg.name)
+	}
+	for i := 0; i+1 < len(base.fields); i += 2 {
+		appendBinaryKeyVal(b, base.fields[i], base.fields[i+1], ff)
+	}
+	for i := range base.typedFields {
+		appendBinaryField(b, &base.typedFields[i], ff)
+	}
+	for i := range base.conditional {
+		if c := &base.conditional[i]; c.applies(level) {
+			for j := range c.fields {
+				appendBinaryField(b, &c.fields[j], ff)
+			}
+		}
+	}
+	for i := range ctxFields {
+		appendBinaryField(b, &ctxFields[i], ff)
+	}
+	for i := 0; i+1 < len(callFields); i += 2 {
+		appendBinaryKeyVal(b, callFields[i], callFields[i+1], ff)
+	}
+	for i := range callTypedFields {
+		appendBinaryField(b, &callTypedFields[i], ff)
+	}
+	appendBinaryDropped(b)
+	if truncated {
+		b.B = appendBinaryKey(b.B, TruncatedMessageKey)
+		appendBinaryInt(b, int64(origLen))
+	}
+
+	finishBinaryRecord(b, start)
+}
+
+// formatBinary encodes a fully assembled Entry as a binary record.
+//
+// Stack traces are rendered in place rather than deferred to the worker,
+// since the record length is written once the record is complete.
+func formatBinary(b *buffer, e *Entry) {
+	var flags byte
+	if !e.Time.IsZero() {
+		flags |= binaryTime
+	}
+	if e.Prefix != "" {
+		flags |= binaryPrefix
+	}
+	if e.Caller != "" {
+		flags |= binaryCaller
+	}
+	if e.Sequence != 0 {
+		flags |= binarySequence
+	}
+
+	start := len(b.B)
+	b.B = append(b.B, binaryVersion, byte(e.Level), flags)
+	if flags&binaryTime != 0 {
+		b.B = binary.LittleEndian.AppendUint64(b.B, uint64(e.Time.UnixNano()))
+	}
+	if flags&binaryPrefix != 0 {
+		b.B = appendBinaryString(b.B, e.Prefix)
+	}
+	if flags&binaryCaller != 0 {
+		b.B = appendBinaryString(b.B, e.Caller)
+		b.B = appendBinaryString(b.B, e.CallerFile)
+		b.B = binary.AppendUvarint(b.B, uint64(max(e.CallerLine, 0)))
+		b.B = appendBinaryString(b.B, e.CallerFunc)
+	}
+	if flags&binarySequence != 0 {
+		b.B = binary.AppendUvarint(b.B, e.Sequence)
+	}
+	b.B = appendBinaryString(b.B, e.Message)
+
+	ff := binaryFormat(e.timeLocation)
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		appendBinaryKeyVal(b, e.Fields[i], e.Fields[i+1], ff)
+	}
+	for i := range e.TypedFields {
+		appendBinaryField(b, &e.TypedFields[i], ff)
+	}
+	appendBinaryDropped(b)
+
+	if len(e.Stack) > 0 {
+		trace := getBufferSize(len(e.Stack) * stackFrameSizeHint)
+		appendStackLines(trace, e.Stack, e.StackDepth, e.StackFilter)
+		b.B = appendBinaryKey(b.B, StacktraceKey)
+		b.B = append(b.B, binaryString)
+		b.B = binary.AppendUvarint(b.B, uint64(len(trace.B)))
+		b.B = append(b.B, trace.B...)
+		putBuffer(trace)
+	}
+	if len(e.Goroutines) > 0 {
+		b.B = appendBinaryKey(b.B, GoroutinesKey)
+		b.B = append(b.B, binaryString)
+		b.B = binary.AppendUvarint(b.B, uint64(len(e.Goroutines)))
+		b.B = append(b.B, e.Goroutines...)
+	}
+
+	finishBinaryRecord(b, start)
+}
+
+// finishBinaryRecord inserts the length prefix of the record that begins at
+// start and runs to the end of b.
+func finishBinaryRecord(b *buffer, start int) {
+	var n [binary.MaxVarintLen64]byte
+	prefix := binary.AppendUvarint(n[:0], uint64(len(b.B)-start))
+	b.B = slices.Insert(b.B, start, prefix...)
+}
+
+// appendBinaryString appends s with its uvarint length.
+func appendBinaryString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// appendBinaryKey appends key as its dictionary index, or inline when it is
+// not in _binaryKeys.
+func appendBinaryKey(b []byte, key string) []byte {
+	if i, ok := _binaryKeyIndex[key]; ok {
+		return binary.AppendUvarint(b, i)
+	}
+	b = append(b, 0)
+	return appendBinaryString(b, key)
+}
+
+// appendBinaryDropped ends the field limit on b, attaching the number of
+// fields it dropped under TruncatedFieldsKey.
+func appendBinaryDropped(b *buffer) {
+	if n := b.droppedFields(); n > 0 {
+		b.B = appendBinaryKey(b.B, TruncatedFieldsKey)
+		appendBinaryInt(b, int64(n))
+	}
+}
+
+// binaryFormat returns the fieldFormat of the times within JSON values, such
+// as the elements of a Times field: RFC 3339, so that they survive decoding,
+// in the location loc of the Logger.
+func binaryFormat(loc *time.Location) fieldFormat {
+	return fieldFormat{layout: time.RFC3339Nano, loc: loc}
+}
+
+// appendBinaryKeyVal encodes a loosely typed key-value pair.
+func appendBinaryKeyVal(b *buffer, key, val any, ff fieldFormat) {
+	mark := len(b.B)
+	if k, ok := key.(string); ok {
+		b.B = appendBinaryKey(b.B, k)
+	} else {
+		b.B = appendBinaryKey(b.B, formatAny(key))
+	}
+	appendBinaryAny(b, val, ff)
+	b.keepField(mark)
+}
+
+// appendBinaryField encodes a strongly typed Field, writing the times within
+// JSON values in ff, as returned by binaryFormat.
+func appendBinaryField(b *buffer, f *Field, ff fieldFormat) {
+	mark := len(b.B)
+	encodeBinaryField(b, f, ff)
+	b.keepField(mark)
+}
+
+// encodeBinaryField encodes f for appendBinaryField.
+func encodeBinaryField(b *buffer, f *Field, ff fieldFormat) {
+	b.B = appendBinaryKey(b.B, f.Key)
+	switch f.Type {
+	case StringType:
+		b.B = append(b.B, binaryString)
+		b.B = appendBinaryString(b.B, f.Str)
+	case IntType:
+		appendBinaryInt(b, f.Int)
+	case BoolType:
+		if f.Int == 1 {
+			b.B = append(b.B, binaryTrue)
+		} else {
+			b.B = append(b.B, binaryFalse)
+		}
+	case ErrorType:
+		if f.Any == nil {
+			b.B = append(b.B, binaryNull)
+			return
+		}
+		b.B = append(b.B, binaryString)
+		b.B = appendBinaryString(b.B, f.Any.(error).Error())
+	case TimeType:
+		b.B = append(b.B, binaryTimeValue)
+		b.B = binary.LittleEndian.AppendUint64(b.B, uint64(f.Int))
+	case DurationType:
+		b.B = append(b.B, binaryDuration)
+		b.B = binary.AppendVarint(b.B, f.Int)
+	case AnyType:
+		appendBinaryAny(b, f.Any, ff)
+	default:
+		// Objects, arrays, and slices keep their JSON form.
+		appendBinaryJSON(b, func(js *buffer) { appendJSONFieldValue(js, f, ff) })
+	}
+}
+
+// appendBinaryAny encodes an arbitrary value the way appendJSONAny would
+// present it, keeping the JSON form for objects and collections.
+func appendBinaryAny(b *buffer, v any, ff fieldFormat) {
+	if enc, ok := lookupEncoder(v); ok {
+		appendBinaryJSON(b, func(js *buffer) { appendEncoded(js, enc, v, fieldFormat{loc: ff.loc}) })
+		return
+	}
+	switch val := v.(type) {
+	case string:
+		b.B = append(b.B, binaryString)
+		b.B = appendBinaryString(b.B, val)
+	case int:
+		appendBinaryInt(b, int64(val))
+	case int64:
+		appendBinaryInt(b, val)
+	case int32:
+		appendBinaryInt(b, int64(val))
+	case int16:
+		appendBinaryInt(b, int64(val))
+	case int8:
+		appendBinaryInt(b, int64(val))
+	case uint:
+		appendBinaryUint(b, uint64(val))
+	case uint64:
+		appendBinaryUint(b, val)
+	case uint32:
+		appendBinaryUint(b, uint64(val))
+	case uint16:
+		appendBinaryUint(b, uint64(val))
+	case uint8:
+		appendBinaryUint(b, uint64(val))
+	case bool:
+		if val {
+			b.B = append(b.B, binaryTrue)
+		} else {
+			b.B = append(b.B, binaryFalse)
+		}
+	case float64:
+		appendBinaryFloat(b, val)
+	case float32:
+		appendBinaryFloat(b, float64(val))
+	case time.Time:
+		b.B = append(b.B, binaryTimeValue)
+		b.B = binary.LittleEndian.AppendUint64(b.B, uint64(val.UnixNano()))
+	case time.Duration:
+		b.B = append(b.B, binaryDuration)
+		b.B = binary.AppendVarint(b.B, int64(val))
+	case []byte:
+		b.B = append(b.B, binaryString)
+		b.B = binary.AppendUvarint(b.B, uint64(len(val)))
+		b.B = append(b.B, val...)
+	case ObjectMarshaler, ArrayMarshaler, []int, []string, []any, map[string]any, []time.Time:
+		appendBinaryJSON(b, func(js *buffer) { appendJSONAny(js, v, ff) })
+	case error:
+		b.B = append(b.B, binaryString)
+		b.B = appendBinaryString(b.B, val.Error())
+	case fmt.Stringer, encoding.TextMarshaler:
+		b.B = append(b.B, binaryString)
+		b.B = appendBinaryString(b.B, formatAny(v))
+	case json.Marshaler:
+		appendBinaryJSON(b, func(js *buffer) { appendJSONAny(js, v, ff) })
+	default:
+		b.B = append(b.B, binaryString)
+		b.B = appendBinaryString(b.B, formatAny(v))
+	}
+}
+
+func appendBinaryInt(b *buffer, v int64) {
+	b.B = append(b.B, binaryInt)
+	b.B = binary.AppendVarint(b.B, v)
+}
+
+func appendBinaryUint(b *buffer, v uint64) {
+	b.B = append(b.B, binaryUint)
+	b.B = binary.AppendUvarint(b.B, v)
+}
+
+// appendBinaryFloat encodes v, with NaN and infinities becoming null as they
+// do in JSON.
+func appendBinaryFloat(b *buffer, v float64) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		b.B = append(b.B, binaryNull)
+		return
+	}
+	b.B = append(b.B, binaryFloat)
+	b.B = binary.LittleEndian.AppendUint64(b.B, math.Float64bits(v))
+}
+
+// appendBinaryJSON encodes the JSON that encode writes as a binaryJSON value.
+func appendBinaryJSON(b *buffer, encode func(*buffer)) {
+	js := getBuffer()
+	js.limit = b.room()
+	encode(js)
+	b.B = append(b.B, binaryJSON)
+	b.B = binary.AppendUvarint(b.B, uint64(len(js.B)))
+	b.B = append(b.B, js.B...)
+	putBuffer(js)
+}
+
+// rawJSON holds a value decoded from its JSON form. It encodes as itself
+// under the JSONFormatter and prints as its JSON text otherwise.
+type rawJSON []byte
+
+func (r rawJSON) String() string { return string(r) }
+
+// BinaryDecoder reads the records written by the BinaryFormatter.
+//
+// Pair it with a Core to convert a binary log back to text or JSON:
+//
+//	dec := velo.NewBinaryDecoder(f)
+//	err := dec.Replay(velo.NewCore(os.Stdout, velo.WithFormatter(velo.JSONFormatter)))
+type BinaryDecoder struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+// NewBinaryDecoder returns a BinaryDecoder that reads records from r.
+func NewBinaryDecoder(r io.Reader) *BinaryDecoder {
+	return &BinaryDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next record into e, replacing its time, level, prefix,
+// caller, sequence, message, and fields. The Formatter, TimeFormat, Styles,
+// and Layout of e are left alone.
+//
+// It returns io.EOF once the input ends cleanly between records, and
+// io.ErrUnexpectedEOF when it ends inside one.
+func (d *BinaryDecoder) Decode(e *Entry) error {
+	n, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return err
+	}
+	if n > _maxBinaryRecord {
+		return fmt.Errorf("%w: record of %d bytes", errBinaryRecord, n)
+	}
+	if uint64(cap(d.buf)) < n {
+		d.buf = make([]byte, n)
+	}
+	d.buf = d.buf[:n]
+	if _, err := io.ReadFull(d.r, d.buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	return decodeBinaryRecord(d.buf, e)
+}
+
+// Replay decodes every remaining record and writes it to c, then syncs c.
+// It returns nil once the input is exhausted.
+func (d *BinaryDecoder) Replay(c Core) error {
+	e := getEntry()
+	defer putEntry(e)
+	for {
+		err := d.Decode(e)
+		if err == io.EOF {
+			return c.Sync()
+		}
+		if err != nil {
+			return err
+		}
+		if c.Enabled(e.Level) {
+			if err := c.Write(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// decodeBinaryRecord decodes the record body rec into e.
+func decodeBinaryRecord(rec []byte, e *Entry) error {
+	r := binaryReader{b: rec}
+	if v := r.byte(); v != binaryVersion && !r.bad {
+		return fmt.Errorf("%w: unknown version %d", errBinaryRecord, v)
+	}
+	e.Level = Level(int8(r.byte()))
+	flags := r.byte()
+
+	e.Time = time.Time{}
+	if flags&binaryTime != 0 {
+		e.Time = time.Unix(0, int64(r.uint64()))
+	}
+	e.Prefix = ""
+	if flags&binaryPrefix != 0 {
+		e.Prefix = r.string()
+	}
+	e.Caller, e.CallerFile, e.CallerLine, e.CallerFunc = "", "", 0, ""
+	if flags&binaryCaller != 0 {
+		e.Caller = r.string()
+		e.CallerFile = r.string()
+		e.CallerLine = int(r.uvarint())
+		e.CallerFunc = r.string()
+	}
+	e.Sequence = 0
+	if flags&binarySequence != 0 {
+		e.Sequence = r.uvarint()
+	}
+	e.Message = r.string()
+
+	e.Fields = e.Fields[:0]
+	e.TypedFields = e.TypedFields[:0]
+	e.Stack = e.Stack[:0]
+	e.Goroutines = nil
+	for len(r.b) > 0 && !r.bad {
+		e.TypedFields = append(e.TypedFields, r.field())
+	}
+	if r.bad {
+		return errBinaryRecord
+	}
+	return nil
+}
+
+// binaryReader consumes a record body. Reading past its end sets bad and
+// yields zero values, so callers check bad once they are done.
+type binaryReader struct {
+	b   []byte
+	bad bool
+}
+
+func (r *binaryReader) byte() byte {
+	if len(r.b) < 1 {
+		r.bad = true
+		return 0
+	}
+	c := r.b[0]
+	r.b = r.b[1:]
+	return c
+}
+
+func (r *binaryReader) uint64() uint64 {
+	if len(r.b) < 8 {
+		r.bad = true
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(r.b)
+	r.b = r.b[8:]
+	return v
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.bad = true
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *binaryReader) varint() int64 {
+	v, n := binary.Varint(r.b)
+	if n <= 0 {
+		r.bad = true
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *binaryReader) bytes() []byte {
+	n := r.uvarint()
+	if n > uint64(len(r.b)) {
+		r.bad = true
+		return nil
+	}
+	p := r.b[:n]
+	r.b = r.b[n:]
+	return p
+}
+
+func (r *binaryReader) string() string {
+	return string(r.bytes())
+}
+
+func (r *binaryReader) key() string {
+	i := r.uvarint()
+	switch {
+	case i == 0:
+		return r.string()
+	case i <= uint64(len(_binaryKeys)):
+		return _binaryKeys[i-1]
+	default:
+		r.bad = true
+		return ""
+	}
+}
+
+func (r *binaryReader) field() Field {
+	key := r.key()
+	switch tag := r.byte(); tag {
+	case binaryNull:
+		return Any(key, rawJSON("null"))
+	case binaryString:
+		return String(key, r.string())
+	case binaryInt:
+		return Int64(key, r.varint())
+	case binaryUint:
+		return Any(key, r.uvarint())
+	case binaryFloat:
+		return Any(key, math.Float64frombits(r.uint64()))
+	case binaryFalse:
+		return Bool(key, false)
+	case binaryTrue:
+		return Bool(key, true)
+	case binaryTimeValue:
+		return Field{Key: key, Type: TimeType, Int: int64(r.uint64())}
+	case binaryDuration:
+		return Duration(key, time.Duration(r.varint()))
+	case binaryJSON:
+		return Any(key, rawJSON(slices.Clone(r.bytes())))
+	default:
+		r.bad = true
+		return Field{}
+	}
+}
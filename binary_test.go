@@ -0,0 +1,81 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// logSamples logs the same entries to l wherever it is called from, so that
+// two Loggers report the same caller.
+func logSamples(l *Logger) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	l.LogAt(at, InfoLevel, "started", String("user", "alice"), Int("attempt", -3), Bool("ok", true))
+	l.LogAt(at, WarnLevel, "slow", Duration("elapsed", 1500*time.Millisecond), Any("ratio", 0.25), Any("bytes", uint64(1<<40)))
+	l.LogAt(at, ErrorLevel, "failed", Time("since", at.Add(-time.Hour)), Err(errors.New("boom")), Strings("tags", []string{"a", "b"}), Ints("ids", []int{1, 2}))
+	l.With("request", 7).LogAt(at, DebugLevel, "with context", Any("meta", map[string]int{"n": 1}))
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	opts := []Option{
+		WithLevel(DebugLevel),
+		WithTimestamp(time.RFC3339Nano),
+		WithTimeLocation(time.UTC),
+		WithCaller(),
+		WithMessagePrefix("svc"),
+	}
+	var bin, want bytes.Buffer
+	logSamples(New(&bin, append(opts, WithFormatter(BinaryFormatter))...))
+	logSamples(New(&want, append(opts, WithFormatter(JSONFormatter))...))
+
+	var got bytes.Buffer
+	if err := NewBinaryDecoder(&bin).Replay(NewCore(&got, append(opts, WithFormatter(JSONFormatter))...)); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("replayed\n%s\nwant\n%s", got.String(), want.String())
+	}
+}
+
+func TestBinaryDecoderTruncated(t *testing.T) {
+	var bin bytes.Buffer
+	New(&bin, WithFormatter(BinaryFormatter)).Info("msg", "key", "value")
+	rec := bin.Bytes()
+
+	e := getEntry()
+	defer putEntry(e)
+	if err := NewBinaryDecoder(bytes.NewReader(rec)).Decode(e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Message != "msg" || len(e.TypedFields) != 1 || e.TypedFields[0].Key != "key" {
+		t.Errorf("decoded %q with fields %v", e.Message, e.TypedFields)
+	}
+	if err := NewBinaryDecoder(bytes.NewReader(rec[:len(rec)-1])).Decode(e); err != io.ErrUnexpectedEOF {
+		t.Errorf("Decode of a truncated record = %v, want io.ErrUnexpectedEOF", err)
+	}
+	if err := NewBinaryDecoder(bytes.NewReader(nil)).Decode(e); err != io.EOF {
+		t.Errorf("Decode of empty input = %v, want io.EOF", err)
+	}
+}
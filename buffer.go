@@ -20,33 +20,163 @@
 
 package velo
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // Buffer is a zero allocation byte buffer pooled for maximum performance.
 type buffer struct {
 	B []byte
-}
 
-var bufPool = sync.Pool{
-	New: func() any {
-		return &buffer{B: make([]byte, 0, 4096)}
-	},
+	// stack is a stack trace still to be symbolized into B.
+	stack pendingStack
+
+	// limit is the length past which fields are dropped rather than
+	// written, or zero for no limit; dropped counts them. See limitEntry.
+	limit   int
+	dropped int
 }
 
+// _bufferClasses are the capacities by which buffers are pooled, smallest
+// first. Log calls take a buffer from the class fitting the recent entries of
+// their Logger, as tracked by sizeEstimator, so small entries do not hold
+// large buffers and large entries rarely grow theirs. A buffer is returned to the largest class its capacity covers, so the
+// occasional large entry, such as one carrying a stack trace or goroutine
+// dump, leaves its buffer for the next large entry instead of either
+// discarding it or handing it to ordinary entries. Buffers larger than
+// _maxPooledBuffer are left to the garbage collector.
+//
+// Performance Note: Each sync.Pool already keeps a private cache per P, so
+// a Get and Put on the same P never contend. Splitting by size keeps the
+// common classes dense, which keeps those per-P caches warm.
+var _bufferClasses = [...]int{512, 2 << 10, 8 << 10, 32 << 10, 128 << 10, 512 << 10}
+
+// _maxPooledBuffer is the capacity above which buffers are not pooled.
+const _maxPooledBuffer = 1 << 20
+
+var _bufPools [len(_bufferClasses)]sync.Pool
+
+// getBuffer returns an empty buffer from the smallest size class.
 func getBuffer() *buffer {
-	return bufPool.Get().(*buffer)
+	return getBufferClass(0)
+}
+
+// getBufferSize returns an empty buffer expected to hold about n bytes.
+func getBufferSize(n int) *buffer {
+	for i, size := range _bufferClasses {
+		if n <= size {
+			return getBufferClass(i)
+		}
+	}
+	return getBufferClass(len(_bufferClasses) - 1)
+}
+
+func getBufferClass(i int) *buffer {
+	if b, ok := _bufPools[i].Get().(*buffer); ok {
+		return b
+	}
+	return &buffer{B: make([]byte, 0, _bufferClasses[i])}
+}
+
+// sizeEstimator tracks an exponentially weighted moving average of the size of
+// the entries a Logger formats.
+//
+// Performance Note: The average only changes when an entry differs from it by
+// more than a few bytes, so a Logger with steady entry sizes stops writing to
+// the shared counter entirely. Concurrent updates may overwrite each other,
+// which merely slows the adaptation.
+type sizeEstimator struct {
+	avg atomic.Int64
+}
+
+// estimate returns the expected size of the next entry, with some headroom.
+func (s *sizeEstimator) estimate() int {
+	if s == nil {
+		return 0
+	}
+	avg := s.avg.Load()
+	return int(avg + avg/4)
+}
+
+// observe folds the size n of a formatted entry into the average.
+func (s *sizeEstimator) observe(n int) {
+	if s == nil {
+		return
+	}
+	avg := s.avg.Load()
+	if d := (int64(n) - avg) / 8; d != 0 {
+		s.avg.Store(avg + d)
+	}
 }
 
 func (b *buffer) Reset() {
 	b.B = b.B[:0]
+	b.stack.reset()
+	b.limit, b.dropped = 0, 0
+}
+
+// _entryLimitReserve is the room kept under Options.MaxEntryBytes for the
+// fields and closing written after the limited ones.
+const _entryLimitReserve = 64
+
+// limitEntry caps the fields of the entry about to be formatted onto b so
+// that the entry stays within max bytes. A max of zero leaves it unlimited.
+func (b *buffer) limitEntry(max int) {
+	if max > 0 {
+		b.limit = len(b.B) + max - _entryLimitReserve
+		if b.limit <= 0 {
+			b.limit = 1
+		}
+	}
+}
+
+// keepField reports whether the field formatted onto b since mark fits
+// within the limit, removing it and counting it as dropped when it does not.
+func (b *buffer) keepField(mark int) bool {
+	if b.limit == 0 || len(b.B) <= b.limit {
+		return true
+	}
+	b.B = b.B[:mark]
+	b.dropped++
+	return false
+}
+
+// room returns the bytes left before the limit on b, at least one, or zero
+// if b is not limited. A value encoded elsewhere and limited to room is cut
+// short only when the field holding it would be dropped anyway.
+func (b *buffer) room() int {
+	if b.limit == 0 {
+		return 0
+	}
+	return max(b.limit-len(b.B), 1)
+}
+
+// fits reports whether n more bytes stay within the limit on b.
+func (b *buffer) fits(n int) bool {
+	return b.limit == 0 || len(b.B)+n <= b.limit
+}
+
+// droppedFields lifts the limit on b and returns the number of fields it
+// dropped, so that the fields closing an entry are always written.
+func (b *buffer) droppedFields() int {
+	n := b.dropped
+	b.limit, b.dropped = 0, 0
+	return n
 }
 
 func putBuffer(b *buffer) {
-	if cap(b.B) > 64*1024 {
+	c := cap(b.B)
+	if c > _maxPooledBuffer {
 		return
 	}
-	b.Reset()
-	bufPool.Put(b)
+	for i := len(_bufferClasses) - 1; i >= 0; i-- {
+		if c >= _bufferClasses[i] {
+			b.Reset()
+			_bufPools[i].Put(b)
+			return
+		}
+	}
 }
 
 func (b *buffer) Free() {
@@ -57,8 +187,9 @@ func (b *buffer) WriteString(s string) {
 	b.B = append(b.B, s...)
 }
 
-func (b *buffer) WriteByte(c byte) {
+func (b *buffer) WriteByte(c byte) error {
 	b.B = append(b.B, c)
+	return nil
 }
 
 func (b *buffer) Write(p []byte) (int, error) {
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func BenchmarkBufferPool(b *testing.B) {
+	for _, size := range []int{100, 4 << 10, 64 << 10} {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					buf := getBufferSize(size)
+					buf.B = buf.B[:size]
+					putBuffer(buf)
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkParallel logs from every P at once, mixing ordinary entries with
+// the occasional large one, so that the buffers of different size classes
+// are taken and returned concurrently.
+func BenchmarkParallel(b *testing.B) {
+	large := strings.Repeat("x", 16<<10)
+	for _, tt := range []struct {
+		name string
+		opts []Option
+	}{
+		{"sync", nil},
+		{"async", []Option{WithAsync(1024, OverflowBlock)}},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			l := New(io.Discard, append([]Option{WithFormatter(JSONFormatter)}, tt.opts...)...)
+			defer l.Close()
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					if i%64 == 0 {
+						l.InfoFields("large", String("payload", large))
+						continue
+					}
+					l.InfoFields("small", String("user", "alice"), Int("attempt", i))
+				}
+			})
+		})
+	}
+}
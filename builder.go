@@ -0,0 +1,231 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"sync"
+	"time"
+)
+
+// Builder assembles a log entry field by field and writes it on Msg, for
+// callers used to zerolog's chained style:
+//
+//	logger.Build(velo.InfoLevel).
+//	  Str("user", id).
+//	  Int("status", 200).
+//	  Dur("latency", time.Since(start)).
+//	  Msg("handled")
+//
+// Build returns nil when the level is disabled, and every method of a nil
+// Builder does nothing, so a disabled chain costs a level check.
+//
+// The Logger pools Builder values, so you must not retain or reuse one after
+// calling Msg or Send.
+//
+// Performance Note: When the Logger writes JSON straight from the log call,
+// without an Entry, the Builder encodes each field into a pooled buffer as it
+// is added, and Msg splices the encoded fields into the entry. Fields are
+// therefore captured when they are added, as with zerolog. Otherwise the
+// fields are stored in the pooled Builder and formatted once Msg is called,
+// so entries with up to 32 fields allocate nothing beyond what LogFields
+// would.
+type Builder struct {
+	logger    *Logger
+	fields    []Field
+	fieldsArr [_inlineFields]Field
+	level     Level
+
+	// cfg and buf are set while fields are encoded as they are added: cfg is
+	// the configuration the entry is written with, and buf holds the fields,
+	// each preceded by a comma. at is the time of the first EventTime field.
+	cfg *loggerConfig
+	buf *buffer
+	at  time.Time
+}
+
+var _builderPool = sync.Pool{
+	New: func() any {
+		b := &Builder{}
+		b.fields = b.fieldsArr[:0]
+		return b
+	},
+}
+
+// Build returns a Builder for an entry at the specified level, or nil if the
+// Logger would not write it.
+func (l *Logger) Build(level Level) *Builder {
+	if l == nil || (l.level.val.Load() > int64(level) && l.trigger == nil) {
+		return nil
+	}
+	b := _builderPool.Get().(*Builder)
+	b.logger = l
+	b.level = level
+	if cfg := l.config.Load(); l.level.val.Load() <= int64(level) && cfg.encodesFields(level) {
+		b.cfg = cfg
+		b.buf = getBuffer()
+	}
+	return b
+}
+
+// encodesFields reports whether a Builder can encode the fields of an entry
+// at level as they are added: whether the entry is formatted as JSON without
+// an Entry, and nothing needs to see its fields one by one.
+func (c *loggerConfig) encodesFields(level Level) bool {
+	return c.formatter == JSONFormatter && !c.needsEntry(level) && c.fieldMetrics == nil && c.maxEntryBytes == 0
+}
+
+// Build returns a Builder for an entry at the specified level using the
+// global default Logger.
+func Build(level Level) *Builder { return Default().Build(level) }
+
+// Field adds a strongly typed field, for types the Builder has no method for.
+func (b *Builder) Field(f Field) *Builder {
+	switch {
+	case b == nil:
+	case b.cfg != nil:
+		b.encode(&f)
+	default:
+		b.fields = append(b.fields, f)
+	}
+	return b
+}
+
+// Fields adds strongly typed fields.
+func (b *Builder) Fields(fields ...Field) *Builder {
+	switch {
+	case b == nil:
+	case b.cfg != nil:
+		for i := range fields {
+			b.encode(&fields[i])
+		}
+	default:
+		b.fields = append(b.fields, fields...)
+	}
+	return b
+}
+
+// encode appends f to the encoded fields, or takes the entry's timestamp from
+// it if it is the first EventTime field.
+func (b *Builder) encode(f *Field) {
+	if f.Type == TimeType && f.Any != nil {
+		if z, ok := f.Any.(eventTimeZone); ok {
+			if b.at.IsZero() {
+				b.at = time.Unix(0, f.Int).In(z.loc)
+			}
+			return
+		}
+	}
+	encodeFieldToJSON(b.buf, f, b.cfg.fieldFormat(), true)
+}
+
+// Str adds a string field.
+func (b *Builder) Str(key, val string) *Builder { return b.Field(String(key, val)) }
+
+// Int adds an integer field.
+func (b *Builder) Int(key string, val int) *Builder { return b.Field(Int(key, val)) }
+
+// Int64 adds a 64-bit integer field.
+func (b *Builder) Int64(key string, val int64) *Builder { return b.Field(Int64(key, val)) }
+
+// Bool adds a boolean field.
+func (b *Builder) Bool(key string, val bool) *Builder { return b.Field(Bool(key, val)) }
+
+// Time adds a time.Time field.
+func (b *Builder) Time(key string, val time.Time) *Builder { return b.Field(Time(key, val)) }
+
+// Dur adds a time.Duration field.
+func (b *Builder) Dur(key string, val time.Duration) *Builder { return b.Field(Duration(key, val)) }
+
+// Err adds an error field under the key "error".
+func (b *Builder) Err(err error) *Builder { return b.Field(Err(err)) }
+
+// Object adds a field implementing ObjectMarshaler.
+func (b *Builder) Object(key string, val ObjectMarshaler) *Builder {
+	return b.Field(Object(key, val))
+}
+
+// Array adds a field implementing ArrayMarshaler.
+func (b *Builder) Array(key string, val ArrayMarshaler) *Builder { return b.Field(Array(key, val)) }
+
+// Any adds a field of arbitrary type. Prefer the typed methods, which do not
+// box their values.
+func (b *Builder) Any(key string, val any) *Builder { return b.Field(Any(key, val)) }
+
+// Msg writes the entry with msg and returns the Builder to the pool.
+//
+// It is safe to call Msg on a nil Builder; it does nothing.
+func (b *Builder) Msg(msg string) { b.msg(0, msg) }
+
+// Send writes the entry with an empty message, like Msg("").
+func (b *Builder) Send() { b.msg(0, "") }
+
+// msg writes the entry and returns the Builder to the pool.
+func (b *Builder) msg(skip int, msg string) {
+	if b == nil {
+		return
+	}
+	if b.cfg != nil {
+		b.logger.outputEncoded(skip+1, b.cfg, b.level, msg, b.buf.B, b.at)
+		putBuffer(b.buf)
+		b.cfg, b.buf, b.at = nil, nil, time.Time{}
+	} else {
+		b.logger.logFields(skip+1, b.level, msg, b.fields)
+	}
+
+	clear(b.fields)
+	b.fields = b.fields[:0]
+	if cap(b.fields) > _maxRetainedFields {
+		b.fields = b.fieldsArr[:0]
+	}
+	b.logger = nil
+	_builderPool.Put(b)
+}
+
+// outputEncoded writes an entry whose call fields a Builder has encoded, like
+// output. at is the entry's event time, or zero for the current time.
+func (l *Logger) outputEncoded(skip int, cfg *loggerConfig, level Level, msg string, encoded []byte, at time.Time) {
+	t := cfg.now()
+	if !at.IsZero() {
+		t = cfg.at(at)
+	}
+	if !l.sample(cfg, level, msg, t) {
+		return
+	}
+
+	var start time.Time
+	if cfg.metrics != nil {
+		start = time.Now()
+	}
+
+	var ci *callerInfo
+	if cfg.reportCaller || cfg.developmentCaller(level) {
+		ci = cfg.callers.caller(l, cfg.callerOffset+skip+3, cfg.callerFormatter) // +1 for outputEncoded
+	}
+
+	var ctxFields []Field
+	if cfg.providers != nil {
+		ctxFields = cfg.provide(nil)
+	}
+	l.trip(cfg, l.trigger, level)
+	b := getBufferSize(cfg.sizes.estimate())
+	formatLogJSON(b, l.base.Load(), cfg, level, msg, nil, nil, encoded, ctxFields, ci, t)
+	l.write(cfg, b, level, msg, start)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestBuilderMatchesLogFields checks that a Builder writes the same entry as
+// LogFields with the same fields, whether it encodes them as they are added
+// or collects them.
+func TestBuilderMatchesLogFields(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tt := range []struct {
+		name string
+		opts []Option
+	}{
+		{"json", []Option{WithFormatter(JSONFormatter)}},
+		{"json/timestamp", []Option{WithFormatter(JSONFormatter), WithTimestamp(time.RFC3339), WithTimeLocation(time.UTC)}},
+		{"json/caller", []Option{WithFormatter(JSONFormatter), WithCaller(), WithMessagePrefix("svc"), func(o *Options) { o.CallerFormatter = FuncCallerFormatter }}},
+		{"json/limit", []Option{WithFormatter(JSONFormatter), func(o *Options) { o.MaxEntryBytes = 1 << 10 }}},
+		{"text", []Option{WithFormatter(TextFormatter), WithTimestamp(time.RFC3339)}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got, want bytes.Buffer
+			build := New(&got, tt.opts...).With("svc", "api")
+			fields := New(&want, tt.opts...).With("svc", "api")
+
+			// The caller is reported by function, which both calls share.
+			build.Build(InfoLevel).Str("user", "alice").Int("status", 200).Dur("latency", time.Second).Err(errors.New("boom")).Msg("handled")
+			fields.InfoFields("handled", String("user", "alice"), Int("status", 200), Duration("latency", time.Second), Err(errors.New("boom")))
+			build.Build(WarnLevel).Send()
+			fields.WarnFields("")
+			build.Build(ErrorLevel).Fields(Bool("ok", false), EventTime(at)).Msg("at")
+			fields.LogAt(at, ErrorLevel, "at", Bool("ok", false))
+
+			if got.String() != want.String() {
+				t.Errorf("Builder wrote\n%s\nwant\n%s", got.String(), want.String())
+			}
+		})
+	}
+}
+
+func TestBuilderDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithLevel(WarnLevel))
+	if b := l.Build(InfoLevel); b != nil {
+		t.Fatal("Build returned a Builder for a disabled level")
+	}
+	l.Build(InfoLevel).Str("k", "v").Msg("dropped")
+	if buf.Len() != 0 {
+		t.Errorf("nil Builder wrote %q", buf.String())
+	}
+}
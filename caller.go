@@ -0,0 +1,72 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"runtime"
+	"sync"
+)
+
+// callerCache memoizes formatted caller strings by program counter.
+//
+// A call site always resolves to the same file, line, and function, so after
+// the first entry from a given line the Logger skips runtime.FuncForPC and the
+// CallerFormatter entirely. The cache belongs to a single CallerFormatter and
+// caller offset; changing either starts a new cache. Its size is bounded by
+// the number of distinct logging call sites in the program.
+type callerCache struct {
+	m sync.Map // uintptr -> *callerInfo
+}
+
+// callerInfo is a resolved call site.
+type callerInfo struct {
+	file      string
+	line      int
+	fn        string
+	formatted string
+	text      string // formatted in angle brackets, as the TextFormatter writes it
+}
+
+// caller resolves the call site skip frames above its own caller, or returns
+// nil if the frame cannot be resolved.
+func (c *callerCache) caller(l *Logger, skip int, format CallerFormatter) *callerInfo {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+1, pcs[:]) == 0 {
+		return nil
+	}
+	if ci, ok := c.m.Load(pcs[0]); ok {
+		return ci.(*callerInfo)
+	}
+
+	file, line, fn := l.getCaller(skip + 1)
+	if file == "" {
+		return nil
+	}
+	ci := &callerInfo{file: file, line: line, fn: fn}
+	if format != nil {
+		ci.formatted = format(file, line, fn)
+	}
+	if ci.formatted != "" {
+		ci.text = "<" + ci.formatted + ">"
+	}
+	c.m.Store(pcs[0], ci)
+	return ci
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "sync"
+
+// CheckedEntry is a log entry that has already passed the Logger's level check.
+//
+// It lets callers guard expensive field construction idiomatically:
+//
+//	if ce := logger.Check(velo.DebugLevel, "cache state"); ce != nil {
+//	  ce.Write(velo.Object("cache", snapshot()))
+//	}
+//
+// The Logger pools CheckedEntry values, so you must not retain or reuse one
+// after calling Write.
+type CheckedEntry struct {
+	logger *Logger
+	msg    string
+	level  Level
+}
+
+var _checkedEntryPool = sync.Pool{
+	New: func() any {
+		return &CheckedEntry{}
+	},
+}
+
+// Enabled reports whether the Logger would write a message at level.
+//
+// Use it to skip building expensive arguments for disabled levels:
+//
+//	if logger.Enabled(velo.DebugLevel) {
+//	  logger.Debug("cache state", "entries", cache.Dump())
+//	}
+//
+// When the Logger writes to a Core, the Core must also enable the level. The
+// answer can change at any time through SetLevel.
+func (l *Logger) Enabled(level Level) bool {
+	if l == nil {
+		return false
+	}
+	if l.level.val.Load() > int64(level) {
+		return false
+	}
+	cfg := l.config.Load()
+	return cfg.core == nil || cfg.core.Enabled(level)
+}
+
+// DebugEnabled reports whether the Logger would write a message at DebugLevel.
+func (l *Logger) DebugEnabled() bool { return l.Enabled(DebugLevel) }
+
+// InfoEnabled reports whether the Logger would write a message at InfoLevel.
+func (l *Logger) InfoEnabled() bool { return l.Enabled(InfoLevel) }
+
+// WarnEnabled reports whether the Logger would write a message at WarnLevel.
+func (l *Logger) WarnEnabled() bool { return l.Enabled(WarnLevel) }
+
+// ErrorEnabled reports whether the Logger would write a message at ErrorLevel.
+func (l *Logger) ErrorEnabled() bool { return l.Enabled(ErrorLevel) }
+
+// Check returns a CheckedEntry if the Logger would write a message at the
+// specified level, or nil if the level is disabled, by the Logger or by its
+// Core.
+func (l *Logger) Check(level Level, msg string) *CheckedEntry {
+	if !l.Enabled(level) {
+		return nil
+	}
+	ce := _checkedEntryPool.Get().(*CheckedEntry)
+	ce.logger = l
+	ce.level = level
+	ce.msg = msg
+	return ce
+}
+
+// Write logs the checked message with the provided strongly typed fields and
+// returns the CheckedEntry to the pool.
+//
+// It is safe to call Write on a nil CheckedEntry; it does nothing.
+func (ce *CheckedEntry) Write(fields ...Field) {
+	if ce == nil {
+		return
+	}
+	l, level, msg := ce.logger, ce.level, ce.msg
+	ce.logger = nil
+	ce.msg = ""
+	_checkedEntryPool.Put(ce)
+
+	l.logFields(0, level, msg, fields)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Command velo pretty-prints JSON logs written by velo.
+//
+// Usage:
+//
+//	velo [-level level] [-fields keys] [-omit keys] [-color mode] [-time-format layout] [-input-time-format layout] [file ...]
+//
+// It reads newline-delimited JSON from the named files in order, or from
+// standard input when none are given, and renders every entry with the
+// TextFormatter on standard output:
+//
+//	kubectl logs -f deploy/api | velo -level warn -omit pid,hostname
+//
+// Entries below -level are skipped. -fields keeps only the listed fields and
+// -omit drops the listed fields, both as comma-separated keys. Lines that are
+// not velo JSON, such as panics written by the runtime, are printed as they
+// are.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"velo"
+	"velo/parse"
+)
+
+func main() {
+	level := velo.DebugLevel
+	color := velo.ColorAuto
+	flag.Var(&level, "level", "minimum level to print")
+	flag.Var(&color, "color", "color mode: auto, never, or always")
+	fields := flag.String("fields", "", "comma-separated keys of the only fields to print")
+	omit := flag.String("omit", "", "comma-separated keys of fields not to print")
+	timeFormat := flag.String("time-format", velo.DefaultTimeFormat, "output timestamp layout")
+	inputTimeFormat := flag.String("input-time-format", velo.DefaultTimeFormat, "timestamp layout of the input: a time layout, unix, or unix_milli")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: velo [flags] [file ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	p := &printer{
+		core: velo.NewCore(os.Stdout,
+			velo.WithLevel(level),
+			velo.WithTimestamp(*timeFormat),
+			velo.WithColor(color),
+		),
+		opts: parse.Options{TimeFormat: *inputTimeFormat},
+		keep: splitKeys(*fields),
+		omit: splitKeys(*omit),
+	}
+
+	if flag.NArg() == 0 {
+		p.print(os.Stdin, "stdin")
+		return
+	}
+	for _, name := range flag.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		p.print(f, name)
+		f.Close()
+	}
+}
+
+// printer renders the entries of a stream through core.
+type printer struct {
+	core velo.Core
+	opts parse.Options
+	keep []string
+	omit []string
+}
+
+// print renders every line of r, exiting on a read error.
+func (p *printer) print(r io.Reader, name string) {
+	br := bufio.NewReaderSize(r, 64<<10)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			p.line(line)
+		}
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			fatalf("%s: %v", name, err)
+		}
+	}
+}
+
+func (p *printer) line(line []byte) {
+	e, err := parse.JSON(line, p.opts)
+	if err != nil {
+		if line[len(line)-1] != '\n' {
+			line = append(line, '\n')
+		}
+		os.Stdout.Write(line)
+		return
+	}
+	if !p.core.Enabled(e.Level) {
+		return
+	}
+	if len(p.keep) > 0 || len(p.omit) > 0 {
+		e.TypedFields = slices.DeleteFunc(e.TypedFields, func(f velo.Field) bool {
+			return (len(p.keep) > 0 && !slices.Contains(p.keep, f.Key)) || slices.Contains(p.omit, f.Key)
+		})
+	}
+	p.core.Write(e)
+}
+
+func splitKeys(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "velo: "+format+"\n", args...)
+	os.Exit(1)
+}
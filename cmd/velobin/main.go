@@ -0,0 +1,91 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Command velobin converts logs written by the velo BinaryFormatter back to
+// text or JSON.
+//
+// Usage:
+//
+//	velobin [-format text|json] [-level level] [-time-format layout] [file ...]
+//
+// It reads the named files in order, or standard input when none are given,
+// and writes the converted entries to standard output. Entries below -level
+// are skipped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"velo"
+)
+
+func main() {
+	format := velo.TextFormatter
+	level := velo.DebugLevel
+	flag.Var(&format, "format", "output format: text or json")
+	flag.Var(&level, "level", "minimum level to print")
+	timeFormat := flag.String("time-format", time.RFC3339Nano, "timestamp layout")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: velobin [flags] [file ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if format == velo.BinaryFormatter {
+		fatalf("-format must be text or json")
+	}
+
+	core := velo.NewCore(os.Stdout,
+		velo.WithFormatter(format),
+		velo.WithLevel(level),
+		velo.WithTimestamp(*timeFormat),
+		velo.WithAsync(8192, velo.OverflowBlock),
+	)
+
+	if flag.NArg() == 0 {
+		replay(core, os.Stdin, "stdin")
+		return
+	}
+	for _, name := range flag.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		replay(core, f, name)
+		f.Close()
+	}
+}
+
+// replay converts every record in r, exiting on the first malformed one.
+func replay(core velo.Core, r io.Reader, name string) {
+	if err := velo.NewBinaryDecoder(r).Replay(core); err != nil {
+		core.Sync()
+		fatalf("%s: %v", name, err)
+	}
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "velobin: "+format+"\n", args...)
+	os.Exit(1)
+}
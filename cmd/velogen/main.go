@@ -0,0 +1,292 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Command velogen generates typed logging functions from event definitions.
+//
+// Usage:
+//
+//	velogen [-o file] [-package name] definitions.json
+//
+// The definitions name a package and its events. Each event has a Go name, a
+// level, a message, and typed fields:
+//
+//	{
+//		"package": "events",
+//		"events": [{
+//			"name": "RequestCompleted",
+//			"level": "info",
+//			"message": "request completed",
+//			"doc": "RequestCompleted records a served HTTP request.",
+//			"fields": [
+//				{"name": "status", "type": "int"},
+//				{"name": "dur", "key": "duration", "type": "time.Duration"}
+//			]
+//		}]
+//	}
+//
+// For every event velogen writes a function taking the Logger followed by
+// one parameter per field, which logs the message through LogFieldsWithSkip
+// with the fields built by their typed constructors:
+//
+//	events.RequestCompleted(log, 200, time.Since(start))
+//
+// Call sites therefore agree on keys and value types at compile time. A
+// field's key defaults to its name. The supported types are string, int,
+// int64, bool, error, time.Time, time.Duration, []int, []string,
+// []time.Time, velo.ObjectMarshaler, velo.ArrayMarshaler, and any; the
+// numeric types float32, float64, int8, int16, int32, uint, uint8, uint16,
+// uint32, and uint64 are logged with velo.Any.
+//
+// The output is written to -o, or standard output when it is unset, and is
+// meant to be produced by a go:generate directive:
+//
+//	//go:generate go run velo/cmd/velogen -o events_gen.go events.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"velo"
+)
+
+// definitions is the input document.
+type definitions struct {
+	Package string  `json:"package"`
+	Events  []event `json:"events"`
+}
+
+type event struct {
+	Name    string     `json:"name"`
+	Level   velo.Level `json:"level"`
+	Message string     `json:"message"`
+	Doc     string     `json:"doc"`
+	Fields  []field    `json:"fields"`
+}
+
+type field struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	Type string `json:"type"`
+}
+
+// constructors maps each supported field type to the expression building its
+// Field from a key and a value, in that order.
+var constructors = map[string]string{
+	"string":               "velo.String(%s, %s)",
+	"int":                  "velo.Int(%s, %s)",
+	"int64":                "velo.Int64(%s, %s)",
+	"bool":                 "velo.Bool(%s, %s)",
+	"error":                "velo.Field{Key: %s, Type: velo.ErrorType, Any: %s}",
+	"time.Time":            "velo.Time(%s, %s)",
+	"time.Duration":        "velo.Duration(%s, %s)",
+	"[]int":                "velo.Ints(%s, %s)",
+	"[]string":             "velo.Strings(%s, %s)",
+	"[]time.Time":          "velo.Times(%s, %s)",
+	"velo.ObjectMarshaler": "velo.Object(%s, %s)",
+	"velo.ArrayMarshaler":  "velo.Array(%s, %s)",
+	"any":                  "velo.Any(%s, %s)",
+	"float32":              "velo.Any(%s, %s)",
+	"float64":              "velo.Any(%s, %s)",
+	"int8":                 "velo.Any(%s, %s)",
+	"int16":                "velo.Any(%s, %s)",
+	"int32":                "velo.Any(%s, %s)",
+	"uint":                 "velo.Any(%s, %s)",
+	"uint8":                "velo.Any(%s, %s)",
+	"uint16":               "velo.Any(%s, %s)",
+	"uint32":               "velo.Any(%s, %s)",
+	"uint64":               "velo.Any(%s, %s)",
+}
+
+// levelNames maps each level to the name of its constant.
+var levelNames = map[velo.Level]string{
+	velo.DebugLevel:  "DebugLevel",
+	velo.InfoLevel:   "InfoLevel",
+	velo.WarnLevel:   "WarnLevel",
+	velo.ErrorLevel:  "ErrorLevel",
+	velo.DPanicLevel: "DPanicLevel",
+	velo.PanicLevel:  "PanicLevel",
+	velo.FatalLevel:  "FatalLevel",
+}
+
+// reserved are the parameter names that would shadow the Logger or a package
+// used by the generated code.
+var reserved = []string{"log", "velo", "time"}
+
+func main() {
+	out := flag.String("o", "", "output file (default standard output)")
+	pkg := flag.String("package", "", "package name, overriding the definitions")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: velogen [flags] definitions.json\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	input := flag.Arg(0)
+	data, err := os.ReadFile(input)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	defs, err := load(data, *pkg)
+	if err != nil {
+		fatalf("%s: %v", input, err)
+	}
+
+	src, err := format.Source(defs.generate(os.Args[1:]))
+	if err != nil {
+		fatalf("formatting output: %v", err)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fatalf("%v", err)
+	}
+}
+
+// load decodes and validates the definitions in data. A non-empty pkg
+// replaces their package name.
+func load(data []byte, pkg string) (*definitions, error) {
+	var defs definitions
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&defs); err != nil {
+		return nil, err
+	}
+	if pkg != "" {
+		defs.Package = pkg
+	}
+	if err := defs.validate(); err != nil {
+		return nil, err
+	}
+	return &defs, nil
+}
+
+// validate reports the first definition that would not compile or would
+// repeat a key or function name.
+func (d *definitions) validate() error {
+	if !token.IsIdentifier(d.Package) {
+		return fmt.Errorf("invalid package name %q", d.Package)
+	}
+	if len(d.Events) == 0 {
+		return errors.New("no events defined")
+	}
+	names := make(map[string]bool, len(d.Events))
+	for i := range d.Events {
+		e := &d.Events[i]
+		if !token.IsIdentifier(e.Name) || !token.IsExported(e.Name) {
+			return fmt.Errorf("event %q: name must be an exported identifier", e.Name)
+		}
+		if names[e.Name] {
+			return fmt.Errorf("event %q: defined twice", e.Name)
+		}
+		names[e.Name] = true
+		if _, ok := levelNames[e.Level]; !ok {
+			return fmt.Errorf("event %q: invalid level %v", e.Name, e.Level)
+		}
+		if e.Message == "" {
+			return fmt.Errorf("event %q: empty message", e.Name)
+		}
+
+		params := make(map[string]bool, len(e.Fields))
+		keys := make(map[string]bool, len(e.Fields))
+		for j := range e.Fields {
+			f := &e.Fields[j]
+			if !token.IsIdentifier(f.Name) || slices.Contains(reserved, f.Name) {
+				return fmt.Errorf("event %q: invalid field name %q", e.Name, f.Name)
+			}
+			if f.Key == "" {
+				f.Key = f.Name
+			}
+			if params[f.Name] || keys[f.Key] {
+				return fmt.Errorf("event %q: field %q defined twice", e.Name, f.Name)
+			}
+			params[f.Name], keys[f.Key] = true, true
+			if _, ok := constructors[f.Type]; !ok {
+				return fmt.Errorf("event %q: field %q has unsupported type %q", e.Name, f.Name, f.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// generate renders the unformatted source of the package.
+func (d *definitions) generate(args []string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by velogen %s; DO NOT EDIT.\n\n", strings.Join(args, " "))
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", d.Package)
+	if d.usesTime() {
+		b.WriteString("\t\"time\"\n\n")
+	}
+	b.WriteString("\t\"velo\"\n)\n")
+
+	for _, e := range d.Events {
+		b.WriteString("\n")
+		if e.Doc != "" {
+			for line := range strings.SplitSeq(strings.TrimSpace(e.Doc), "\n") {
+				fmt.Fprintf(&b, "// %s\n", strings.TrimSpace(line))
+			}
+		} else {
+			fmt.Fprintf(&b, "// %s logs %s at %s.\n", e.Name, strconv.Quote(e.Message), levelNames[e.Level])
+		}
+
+		fmt.Fprintf(&b, "func %s(log *velo.Logger", e.Name)
+		for _, f := range e.Fields {
+			fmt.Fprintf(&b, ", %s %s", f.Name, f.Type)
+		}
+		fmt.Fprintf(&b, ") {\n\tlog.LogFieldsWithSkip(1, velo.%s, %s", levelNames[e.Level], strconv.Quote(e.Message))
+		for _, f := range e.Fields {
+			b.WriteString(",\n\t\t")
+			fmt.Fprintf(&b, constructors[f.Type], strconv.Quote(f.Key), f.Name)
+		}
+		b.WriteString(")\n}\n")
+	}
+	return b.Bytes()
+}
+
+func (d *definitions) usesTime() bool {
+	for _, e := range d.Events {
+		for _, f := range e.Fields {
+			if strings.Contains(f.Type, "time.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "velogen: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"velo/velotest"
+)
+
+// TestGenerate renders testdata/events.json and compares the result with
+// testdata/events.golden. Set VELOTEST_UPDATE=1 to rewrite the golden file.
+func TestGenerate(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "events.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defs, err := load(data, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := format.Source(defs.generate([]string{"events.json"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	velotest.AssertGolden(t, "events", src)
+
+	// The output is gofmt'ed and compiles against velo.
+	if formatted, err := format.Source(src); err != nil || !bytes.Equal(formatted, src) {
+		t.Errorf("output is not gofmt'ed: %v", err)
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "events_gen.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("events", fset, []*ast.File{f}, nil); err != nil {
+		t.Errorf("output does not compile: %v", err)
+	}
+}
+
+func TestLoadPackageOverride(t *testing.T) {
+	defs, err := load([]byte(`{"package": "events", "events": [{"name": "Started", "message": "started"}]}`), "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if defs.Package != "audit" {
+		t.Errorf("package = %q, want the override", defs.Package)
+	}
+	if src := string(defs.generate(nil)); !strings.Contains(src, "velo.InfoLevel") {
+		t.Errorf("an event without a level is not logged at InfoLevel:\n%s", src)
+	}
+}
+
+func TestLoadRejectsInvalidDefinitions(t *testing.T) {
+	for _, tt := range []struct{ name, json, err string }{
+		{"package", `{"package": "my-events", "events": [{"name": "A", "message": "a"}]}`, "invalid package name"},
+		{"no events", `{"package": "events"}`, "no events defined"},
+		{"unexported name", `{"package": "events", "events": [{"name": "started", "message": "a"}]}`, "exported identifier"},
+		{"duplicate name", `{"package": "events", "events": [{"name": "A", "message": "a"}, {"name": "A", "message": "b"}]}`, "defined twice"},
+		{"level", `{"package": "events", "events": [{"name": "A", "level": "loud", "message": "a"}]}`, "unrecognized level"},
+		{"empty message", `{"package": "events", "events": [{"name": "A"}]}`, "empty message"},
+		{"field name", `{"package": "events", "events": [{"name": "A", "message": "a", "fields": [{"name": "max-rows", "type": "int"}]}]}`, "invalid field name"},
+		{"reserved field name", `{"package": "events", "events": [{"name": "A", "message": "a", "fields": [{"name": "log", "type": "int"}]}]}`, "invalid field name"},
+		{"duplicate key", `{"package": "events", "events": [{"name": "A", "message": "a", "fields": [{"name": "a", "key": "id", "type": "int"}, {"name": "b", "key": "id", "type": "int"}]}]}`, "defined twice"},
+		{"type", `{"package": "events", "events": [{"name": "A", "message": "a", "fields": [{"name": "ip", "type": "net.IP"}]}]}`, "unsupported type"},
+		{"unknown field", `{"package": "events", "events": [{"name": "A", "message": "a", "levle": "warn"}]}`, "unknown field"},
+	} {
+		_, err := load([]byte(tt.json), "")
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: load() = %v, want an error containing %q", tt.name, err, tt.err)
+		}
+	}
+}
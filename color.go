@@ -0,0 +1,174 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ColorMode controls whether the TextFormatter emits ANSI escape sequences.
+type ColorMode int
+
+const (
+	// ColorAuto honors the NO_COLOR, FORCE_COLOR, CLICOLOR_FORCE, and CLICOLOR
+	// conventions, and otherwise enables colors only when the Logger's writer
+	// is a terminal. This is the default.
+	ColorAuto ColorMode = iota
+	// ColorNever disables all ANSI escape sequences.
+	ColorNever
+	// ColorAlways enables colors even when the writer is not a terminal.
+	ColorAlways
+)
+
+// String returns the lowercase ASCII representation of the color mode.
+func (m ColorMode) String() string {
+	switch m {
+	case ColorAuto:
+		return "auto"
+	case ColorNever:
+		return "never"
+	case ColorAlways:
+		return "always"
+	default:
+		return fmt.Sprintf("ColorMode(%d)", int(m))
+	}
+}
+
+// MarshalText serializes the ColorMode to its lowercase name.
+func (m ColorMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText deserializes "auto", "never", or "always", in any case, into a
+// ColorMode. An empty string selects ColorAuto.
+func (m *ColorMode) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "auto", "":
+		*m = ColorAuto
+	case "never":
+		*m = ColorNever
+	case "always":
+		*m = ColorAlways
+	default:
+		return fmt.Errorf("unrecognized color mode: %q", text)
+	}
+	return nil
+}
+
+var (
+	_plainStyles = sync.OnceValue(PlainStyles)
+	_forceStyles = sync.OnceValue(func() *Styles {
+		profile := ANSI256Color
+		if mode, p := envColorMode(); mode == ColorAlways {
+			profile = p
+		}
+		return newStyles(profile)
+	})
+)
+
+// envColorMode reads the NO_COLOR, FORCE_COLOR, CLICOLOR_FORCE, and CLICOLOR conventions.
+//
+// An explicit request to force colors wins, because it is the more specific
+// instruction. Otherwise NO_COLOR (any non-empty value) or CLICOLOR=0 disable
+// colors, and everything else falls back to terminal detection.
+func envColorMode() (ColorMode, ColorProfile) {
+	if v := os.Getenv("FORCE_COLOR"); v != "" && v != "0" && v != "false" {
+		switch v {
+		case "1":
+			return ColorAlways, ANSIColor
+		case "3":
+			return ColorAlways, TrueColor
+		default:
+			return ColorAlways, ANSI256Color
+		}
+	}
+	if v := os.Getenv("CLICOLOR_FORCE"); v != "" && v != "0" {
+		return ColorAlways, ANSI256Color
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("CLICOLOR") == "0" {
+		return ColorNever, NoColor
+	}
+	return ColorAuto, NoColor
+}
+
+// detectProfile returns the color profile for w, honoring the color
+// environment variables before inspecting the terminal.
+//
+// Terminals advertise their capabilities through COLORTERM and TERM; a
+// terminal that advertises nothing is assumed to support the 16 standard
+// colors.
+func detectProfile(w io.Writer) ColorProfile {
+	if mode, profile := envColorMode(); mode != ColorAuto {
+		return profile
+	}
+	if !isTerminal(w) {
+		return NoColor
+	}
+	term := os.Getenv("TERM")
+	switch colorterm := strings.ToLower(os.Getenv("COLORTERM")); {
+	case term == "dumb":
+		return NoColor
+	case colorterm == "truecolor" || colorterm == "24bit":
+		return TrueColor
+	case strings.Contains(term, "256color"), colorterm != "", runtime.GOOS == "windows":
+		return ANSI256Color
+	default:
+		return ANSIColor
+	}
+}
+
+// stylesFor picks the styles a Logger writing to w uses when Options.Styles is unset.
+//
+// It returns nil to select the global default styles, PlainStyles when colors
+// are disabled, or a forced color variant when ColorAlways overrides detection.
+// On Windows, it also enables ANSI processing on console writers, falling back
+// to PlainStyles on consoles that do not support it.
+func stylesFor(w io.Writer, mode ColorMode) *Styles {
+	if mode == ColorAuto {
+		mode, _ = envColorMode()
+	}
+	switch mode {
+	case ColorNever:
+		return _plainStyles()
+	case ColorAlways:
+		enableVirtualTerminal(w)
+		return _forceStyles()
+	default:
+		if isTerminal(w) && enableVirtualTerminal(w) {
+			return nil
+		}
+		return _plainStyles()
+	}
+}
+
+// isTerminal reports whether w is a file attached to a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isTerminalFile(f)
+}
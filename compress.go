@@ -0,0 +1,218 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// Compressor is a streaming compressor writing one frame to its underlying
+// writer between Reset and Close, such as *gzip.Writer or the *zstd.Encoder of
+// github.com/klauspost/compress. Close must finish the frame without closing
+// the underlying writer.
+type Compressor interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// DefaultCompressFrameSize is the frame size of a CompressWriter when
+// CompressOptions.FrameSize is unset.
+const DefaultCompressFrameSize = 4 << 20
+
+// DefaultCompressFlushInterval is the flush interval of a CompressWriter when
+// CompressOptions.FlushInterval is unset.
+const DefaultCompressFlushInterval = time.Second
+
+// CompressOptions configures NewCompressWriter.
+type CompressOptions struct {
+	// NewCompressor creates the compressor writing frames to its argument. It
+	// defaults to gzip at the default compression level; see GzipCompressor.
+	// For zstd, wrap an encoder from github.com/klauspost/compress:
+	//
+	//	NewCompressor: func(w io.Writer) (velo.Compressor, error) { return zstd.NewWriter(w) }
+	NewCompressor func(w io.Writer) (Compressor, error)
+
+	// FrameSize is the number of uncompressed bytes after which the current
+	// frame is finished and a new one started. It defaults to
+	// DefaultCompressFrameSize.
+	FrameSize int
+
+	// FlushInterval is how long written data may wait in the compressor before
+	// its frame is finished. It defaults to DefaultCompressFlushInterval; a
+	// negative value disables periodic flushing.
+	FlushInterval time.Duration
+}
+
+// GzipCompressor returns a CompressOptions.NewCompressor producing gzip
+// members at level, one of the levels of compress/gzip.
+func GzipCompressor(level int) func(w io.Writer) (Compressor, error) {
+	return func(w io.Writer) (Compressor, error) {
+		return gzip.NewWriterLevel(w, level)
+	}
+}
+
+// CompressWriter compresses log output on its way to an underlying writer, for
+// high-volume file logging where disk is the constraint.
+//
+// The output is a sequence of self-contained frames, such as gzip members,
+// which standard tools decompress as one stream. A frame is finished once it
+// holds FrameSize uncompressed bytes, when FlushInterval has passed since its
+// first write, and on Sync, always at a write boundary. A Logger, synchronous
+// or asynchronous, only writes whole entries, so entries never span frames.
+// If the process dies, everything up to the last finished frame remains
+// decompressible.
+//
+// Use it as the writer of a Logger, including an asynchronous one, whose
+// worker compresses off the logging goroutines:
+//
+//	f, _ := os.OpenFile("app.log.gz", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+//	cw, _ := velo.NewCompressWriter(f, velo.CompressOptions{})
+//	logger := velo.New(cw, velo.WithAsync(8192, velo.OverflowBlock))
+//	defer cw.Close()
+//	defer logger.Close()
+//
+// It is safe for concurrent use.
+type CompressWriter struct {
+	mu       sync.Mutex
+	out      io.Writer
+	c        Compressor
+	open     bool
+	written  int
+	size     int
+	interval time.Duration
+	timer    *time.Timer
+	err      error
+	closed   bool
+}
+
+// NewCompressWriter returns a CompressWriter writing the frames to w.
+func NewCompressWriter(w io.Writer, o CompressOptions) (*CompressWriter, error) {
+	if o.FrameSize < 0 {
+		return nil, errors.New("velo: FrameSize must not be negative")
+	}
+	if o.NewCompressor == nil {
+		o.NewCompressor = GzipCompressor(gzip.DefaultCompression)
+	}
+	if o.FrameSize == 0 {
+		o.FrameSize = DefaultCompressFrameSize
+	}
+	if o.FlushInterval == 0 {
+		o.FlushInterval = DefaultCompressFlushInterval
+	}
+	c, err := o.NewCompressor(w)
+	if err != nil {
+		return nil, err
+	}
+	return &CompressWriter{out: w, c: c, open: true, size: o.FrameSize, interval: o.FlushInterval}, nil
+}
+
+// Write compresses p into the current frame, finishing the frame afterwards
+// if it has reached the frame size. It returns the error of an earlier
+// failed frame, if any.
+func (w *CompressWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, errors.New("velo: write to closed CompressWriter")
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	if !w.open {
+		w.c.Reset(w.out)
+		w.open = true
+	}
+	n, err := w.c.Write(p)
+	w.written += n
+	if err != nil {
+		w.err = err
+		return n, err
+	}
+	if w.written >= w.size {
+		return n, w.finish()
+	}
+	if w.timer == nil && w.interval > 0 {
+		w.timer = time.AfterFunc(w.interval, w.flush)
+	}
+	return n, nil
+}
+
+// finish closes the current frame. w.mu must be held.
+func (w *CompressWriter) finish() error {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	if w.written == 0 {
+		return w.err
+	}
+	w.open, w.written = false, 0
+	if err := w.c.Close(); err != nil && w.err == nil {
+		w.err = err
+	}
+	return w.err
+}
+
+// flush finishes the current frame when the flush interval expires.
+func (w *CompressWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timer = nil
+	if !w.closed {
+		w.finish()
+	}
+}
+
+// Sync finishes the current frame and syncs the underlying writer if it
+// has a Sync method.
+func (w *CompressWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.finish(); err != nil {
+		return err
+	}
+	if s, ok := w.out.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// Close finishes the current frame and closes the underlying writer if it is
+// an io.Closer. Close the Logger writing to w first.
+func (w *CompressWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	err := w.finish()
+	if c, ok := w.out.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
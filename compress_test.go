@@ -0,0 +1,83 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestCompressWriterFramesHoldWholeEntries logs entries of varied sizes
+// through an asynchronous Logger, whose worker buffers its writes, and checks
+// that every frame decompresses to whole lines.
+func TestCompressWriterFramesHoldWholeEntries(t *testing.T) {
+	var out bytes.Buffer
+	cw, err := NewCompressWriter(&out, CompressOptions{FrameSize: 10 << 10, FlushInterval: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(cw, WithAsync(64, OverflowBlock))
+	const entries = 500
+	for i := range entries {
+		l.Info("entry", "i", i, "pad", strings.Repeat("x", i*37%3000))
+	}
+	l.Close()
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := gzip.NewReader(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames, lines := 0, 0
+	for {
+		zr.Multistream(false)
+		frame, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames++
+		if !bytes.HasSuffix(frame, []byte("\n")) {
+			t.Fatalf("frame %d ends mid-entry: %q", frames, frame[max(len(frame)-40, 0):])
+		}
+		sc := bufio.NewScanner(bytes.NewReader(frame))
+		sc.Buffer(nil, 1<<20)
+		for sc.Scan() {
+			if !strings.HasPrefix(sc.Text(), "INFO entry i=") {
+				t.Fatalf("frame %d starts mid-entry: %q", frames, sc.Text()[:min(len(sc.Text()), 40)])
+			}
+			lines++
+		}
+		if err := zr.Reset(&out); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if lines != entries || frames < 2 {
+		t.Errorf("read %d entries from %d frames, want %d entries from several frames", lines, frames, entries)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+// Config is a declarative, serializable description of a Logger.
+//
+// It carries JSON and YAML struct tags so services can load logger settings
+// from their existing configuration files and call Build, instead of wiring
+// Options by hand. Levels, formats, overflow strategies, and color modes
+// decode from their lowercase names, such as "warn", "json", "drop", and
+// "never".
+//
+//	var cfg velo.Config
+//	if err := json.Unmarshal(data, &cfg); err != nil {
+//	  return err
+//	}
+//	logger, err := cfg.Build()
+//
+// Hooks that cannot be serialized, such as Observer or ContextExtractor, can
+// be set on the Options returned by the Options method before calling
+// NewWithOptions.
+type Config struct {
+	// Level sets the minimum logging priority. It defaults to InfoLevel.
+	Level Level `json:"level" yaml:"level"`
+
+	// Format selects the Formatter, "text", "json", or "binary". It defaults to "text".
+	Format Formatter `json:"format" yaml:"format"`
+
+	// OutputPaths lists the destinations for log data. "stdout" and "stderr"
+	// name the standard streams; any other value is a file path, opened for
+	// appending. It defaults to standard error.
+	OutputPaths []string `json:"outputPaths" yaml:"outputPaths"`
+
+	// Color controls ANSI styling for the text format: "auto", "never", or
+	// "always".
+	Color ColorMode `json:"color" yaml:"color"`
+
+	// Timestamp includes a timestamp in every log entry.
+	Timestamp bool `json:"timestamp" yaml:"timestamp"`
+
+	// TimeFormat specifies the layout string for timestamps. It defaults to
+	// DefaultTimeFormat.
+	TimeFormat string `json:"timeFormat" yaml:"timeFormat"`
+
+	// Caller includes the calling file and line number in every log entry.
+	Caller bool `json:"caller" yaml:"caller"`
+
+	// Stacktrace includes a stack trace for entries at ErrorLevel or higher.
+	Stacktrace bool `json:"stacktrace" yaml:"stacktrace"`
+
+	// Prefix prepends a static string to every log message.
+	Prefix string `json:"prefix" yaml:"prefix"`
+
+	// Name is the dot-separated name of the Logger, written under NameKey.
+	Name string `json:"name" yaml:"name"`
+
+	// Fields attaches default fields to every log entry, in key order.
+	Fields map[string]any `json:"fields" yaml:"fields"`
+
+	// HostInfo attaches the hostname, process ID, and service name and
+	// version to every log entry. See Options.IncludeHostInfo.
+	HostInfo bool `json:"hostInfo" yaml:"hostInfo"`
+
+	// Service and Version override the service name and version attached by
+	// HostInfo.
+	Service string `json:"service" yaml:"service"`
+	Version string `json:"version" yaml:"version"`
+
+	// Fatal dictates what happens after a FatalLevel entry: "exit", "panic",
+	// or "callback". It defaults to "exit".
+	Fatal FatalBehavior `json:"fatal" yaml:"fatal"`
+
+	// FatalExitCode is the exit status used by "exit". It defaults to 1.
+	FatalExitCode int `json:"fatalExitCode" yaml:"fatalExitCode"`
+
+	// Sampling, when set, wraps the Logger with NewSamplerWithOptions.
+	Sampling *SamplingConfig `json:"sampling" yaml:"sampling"`
+
+	// Rotation, when set, rotates every file in OutputPaths by size.
+	Rotation *RotationConfig `json:"rotation" yaml:"rotation"`
+
+	// Async, when set, routes entries through the background worker.
+	Async *AsyncConfig `json:"async" yaml:"async"`
+}
+
+// SamplingConfig configures the sampler applied by Config.Build.
+//
+// The first Initial entries with the same level and message in each Tick are
+// logged, then every Thereafter-th entry. See NewSamplerWithOptions.
+type SamplingConfig struct {
+	Initial    int `json:"initial" yaml:"initial"`
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+
+	// Tick is the sampling interval. It defaults to one second. In JSON it
+	// is a number of nanoseconds; YAML also accepts strings such as "1s".
+	Tick time.Duration `json:"tick" yaml:"tick"`
+}
+
+// tick returns the sampling interval, defaulting to one second.
+func (s *SamplingConfig) tick() time.Duration {
+	if s.Tick == 0 {
+		return time.Second
+	}
+	return s.Tick
+}
+
+// validate reports an error if any value in s is negative. A nil s is valid.
+func (s *SamplingConfig) validate() error {
+	if s != nil && (s.Initial < 0 || s.Thereafter < 0 || s.Tick < 0) {
+		return errors.New("velo: sampling values must not be negative")
+	}
+	return nil
+}
+
+// RotationConfig configures size based rotation of file outputs.
+type RotationConfig struct {
+	// MaxSize is the size in megabytes at which a file is rotated.
+	MaxSize int `json:"maxSize" yaml:"maxSize"`
+
+	// MaxBackups is the number of rotated files to keep, named path.1,
+	// path.2, and so on, from newest to oldest. Zero keeps none.
+	MaxBackups int `json:"maxBackups" yaml:"maxBackups"`
+}
+
+// AsyncConfig configures the background worker of an asynchronous Logger.
+type AsyncConfig struct {
+	// BufferSize defines the capacity of the queue. It defaults to 8192.
+	BufferSize int `json:"bufferSize" yaml:"bufferSize"`
+
+	// Overflow dictates behavior when the queue fills up: "sync", "drop", or
+	// "block". It defaults to "sync".
+	Overflow OverflowStrategy `json:"overflow" yaml:"overflow"`
+}
+
+// Options converts the Config into Options, without opening any outputs.
+func (c Config) Options() Options {
+	o := Options{
+		Level:            c.Level,
+		Formatter:        c.Format,
+		Color:            c.Color,
+		ReportTimestamp:  c.Timestamp,
+		TimeFormat:       c.TimeFormat,
+		ReportCaller:     c.Caller,
+		ReportStacktrace: c.Stacktrace,
+		Prefix:           c.Prefix,
+		Name:             c.Name,
+		IncludeHostInfo:  c.HostInfo,
+		ServiceName:      c.Service,
+		ServiceVersion:   c.Version,
+		FatalBehavior:    c.Fatal,
+		FatalExitCode:    c.FatalExitCode,
+	}
+	if len(c.Fields) > 0 {
+		keys := make([]string, 0, len(c.Fields))
+		for k := range c.Fields {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		o.Fields = make([]any, 0, 2*len(keys))
+		for _, k := range keys {
+			o.Fields = append(o.Fields, k, c.Fields[k])
+		}
+	}
+	if c.Async != nil {
+		o.Async = true
+		o.BufferSize = c.Async.BufferSize
+		o.OverflowStrategy = c.Async.Overflow
+	}
+	return o
+}
+
+// Build validates the Config, opens its outputs, and constructs a Logger.
+//
+// It returns an error, without leaving any files open, if a value is out of
+// range or an output cannot be opened. Close the Logger as usual; Build does
+// not close the files it opened, since they typically live as long as the
+// process.
+func (c Config) Build() (*Logger, error) {
+	o := c.Options()
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if err := o.normalize(); err != nil {
+		return nil, err
+	}
+	w, err := c.openOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	l := NewWithOptions(w, o)
+	if s := c.Sampling; s != nil {
+		sampled := NewSamplerWithOptions(l, s.tick(), s.Initial, s.Thereafter)
+		l.Close()
+		l = sampled
+	}
+	return l, nil
+}
+
+// validate reports the first out of range value in the sections of c that
+// have no counterpart in Options.
+func (c Config) validate() error {
+	if err := c.Sampling.validate(); err != nil {
+		return err
+	}
+	if r := c.Rotation; r != nil && (r.MaxSize <= 0 || r.MaxBackups < 0) {
+		return errors.New("velo: rotation requires a positive maxSize and a non-negative maxBackups")
+	}
+	return nil
+}
+
+// openOutputs opens every path in c.OutputPaths and combines them into a
+// single writer.
+func (c Config) openOutputs() (io.Writer, error) {
+	if len(c.OutputPaths) == 0 {
+		return os.Stderr, nil
+	}
+	var (
+		ws     []io.Writer
+		opened []io.Closer
+	)
+	for _, path := range c.OutputPaths {
+		var (
+			w   io.Writer
+			err error
+		)
+		switch path {
+		case "stdout":
+			w = os.Stdout
+		case "stderr":
+			w = os.Stderr
+		case "":
+			err = errors.New("empty output path")
+		default:
+			if r := c.Rotation; r != nil {
+				var rf *rotatingFile
+				if rf, err = openRotatingFile(path, int64(r.MaxSize)<<20, r.MaxBackups); err == nil {
+					w = rf
+					opened = append(opened, rf)
+				}
+			} else {
+				var f *os.File
+				if f, err = openLogFile(path); err == nil {
+					w = f
+					opened = append(opened, f)
+				}
+			}
+		}
+		if err != nil {
+			for _, f := range opened {
+				f.Close()
+			}
+			return nil, fmt.Errorf("velo: open output %q: %w", path, err)
+		}
+		ws = append(ws, w)
+	}
+	if len(ws) == 1 {
+		return ws[0], nil
+	}
+	return multiWriter(ws), nil
+}
+
+// multiWriter duplicates writes to every writer, like io.MultiWriter, and
+// forwards Sync to those that support it.
+type multiWriter []io.Writer
+
+func (m multiWriter) Write(p []byte) (int, error) {
+	for _, w := range m {
+		n, err := w.Write(p)
+		if err != nil {
+			return n, err
+		}
+		if n != len(p) {
+			return n, io.ErrShortWrite
+		}
+	}
+	return len(p), nil
+}
+
+// Sync flushes every writer that implements Sync, returning the first error.
+func (m multiWriter) Sync() error {
+	var first error
+	for _, w := range m {
+		if s, ok := w.(interface{ Sync() error }); ok {
+			if err := s.Sync(); err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
@@ -41,6 +41,8 @@ func WithContext(ctx context.Context, logger *Logger) context.Context {
 // It returns the global default Logger if the context does not contain one.
 // Use this to retrieve a request scoped Logger injected by middleware. This
 // ensures your application always has a valid Logger instance to write to.
+// Log through LogContext or LogContextFields with the same context to attach
+// its correlation ID.
 func FromContext(ctx context.Context) *Logger {
 	if logger, ok := ctx.Value(_contextKeyInstance).(*Logger); ok {
 		return logger
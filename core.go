@@ -0,0 +1,250 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"sync/atomic"
+)
+
+// Core is a destination for fully assembled entries: a level filter, an
+// encoder, and a writer.
+//
+// A Logger built with NewWithCore or Options.Core assembles entries, with
+// their fields, caller, and stack trace, according to its own Options and
+// hands them to its Core instead of formatting them itself. Combine cores
+// with NewTee to send one stream of entries to several destinations, each
+// with its own level, format, and writer:
+//
+//	file := velo.NewCore(f, velo.WithLevel(velo.DebugLevel), velo.WithFormatter(velo.JSONFormatter))
+//	console := velo.NewCore(os.Stderr, velo.WithLevel(velo.InfoLevel))
+//	logger := velo.NewWithCore(velo.NewTee(file, console), velo.WithCaller())
+//
+// Write must not retain the Entry, which is pooled, after it returns.
+// Implementations must be safe for concurrent use.
+type Core interface {
+	// Enabled reports whether the Core writes entries at level.
+	Enabled(level Level) bool
+	// With returns a Core that adds fields to every entry it writes.
+	With(fields []Field) Core
+	// Write encodes and writes e.
+	Write(e *Entry) error
+	// Sync flushes any buffered entries.
+	Sync() error
+}
+
+// NewCore returns a Core that formats entries with a Logger writing to w,
+// configured by opts.
+//
+// The Logger decides how an entry is presented: its Level, Formatter, Styles,
+// Color, TimeFormat, TextLayout, LevelLabels, CallerObject, SortFields,
+// Fields, Hooks, Processors, and asynchronous writing all apply. What an
+// entry carries, such as a timestamp, caller, or stack trace, is decided by
+// the Logger that produced it.
+func NewCore(w io.Writer, opts ...Option) Core {
+	return New(w, opts...).Core()
+}
+
+// Core returns a view of l as a Core, for use with NewTee or NewWithCore.
+//
+// Closing a Logger that uses the Core also closes l.
+func (l *Logger) Core() Core {
+	return loggerCore{l}
+}
+
+// loggerCore adapts a Logger to the Core interface.
+type loggerCore struct {
+	l *Logger
+}
+
+func (c loggerCore) Enabled(level Level) bool {
+	return c.l != nil && c.l.level.val.Load() <= int64(level)
+}
+
+func (c loggerCore) With(fields []Field) Core {
+	return loggerCore{c.l.WithFields(fields...)}
+}
+
+func (c loggerCore) Write(e *Entry) error {
+	if c.Enabled(e.Level) {
+		c.l.writeCoreEntry(e)
+	}
+	return nil
+}
+
+func (c loggerCore) Sync() error {
+	return c.l.Sync()
+}
+
+func (c loggerCore) close() {
+	c.l.Close()
+}
+
+// writeCoreEntry formats an entry assembled by another Logger as if l had
+// produced it, adding l's own fields first.
+func (l *Logger) writeCoreEntry(src *Entry) {
+	cfg := l.config.Load()
+	e := getEntry()
+	e.Level = src.Level
+	e.Time = src.Time
+	e.Message = src.Message
+	e.Prefix = src.Prefix
+	if e.Prefix == "" {
+		e.Prefix = cfg.prefix
+	}
+	e.Sequence = src.Sequence
+	e.Caller = src.Caller
+	e.CallerFile, e.CallerLine, e.CallerFunc = src.CallerFile, src.CallerLine, src.CallerFunc
+	e.Stack = append(e.Stack[:0], src.Stack...)
+	e.StackDepth = src.StackDepth
+	e.StackFilter = src.StackFilter
+	e.Goroutines = src.Goroutines
+	e.Formatter = cfg.formatter
+	e.encoder = cfg.encoder
+	e.TimeFormat = cfg.timeFormat
+	e.timeLocation = cfg.timeLocation
+	e.durations = cfg.durations
+	e.Styles = cfg.styles
+	e.Layout = cfg.layout
+	e.callerObject = cfg.callerObject
+	e.deferStack = l.worker != nil && len(e.Stack) > 0 && cfg.encoder == nil
+	e.duplicateKeys = cfg.duplicateKeys
+
+	base := l.base.Load()
+	e.TypedFields = appendKeyVals(e.TypedFields, base.fields)
+	e.TypedFields = append(e.TypedFields, base.typedFields...)
+	e.TypedFields = base.appendConditional(e.TypedFields, e.Level)
+	e.TypedFields = appendKeyVals(e.TypedFields, src.Fields)
+	e.TypedFields = append(e.TypedFields, src.TypedFields...)
+	if cfg.sortFields {
+		slices.SortStableFunc(e.TypedFields, compareFieldKeys)
+	}
+
+	l.deliver(cfg, e)
+	putEntry(e)
+}
+
+// NewTee returns a Core that duplicates entries to every core in cores.
+//
+// Each core applies its own level, so a tee of a DebugLevel file core and an
+// InfoLevel console core writes debug entries to the file only. Nil cores are
+// ignored and nested tees are flattened.
+func NewTee(cores ...Core) Core {
+	var t teeCore
+	for _, c := range cores {
+		switch c := c.(type) {
+		case nil:
+		case *teeCore:
+			t = append(t, *c...)
+		default:
+			t = append(t, c)
+		}
+	}
+	if len(t) == 1 {
+		return t[0]
+	}
+	return &t
+}
+
+// teeCore fans entries out to several cores.
+type teeCore []Core
+
+func (t *teeCore) Enabled(level Level) bool {
+	for _, c := range *t {
+		if c.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *teeCore) With(fields []Field) Core {
+	nt := make(teeCore, len(*t))
+	for i, c := range *t {
+		nt[i] = c.With(fields)
+	}
+	return &nt
+}
+
+// Write writes e to every core that is enabled at its level, returning all
+// errors joined.
+func (t *teeCore) Write(e *Entry) error {
+	var errs []error
+	for _, c := range *t {
+		if c.Enabled(e.Level) {
+			if err := c.Write(e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Sync flushes every core, returning all errors joined.
+func (t *teeCore) Sync() error {
+	var errs []error
+	for _, c := range *t {
+		if err := c.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *teeCore) close() {
+	for _, c := range *t {
+		closeCore(c)
+	}
+}
+
+// closeCore closes c if it owns resources, such as a Logger from NewCore.
+func closeCore(c Core) {
+	if cl, ok := c.(interface{ close() }); ok {
+		cl.close()
+	}
+}
+
+// coreHandle is the Core of a Logger, shared with its children. The Core is
+// closed when the last of them is closed.
+type coreHandle struct {
+	Core
+	refs atomic.Int64
+}
+
+// NewWithCore constructs a Logger that hands its entries to c.
+//
+// The Logger applies opts on top of Options{Level: DebugLevel}, so by default
+// each Core decides which levels it writes. Options that control what entries
+// carry, such as ReportTimestamp, ReportCaller, ReportStacktrace, Fields, and
+// Hooks, still apply; those that control formatting and writing are taken
+// from the cores.
+func NewWithCore(c Core, opts ...Option) *Logger {
+	o := Options{Level: DebugLevel}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	o.Core = c
+	return NewWithOptions(nil, o)
+}
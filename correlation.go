@@ -0,0 +1,132 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+)
+
+// CorrelationKey is the field key under which every formatter writes a
+// correlation ID.
+const CorrelationKey = "correlation_id"
+
+// CorrelationHeader is the conventional HTTP header carrying a correlation ID
+// between services.
+const CorrelationHeader = "X-Correlation-ID"
+
+// CorrelationID identifies the entries that belong to one request, job, or
+// other unit of work, across Loggers, services, and sinks.
+//
+// Attach it to a Logger with WithCorrelationID, or to a context with
+// ContextWithCorrelationID, and it is written under CorrelationKey.
+type CorrelationID string
+
+// NewCorrelationID returns a random 128-bit CorrelationID in lowercase hex.
+func NewCorrelationID() CorrelationID {
+	var id [16]byte
+	rand.Read(id[:])
+	return CorrelationID(hex.EncodeToString(id[:]))
+}
+
+// Correlation constructs the Field carrying id under CorrelationKey.
+func Correlation(id CorrelationID) Field {
+	return String(CorrelationKey, string(id))
+}
+
+type correlationKey struct{}
+
+// ContextWithCorrelationID returns a copy of ctx carrying id.
+//
+// Entries logged with the context through LogContext or LogContextFields
+// carry id, unless the Logger has a correlation ID of its own.
+func ContextWithCorrelationID(ctx context.Context, id CorrelationID) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID of ctx, or the empty
+// string if it has none.
+//
+// The ID is the one set by ContextWithCorrelationID or, failing that, the one
+// carried by the Logger stored with WithContext. Use it to forward the ID to
+// downstream services, for example in CorrelationHeader.
+func CorrelationIDFromContext(ctx context.Context) CorrelationID {
+	if id, ok := ctx.Value(correlationKey{}).(CorrelationID); ok {
+		return id
+	}
+	if l, ok := ctx.Value(_contextKeyInstance).(*Logger); ok {
+		return l.base.Load().correlationID()
+	}
+	return ""
+}
+
+// WithCorrelationID creates a child Logger that writes id under
+// CorrelationKey. Storing the child with WithContext also propagates id to
+// the context, so that CorrelationIDFromContext and other Loggers logging with
+// the context find it.
+func (l *Logger) WithCorrelationID(id CorrelationID) *Logger {
+	return l.WithFields(Correlation(id))
+}
+
+// correlationID returns the correlation ID among the fields, or the empty
+// string if there is none.
+func (bf *baseFields) correlationID() CorrelationID {
+	for i := range bf.typedFields {
+		if f := &bf.typedFields[i]; f.Key == CorrelationKey && f.Type == StringType {
+			return CorrelationID(f.Str)
+		}
+	}
+	for i := 0; i+1 < len(bf.fields); i += 2 {
+		if k, ok := bf.fields[i].(string); ok && k == CorrelationKey {
+			switch v := bf.fields[i+1].(type) {
+			case CorrelationID:
+				return v
+			case string:
+				return CorrelationID(v)
+			}
+		}
+	}
+	return ""
+}
+
+// contextFields returns the fields cfg extracts from ctx, followed by the
+// correlation ID of ctx unless l carries one already. When the extractor
+// returns no fields, the ID is stored in scratch to avoid allocating.
+func (l *Logger) contextFields(cfg *loggerConfig, ctx context.Context, scratch *[1]Field) []Field {
+	if ctx == nil {
+		return nil
+	}
+	var fields []Field
+	if cfg.contextExtractor != nil {
+		fields = cfg.contextExtractor(ctx)
+	}
+	id := CorrelationIDFromContext(ctx)
+	if id == "" || l.base.Load().correlationID() != "" {
+		return fields
+	}
+	if len(fields) == 0 {
+		scratch[0] = Correlation(id)
+		return scratch[:]
+	}
+	return append(slices.Clip(fields), Correlation(id))
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// PanicKey is the key holding the value recovered by RecoverAndLog.
+const PanicKey = "panic"
+
+// _crashStackDepth is the minimum number of frames rendered for a recovered
+// panic, which needs more context than an ordinary error entry.
+const _crashStackDepth = 32
+
+// RecoverAndLog recovers a panic in the calling goroutine, writes the panic
+// value and its stack trace to l at PanicLevel, flushes every background
+// worker, and then panics again with the same value.
+//
+// Without it, a goroutine that panics outside the logger ends the process
+// before the background workers drain, and the entries still buffered are
+// lost along with the record of the panic itself. Defer it first in main and
+// at the top of each goroutine you start:
+//
+//	go func() {
+//		defer velo.RecoverAndLog(logger)
+//		work()
+//	}()
+//
+// It must be deferred directly; called from another deferred function, it
+// recovers nothing. A nil l selects the default Logger. Because the panic
+// continues, the process still crashes with the runtime's usual report, and
+// recovering middleware further up the stack still sees the original value.
+//
+// Go offers no hook for goroutines that never defer RecoverAndLog. Use
+// SetCrashFile to keep a copy of the runtime's report for those.
+func RecoverAndLog(l *Logger) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if l == nil {
+		l = Default()
+	}
+	l.logPanic(r)
+	panic(r)
+}
+
+// logPanic writes a recovered panic value and flushes all workers.
+//
+// The entry bypasses sampling and the trigger buffer, and always carries a
+// stack trace taken from the panicking goroutine, starting at the frame that
+// panicked.
+func (l *Logger) logPanic(r any) {
+	if l.level.val.Load() <= int64(PanicLevel) {
+		cfg := *l.config.Load()
+		cfg.recovered = true
+		cfg.reportStacktrace = true
+		cfg.stackLevel = stackLevel(PanicLevel)
+		cfg.stackDepth = max(cfg.stackDepth, _crashStackDepth)
+		fields := [...]Field{Any(PanicKey, r)}
+		l.logWithEntry(panicSkip(), PanicLevel, "unhandled panic", nil, fields[:], nil, &cfg, cfg.now(), false)
+	}
+	l.Sync()
+	flushAllWorkers()
+}
+
+// panicSkip returns the number of runtime frames between RecoverAndLog and the
+// code that panicked, such as runtime.gopanic and, for a nil map write or an
+// out of range index, the runtime function that detected it. Skipping them
+// makes the caller name the line that panicked.
+func panicSkip() int {
+	var pcs [16]uintptr
+	n := runtime.Callers(4, pcs[:]) // skip Callers, panicSkip, logPanic, RecoverAndLog
+	frames := runtime.CallersFrames(pcs[:n])
+	skip := 0
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "runtime.") {
+			return skip
+		}
+		skip++
+	}
+}
+
+// SetCrashFile appends the runtime's report of every fatal crash to the file
+// at path, in addition to standard error.
+//
+// It covers what RecoverAndLog cannot: unhandled panics in goroutines that do
+// not defer it, and fatal runtime errors such as concurrent map writes or
+// running out of memory. The file survives when standard error goes to a
+// terminal or a collector that is torn down with the process. Only one crash
+// file is active at a time; a later call replaces the earlier one, and an
+// empty path stops writing one. TinyGo and WebAssembly builds report an
+// error.
+func SetCrashFile(path string) error {
+	if path == "" {
+		return setCrashOutput(nil)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	// The runtime duplicates the descriptor, so this one can be closed.
+	defer f.Close()
+	return setCrashOutput(f)
+}
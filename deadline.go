@@ -0,0 +1,84 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// DeadlineWriter is a destination whose writes can be bounded in time, such
+// as a net.Conn. An asynchronous Logger with a WriteTimeout sets a deadline
+// before each write to it.
+type DeadlineWriter interface {
+	io.Writer
+	SetWriteDeadline(t time.Time) error
+}
+
+// deadlineWriter bounds each write to out by timeout, so that a hung
+// destination cannot stall the worker. When a spillWriter wraps it, timeouts
+// are returned like other errors so that the spillWriter spills the entries
+// they cut short. Otherwise what a timed out write did not deliver is
+// dropped.
+//
+// Without a spillWriter, it reports timed out writes as successful so that
+// the worker's bufio.Writer keeps working; take returns the error it hid.
+// Other errors are returned as they are.
+type deadlineWriter struct {
+	out     DeadlineWriter
+	timeout time.Duration
+	spilled bool
+	err     atomic.Pointer[error]
+}
+
+func (d *deadlineWriter) Write(p []byte) (int, error) {
+	if err := d.out.SetWriteDeadline(time.Now().Add(d.timeout)); err != nil {
+		// Destinations such as regular files report that they do not
+		// support deadlines.
+		return d.out.Write(p)
+	}
+	n, err := d.out.Write(p)
+	if err != nil && !d.spilled && isTimeout(err) {
+		d.err.Store(&err)
+		return len(p), nil
+	}
+	return n, err
+}
+
+// take returns and clears the last timeout from the destination.
+func (d *deadlineWriter) take() error {
+	if err := d.err.Swap(nil); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// isTimeout reports whether err means a write deadline passed.
+func isTimeout(err error) bool {
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var t interface{ Timeout() bool }
+	return errors.As(err, &t) && t.Timeout()
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DuplicateKeys dictates how the JSONFormatter writes fields that share a
+// key, as when a field attached with With is repeated at the call site.
+//
+// Some JSON parsers reject objects with duplicate keys, and those that accept
+// them disagree on which value to keep. Only fields are compared; a field
+// named like a built-in key such as "msg" is written as is.
+type DuplicateKeys int
+
+const (
+	// DuplicateKeysAllow writes every field, duplicates included. This is
+	// the default and the fastest option.
+	DuplicateKeysAllow DuplicateKeys = iota
+	// DuplicateKeysLastWins writes only the last field with each key, where
+	// that field appears.
+	DuplicateKeysLastWins
+	// DuplicateKeysFirstWins writes only the first field with each key.
+	DuplicateKeysFirstWins
+	// DuplicateKeysSuffix writes every field, renaming the second and later
+	// fields with a key to key_2, key_3, and so on.
+	DuplicateKeysSuffix
+)
+
+// String returns the name of the policy.
+func (d DuplicateKeys) String() string {
+	switch d {
+	case DuplicateKeysAllow:
+		return "allow"
+	case DuplicateKeysLastWins:
+		return "last"
+	case DuplicateKeysFirstWins:
+		return "first"
+	case DuplicateKeysSuffix:
+		return "suffix"
+	default:
+		return fmt.Sprintf("DuplicateKeys(%d)", int(d))
+	}
+}
+
+// _dedupLinearMax is the field count up to which duplicates are found by
+// comparing keys pairwise. Larger entries index their keys in a map.
+const _dedupLinearMax = 16
+
+// appendDedupJSON encodes fields under policy d.
+func appendDedupJSON(b *buffer, fields []Field, d DuplicateKeys, ff fieldFormat, first bool) bool {
+	var seen map[string]int
+	if len(fields) > _dedupLinearMax {
+		seen = make(map[string]int, len(fields))
+		if d == DuplicateKeysLastWins {
+			for i := range fields {
+				seen[fields[i].Key] = i
+			}
+		}
+	}
+
+	for i := range fields {
+		f := &fields[i]
+		key := f.Key
+		switch d {
+		case DuplicateKeysLastWins:
+			if seen != nil {
+				if seen[key] != i {
+					continue
+				}
+			} else if indexKey(fields[i+1:], key) >= 0 {
+				continue
+			}
+		case DuplicateKeysFirstWins:
+			if seen != nil {
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = i
+			} else if indexKey(fields[:i], key) >= 0 {
+				continue
+			}
+		case DuplicateKeysSuffix:
+			var n int
+			if seen != nil {
+				n = seen[key]
+				seen[key] = n + 1
+			} else {
+				n = countKey(fields[:i], key)
+			}
+			if n > 0 {
+				mark := len(b.B)
+				appendJSONKey(b, key+"_"+strconv.Itoa(n+1), !first)
+				appendJSONFieldValue(b, f, ff)
+				b.keepField(mark)
+				first = false
+				continue
+			}
+		}
+		encodeFieldToJSON(b, f, ff, !first)
+		first = false
+	}
+	return first
+}
+
+// indexKey returns the index of the first field in fields with key, or -1.
+func indexKey(fields []Field, key string) int {
+	for i := range fields {
+		if fields[i].Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// countKey returns the number of fields in fields with key.
+func countKey(fields []Field, key string) int {
+	n := 0
+	for i := range fields {
+		if fields[i].Key == key {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDuplicateKeys(t *testing.T) {
+	for _, tt := range []struct {
+		policy DuplicateKeys
+		want   string
+	}{
+		{DuplicateKeysAllow, `"a":1,"b":2,"a":3,"a":4`},
+		{DuplicateKeysLastWins, `"b":2,"a":4`},
+		{DuplicateKeysFirstWins, `"a":1,"b":2`},
+		{DuplicateKeysSuffix, `"a":1,"b":2,"a_2":3,"a_3":4`},
+	} {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(&buf, WithFormatter(JSONFormatter), WithDuplicateKeys(tt.policy))
+			l.With("a", 1, "b", 2).Info("msg", "a", 3, "a", 4)
+			if want := `"msg":"msg",` + tt.want + "}\n"; !strings.HasSuffix(buf.String(), want) {
+				t.Errorf("got %s, want it to end in %s", buf.String(), want)
+			}
+		})
+	}
+}
+
+// TestDuplicateKeysMany covers entries with more fields than are compared
+// pairwise.
+func TestDuplicateKeysMany(t *testing.T) {
+	var fields []Field
+	for i := range _dedupLinearMax {
+		fields = append(fields, Int("k"+strconv.Itoa(i), i))
+	}
+	fields = append(fields, Int("k0", 100), Int("k1", 101))
+
+	for _, tt := range []struct {
+		policy   DuplicateKeys
+		has, not []string
+	}{
+		{DuplicateKeysLastWins, []string{`"k0":100`, `"k1":101`}, []string{`"k0":0,`, `"k1":1,`}},
+		{DuplicateKeysFirstWins, []string{`"k0":0,`, `"k1":1,`}, []string{`"k0":100`, `"k1":101`}},
+		{DuplicateKeysSuffix, []string{`"k0":0,`, `"k0_2":100`, `"k1_2":101`}, nil},
+	} {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			New(&buf, WithFormatter(JSONFormatter), WithDuplicateKeys(tt.policy)).InfoFields("msg", fields...)
+			for _, s := range tt.has {
+				if !strings.Contains(buf.String(), s) {
+					t.Errorf("got %s, want %s", buf.String(), s)
+				}
+			}
+			for _, s := range tt.not {
+				if strings.Contains(buf.String(), s) {
+					t.Errorf("got %s, want no %s", buf.String(), s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// WorkerDiagnostics describes the state of a single asynchronous worker.
+type WorkerDiagnostics struct {
+	// Destination describes the io.Writer the worker writes to.
+	Destination string `json:"destination"`
+	// Strategy is the worker's overflow strategy.
+	Strategy OverflowStrategy `json:"strategy"`
+	// QueueLen is the number of formatted entries waiting to be written.
+	QueueLen int `json:"queue_len"`
+	// QueueCap is the capacity of the worker's queue.
+	QueueCap int `json:"queue_cap"`
+	// RefCount is the number of open Loggers sharing the worker.
+	RefCount int64 `json:"refs"`
+	// Dropped counts entries discarded by the OverflowDrop strategy.
+	Dropped uint64 `json:"dropped"`
+	// LastError holds the most recent write error, or is empty if none occurred.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// DiagnosticsSnapshot is a point in time view of the logging subsystem.
+type DiagnosticsSnapshot struct {
+	Workers []WorkerDiagnostics `json:"workers"`
+}
+
+// Diagnostics returns a snapshot of every registered asynchronous worker.
+//
+// Use this to answer "why did my logs stop" in production: a full queue, a
+// zero reference count, or a sticky write error are all visible here without
+// attaching a debugger. The snapshot is safe to serialize as JSON.
+func Diagnostics() DiagnosticsSnapshot {
+	workers := runningWorkers()
+	snap := DiagnosticsSnapshot{Workers: make([]WorkerDiagnostics, 0, len(workers))}
+	for _, w := range workers {
+		d := WorkerDiagnostics{
+			Destination: describeWriter(w.output),
+			Strategy:    w.strategy,
+			QueueLen:    w.queue.len(),
+			QueueCap:    w.queue.capacity(),
+			RefCount:    w.refCount.Load(),
+			Dropped:     w.dropped.Load(),
+		}
+		if err := w.lastErr.Load(); err != nil {
+			d.LastError = (*err).Error()
+		}
+		snap.Workers = append(snap.Workers, d)
+	}
+	return snap
+}
+
+// describeWriter returns a human readable description of a log destination.
+func describeWriter(w io.Writer) string {
+	switch v := w.(type) {
+	case *os.File:
+		return v.Name()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprintf("%T", w)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DurationEncoding dictates how the text and JSON formatters write
+// durations: the values of Duration fields, time.Duration values logged as
+// key-value pairs or with Any, and those added through an ObjectEncoder or
+// ArrayEncoder. The BinaryFormatter always records nanoseconds.
+type DurationEncoding int
+
+const (
+	// DurationDefault writes durations as integer nanoseconds in JSON and
+	// as strings such as "1.5s" in text. This is the default.
+	DurationDefault DurationEncoding = iota
+	// DurationNanos writes durations as integer nanoseconds.
+	DurationNanos
+	// DurationMillis writes durations as fractional milliseconds, such as
+	// 1500.25.
+	DurationMillis
+	// DurationSeconds writes durations as fractional seconds, such as 1.5.
+	DurationSeconds
+	// DurationString writes durations as strings such as "1.5s", as
+	// time.Duration.String formats them.
+	DurationString
+)
+
+// String returns the name of the encoding.
+func (d DurationEncoding) String() string {
+	switch d {
+	case DurationDefault:
+		return "default"
+	case DurationNanos:
+		return "nanos"
+	case DurationMillis:
+		return "millis"
+	case DurationSeconds:
+		return "seconds"
+	case DurationString:
+		return "string"
+	default:
+		return fmt.Sprintf("DurationEncoding(%d)", int(d))
+	}
+}
+
+// appendJSONDuration appends d to b as JSON in encoding e.
+func appendJSONDuration(b *buffer, d time.Duration, e DurationEncoding) {
+	switch e {
+	case DurationMillis:
+		b.B = strconv.AppendFloat(b.B, float64(d)/float64(time.Millisecond), 'f', -1, 64)
+	case DurationSeconds:
+		b.B = strconv.AppendFloat(b.B, d.Seconds(), 'f', -1, 64)
+	case DurationString:
+		appendJSONString(b, d.String())
+	default:
+		b.B = appendInt64(b.B, int64(d))
+	}
+}
+
+// textDuration returns d as the TextFormatter writes it in encoding e.
+func textDuration(d time.Duration, e DurationEncoding) string {
+	switch e {
+	case DurationNanos:
+		return formatInt(int64(d))
+	case DurationMillis:
+		return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64)
+	case DurationSeconds:
+		return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+	default:
+		return d.String()
+	}
+}
+
+// textValue returns the loosely typed value v as the TextFormatter writes it
+// with ff, which only changes how durations are written.
+func textValue(v any, ff fieldFormat) string {
+	if d, ok := v.(time.Duration); ok && ff.durations != DurationDefault {
+		return textDuration(d, ff.durations)
+	}
+	return formatAny(v)
+}
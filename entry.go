@@ -33,26 +33,83 @@ import (
 // Performance Note: The library pools Entry objects to eliminate allocations
 // during high throughput logging. The Logger retrieves an Entry from the pool,
 // populates it, formats it, and then immediately returns it to the pool.
+// Fields, TypedFields and Stack start out backed by arrays inside the Entry
+// itself, so that typical entries never grow a slice on the heap.
 type Entry struct {
 	Time           time.Time
 	Fields         []any
 	TypedFields    []Field
 	PreEncodedJSON []byte
 	Stack          []uintptr
+	StackFilter    FrameFilter
+	StackDepth     int
+	Goroutines     []byte
+	Sequence       uint64
 	Message        string
 	Prefix         string
 	Caller         string
+	CallerFile     string
+	CallerFunc     string
+	CallerLine     int
 	TimeFormat     string
+	Styles         *Styles
+	Layout         *TextLayout
 	Formatter      Formatter
 	Level          Level
+
+	encoder       EntryEncoder
+	callerObject  bool
+	deferStack    bool
+	duplicateKeys DuplicateKeys
+	timeLocation  *time.Location
+	durations     DurationEncoding
+
+	// template marks Message as a Logt template, which formatText expands.
+	template bool
+
+	// logger and cfg carry the entry through a Processor pipeline, and
+	// written records that the pipeline reached its end.
+	logger  *Logger
+	cfg     *loggerConfig
+	written bool
+
+	// The inline arrays back Fields, TypedFields and Stack until an entry
+	// outgrows them.
+	fieldsArr [_inlineFields]any
+	typedArr  [_inlineFields]Field
+	stackArr  [_inlineStack]uintptr
+}
+
+const (
+	// _inlineFields and _inlineStack size the arrays embedded in an Entry to
+	// hold the 99th percentile entry, counting Logger, context and call site
+	// fields together.
+	_inlineFields = 32
+	_inlineStack  = 32
+
+	// _maxRetainedFields bounds the capacity a pooled Entry keeps after an
+	// outlier grew its slices, so that one huge entry does not pin its
+	// backing arrays in every pool slot it passes through.
+	_maxRetainedFields = 256
+)
+
+// EntryObserver receives fully assembled entries before the Logger formats them.
+//
+// The Logger fields, context fields, and call site fields are all expanded
+// into Fields and TypedFields. The Entry is pooled, so implementations must
+// copy anything they keep beyond the call to ObserveEntry. The velotest
+// package uses this to capture structured entries in unit tests.
+type EntryObserver interface {
+	ObserveEntry(e *Entry)
 }
 
 var _entryPool = sync.Pool{
 	New: func() any {
-		return &Entry{
-			Fields:      make([]any, 0, 20),
-			TypedFields: make([]Field, 0, 20),
-		}
+		e := new(Entry)
+		e.Fields = e.fieldsArr[:0]
+		e.TypedFields = e.typedArr[:0]
+		e.Stack = e.stackArr[:0]
+		return e
 	},
 }
 
@@ -64,7 +121,51 @@ func putEntry(e *Entry) {
 	// Reset the entry for reuse.
 	e.Fields = e.Fields[:0]
 	e.TypedFields = e.TypedFields[:0]
+	if cap(e.Fields) > _maxRetainedFields {
+		e.Fields = e.fieldsArr[:0]
+	}
+	if cap(e.TypedFields) > _maxRetainedFields {
+		e.TypedFields = e.typedArr[:0]
+	}
 	e.PreEncodedJSON = nil
 	e.Stack = e.Stack[:0]
+	if cap(e.Stack) > _maxRetainedFields {
+		e.Stack = e.stackArr[:0]
+	}
+	e.StackFilter = nil
+	e.StackDepth = 0
+	e.Goroutines = nil
+	e.Caller = ""
+	e.CallerFile = ""
+	e.CallerFunc = ""
+	e.CallerLine = 0
+	e.callerObject = false
+	e.deferStack = false
+	e.encoder = nil
+	e.duplicateKeys = DuplicateKeysAllow
+	e.timeLocation = nil
+	e.durations = DurationDefault
+	e.logger = nil
+	e.cfg = nil
+	e.written = false
+	e.template = false
+	e.Styles = nil
+	e.Layout = nil
+	e.Sequence = 0
 	_entryPool.Put(e)
 }
+
+// fieldFormat returns how e writes field values.
+func (e *Entry) fieldFormat() fieldFormat {
+	return fieldFormat{layout: e.TimeFormat, loc: e.timeLocation, durations: e.durations}
+}
+
+// sizeHint estimates the formatted size of e, so that entries carrying a
+// stack trace or goroutine dump start from a buffer large enough to hold them.
+func (e *Entry) sizeHint() int {
+	n := len(e.Message) + len(e.PreEncodedJSON) + len(e.Goroutines)
+	if !e.deferStack {
+		n += len(e.Stack) * stackFrameSizeHint
+	}
+	return n
+}
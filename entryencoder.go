@@ -0,0 +1,55 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+// EntryEncoder serializes entries in a format of its own, for formats the
+// built in Formatters do not cover, such as logfmt, CEF, or a vendor's wire
+// format. Set it with Options.Encoder, where it takes the place of Formatter:
+//
+//	logger := velo.NewWithOptions(w, velo.Options{Encoder: logfmtEncoder{}})
+//
+// The Logger assembles each entry as it would for a Hook, with the Logger
+// fields in Fields and TypedFields ahead of the call site fields, and the
+// caller, sequence, and stack trace filled in when configured. Stack holds
+// program counters; resolve them with runtime.CallersFrames. The level
+// filter, sampling, Hooks, Processors, and asynchronous writing all apply as
+// usual, but MaxEntryBytes, which the built in Formatters enforce field by
+// field, does not.
+//
+// Implementations must be safe for concurrent use and must not retain the
+// Entry, which is pooled, after AppendEntry returns.
+//
+// Performance Note: An EntryEncoder routes all calls through the Entry path.
+// The TextFormatter and JSONFormatter keep their direct formatting paths
+// when no EntryEncoder is set.
+type EntryEncoder interface {
+	// AppendEntry appends the encoding of e, including any trailing newline,
+	// to dst and returns the extended slice.
+	AppendEntry(dst []byte, e *Entry) []byte
+}
+
+// EntryEncoderFunc adapts an ordinary function to the EntryEncoder interface.
+type EntryEncoderFunc func(dst []byte, e *Entry) []byte
+
+// AppendEntry calls f(dst, e).
+func (f EntryEncoderFunc) AppendEntry(dst []byte, e *Entry) []byte {
+	return f(dst, e)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by NewFromEnv and Config.LoadEnv.
+//
+//	VELO_LEVEL             minimum level: debug, info, warn, error, dpanic, panic, fatal
+//	VELO_FORMAT            text, json, or binary
+//	VELO_OUTPUT            comma separated outputs: stdout, stderr, or file paths
+//	VELO_COLOR             auto, never, or always
+//	VELO_TIMESTAMP         include timestamps (true/false)
+//	VELO_TIME_FORMAT       timestamp layout
+//	VELO_CALLER            include the caller (true/false)
+//	VELO_STACKTRACE        include stack traces on errors (true/false)
+//	VELO_PREFIX            message prefix
+//	VELO_ASYNC             enable the background worker (true/false)
+//	VELO_BUFFER_SIZE       async queue capacity; implies VELO_ASYNC
+//	VELO_OVERFLOW          async overflow strategy: sync, drop, or block; implies VELO_ASYNC
+//	VELO_SAMPLE_INITIAL    entries logged per sampling tick before thinning
+//	VELO_SAMPLE_THEREAFTER log every Nth entry after VELO_SAMPLE_INITIAL
+//	VELO_SAMPLE_TICK       sampling interval, such as 1s
+const (
+	EnvLevel            = "VELO_LEVEL"
+	EnvFormat           = "VELO_FORMAT"
+	EnvOutput           = "VELO_OUTPUT"
+	EnvColor            = "VELO_COLOR"
+	EnvTimestamp        = "VELO_TIMESTAMP"
+	EnvTimeFormat       = "VELO_TIME_FORMAT"
+	EnvCaller           = "VELO_CALLER"
+	EnvStacktrace       = "VELO_STACKTRACE"
+	EnvPrefix           = "VELO_PREFIX"
+	EnvAsync            = "VELO_ASYNC"
+	EnvBufferSize       = "VELO_BUFFER_SIZE"
+	EnvOverflow         = "VELO_OVERFLOW"
+	EnvSampleInitial    = "VELO_SAMPLE_INITIAL"
+	EnvSampleThereafter = "VELO_SAMPLE_THEREAFTER"
+	EnvSampleTick       = "VELO_SAMPLE_TICK"
+)
+
+// NewFromEnv constructs a Logger configured entirely by VELO_* environment
+// variables, for deployments that cannot ship per-service config files.
+//
+// Unset variables keep the defaults of a zero Config, so with no variables set
+// it behaves like New(os.Stderr). It returns an error naming the offending
+// variable if a value cannot be parsed.
+func NewFromEnv() (*Logger, error) {
+	var c Config
+	if err := c.LoadEnv(); err != nil {
+		return nil, err
+	}
+	return c.Build()
+}
+
+// LoadEnv overrides c with the VELO_* environment variables that are set.
+//
+// Use it to let operators adjust a Config loaded from a file.
+func (c *Config) LoadEnv() error {
+	env := envParser{}
+	if v, ok := env.lookup(EnvLevel); ok {
+		env.check(EnvLevel, c.Level.UnmarshalText([]byte(v)))
+	}
+	if v, ok := env.lookup(EnvFormat); ok {
+		env.check(EnvFormat, c.Format.UnmarshalText([]byte(v)))
+	}
+	if v, ok := env.lookup(EnvOutput); ok {
+		c.OutputPaths = c.OutputPaths[:0]
+		for path := range strings.SplitSeq(v, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				c.OutputPaths = append(c.OutputPaths, path)
+			}
+		}
+	}
+	if v, ok := env.lookup(EnvColor); ok {
+		env.check(EnvColor, c.Color.UnmarshalText([]byte(v)))
+	}
+	env.bool(EnvTimestamp, &c.Timestamp)
+	if v, ok := env.lookup(EnvTimeFormat); ok {
+		c.TimeFormat = v
+	}
+	env.bool(EnvCaller, &c.Caller)
+	env.bool(EnvStacktrace, &c.Stacktrace)
+	if v, ok := env.lookup(EnvPrefix); ok {
+		c.Prefix = v
+	}
+
+	async := c.Async != nil
+	env.bool(EnvAsync, &async)
+	_, sized := env.lookup(EnvBufferSize)
+	_, overflow := env.lookup(EnvOverflow)
+	switch {
+	case !async && !sized && !overflow:
+		c.Async = nil
+	case c.Async == nil:
+		c.Async = &AsyncConfig{}
+	}
+	if c.Async != nil {
+		env.int(EnvBufferSize, &c.Async.BufferSize)
+		if v, ok := env.lookup(EnvOverflow); ok {
+			env.check(EnvOverflow, c.Async.Overflow.UnmarshalText([]byte(v)))
+		}
+	}
+
+	_, initial := env.lookup(EnvSampleInitial)
+	_, thereafter := env.lookup(EnvSampleThereafter)
+	_, tick := env.lookup(EnvSampleTick)
+	if c.Sampling == nil && (initial || thereafter || tick) {
+		c.Sampling = &SamplingConfig{}
+	}
+	if c.Sampling != nil {
+		env.int(EnvSampleInitial, &c.Sampling.Initial)
+		env.int(EnvSampleThereafter, &c.Sampling.Thereafter)
+		if v, ok := env.lookup(EnvSampleTick); ok {
+			d, err := time.ParseDuration(v)
+			env.check(EnvSampleTick, err)
+			c.Sampling.Tick = d
+		}
+	}
+	return env.err
+}
+
+// envParser reads environment variables, keeping the first parse error.
+type envParser struct {
+	err error
+}
+
+func (p *envParser) lookup(name string) (string, bool) {
+	v, ok := os.LookupEnv(name)
+	return strings.TrimSpace(v), ok
+}
+
+func (p *envParser) check(name string, err error) {
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("velo: %s: %w", name, err)
+	}
+}
+
+func (p *envParser) bool(name string, dst *bool) {
+	if v, ok := p.lookup(name); ok {
+		b, err := strconv.ParseBool(v)
+		p.check(name, err)
+		*dst = b
+	}
+}
+
+func (p *envParser) int(name string, dst *int) {
+	if v, ok := p.lookup(name); ok {
+		n, err := strconv.Atoi(v)
+		p.check(name, err)
+		*dst = n
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"unsafe"
+)
+
+const (
+	_swarLo = 0x0101010101010101
+	_swarHi = 0x8080808080808080
+)
+
+// indexJSONEscape returns the index of the first byte of s that must be
+// escaped inside a JSON string, or -1 if there is none.
+//
+// Performance Note: It examines eight bytes per iteration using SWAR (SIMD
+// within a register) arithmetic, and only falls back to the _noEscape table
+// for the final few bytes. Long messages without escapes, the common case,
+// are scanned several times faster than with a byte-by-byte loop.
+func indexJSONEscape(s string) int {
+	p := unsafe.Slice(unsafe.StringData(s), len(s))
+	i := 0
+	for ; i+8 <= len(p); i += 8 {
+		if m := escapeMask(binary.LittleEndian.Uint64(p[i:])); m != 0 {
+			return i + bits.TrailingZeros64(m)/8
+		}
+	}
+	for ; i < len(p); i++ {
+		if _noEscape[p[i]] {
+			return i
+		}
+	}
+	return -1
+}
+
+// escapeMask sets the high bit of the lowest byte of x that is a control
+// character, a double quote, or a backslash, where byte 0 is the least
+// significant. Bits above it may be set spuriously by borrows, so only the
+// lowest set bit is meaningful.
+func escapeMask(x uint64) uint64 {
+	ctl := (x - 0x20*_swarLo) & ^x
+	q := x ^ '"'*_swarLo
+	q = (q - _swarLo) & ^q
+	bs := x ^ '\\'*_swarLo
+	bs = (bs - _swarLo) & ^bs
+	return (ctl | q | bs) & _swarHi
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "time"
+
+// EventTimeKey is the key an EventTime field is written under when it is not
+// among the fields of a log call, such as when passed to WithFields.
+const EventTimeKey = "event_time"
+
+// eventTimeZone marks the Field built by EventTime in Field.Any, which Time
+// fields leave unused, and keeps the location of the time. Holding a single
+// pointer, it is stored in the interface without allocating.
+type eventTimeZone struct {
+	loc *time.Location
+}
+
+// EventTime constructs a field that replaces the timestamp of the entry it is
+// logged with, so events replayed from a queue or drained from a batch carry
+// the time they occurred rather than the time they were logged:
+//
+//	logger.InfoFields("order placed", velo.String("id", id), velo.EventTime(placedAt))
+//
+// The field itself is not written. The timestamp still follows
+// ReportTimestamp, TimeFunction, and TimeFormat. EventTime is honored among
+// the fields of a single call; elsewhere it is written like
+// Time(EventTimeKey, t).
+func EventTime(t time.Time) Field {
+	return Field{Key: EventTimeKey, Type: TimeType, Int: t.UnixNano(), Any: eventTimeZone{t.Location()}}
+}
+
+// LogAt writes a message with strongly typed fields at the specified level,
+// using t as its timestamp instead of the current time. It is LogFields with
+// an EventTime field.
+//
+// Performance Note: Up to seven fields are copied on the stack, so calls with
+// few fields allocate no more than LogFields does.
+func (l *Logger) LogAt(t time.Time, level Level, msg string, fields ...Field) {
+	l.logAt(0, t, level, msg, fields)
+}
+
+// LogAt writes a message at the specified level with the timestamp t using the
+// global default Logger.
+func LogAt(t time.Time, level Level, msg string, fields ...Field) {
+	Default().logAt(0, t, level, msg, fields)
+}
+
+// logAt logs fields with an EventTime field for t.
+func (l *Logger) logAt(skip int, t time.Time, level Level, msg string, fields []Field) {
+	if l == nil || (l.level.val.Load() > int64(level) && l.trigger == nil) {
+		return
+	}
+	var scratch [8]Field
+	l.logFields(skip+1, level, msg, append(append(scratch[:0], fields...), EventTime(t)))
+}
+
+// stamp returns the timestamp for a new entry logged with fields: the time of
+// the first EventTime field among them, which it removes, or the current time.
+func (c *loggerConfig) stamp(fields []Field) (time.Time, []Field) {
+	for i := range fields {
+		if f := &fields[i]; f.Type == TimeType && f.Any != nil {
+			if z, ok := f.Any.(eventTimeZone); ok {
+				return c.at(time.Unix(0, f.Int).In(z.loc)), withoutField(fields, i)
+			}
+		}
+	}
+	return c.now(), fields
+}
+
+// withoutField returns fields without fields[i], copying them only when i is
+// neither the first nor the last.
+func withoutField(fields []Field, i int) []Field {
+	switch i {
+	case 0:
+		return fields[1:]
+	case len(fields) - 1:
+		return fields[:i]
+	}
+	return append(fields[:i:i], fields[i+1:]...)
+}
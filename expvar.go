@@ -0,0 +1,97 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+var (
+	_expvarOnce    sync.Once
+	_expvarMetrics *expvarMetrics
+)
+
+// expvarMetrics implements MetricsHook on top of the "velo" expvar map.
+//
+// Every Logger constructed with Options.PublishExpvar shares the same map, so
+// the counters describe the logging health of the whole process.
+type expvarMetrics struct {
+	entries      *expvar.Map
+	dropped      *expvar.Map
+	writeErrors  *expvar.Int
+	bytesWritten *expvar.Int
+}
+
+// publishExpvar lazily registers the "velo" map with the expvar package.
+//
+// It reuses an existing "velo" map if another component already published
+// one, and returns nil if that name is taken by an incompatible variable.
+func publishExpvar() *expvarMetrics {
+	_expvarOnce.Do(func() {
+		var root *expvar.Map
+		switch v := expvar.Get("velo").(type) {
+		case nil:
+			root = expvar.NewMap("velo")
+		case *expvar.Map:
+			root = v
+		default:
+			return
+		}
+
+		m := &expvarMetrics{
+			entries:      new(expvar.Map).Init(),
+			dropped:      new(expvar.Map).Init(),
+			writeErrors:  new(expvar.Int),
+			bytesWritten: new(expvar.Int),
+		}
+		root.Set("entries", m.entries)
+		root.Set("dropped", m.dropped)
+		root.Set("write_errors", m.writeErrors)
+		root.Set("bytes_written", m.bytesWritten)
+		root.Set("workers", expvar.Func(expvarWorkers))
+		_expvarMetrics = m
+	})
+	return _expvarMetrics
+}
+
+// expvarWorkers reports the queue state of every live asynchronous worker.
+func expvarWorkers() any {
+	return Diagnostics().Workers
+}
+
+func (m *expvarMetrics) EntryLogged(level Level, _ time.Duration) {
+	m.entries.Add(level.String(), 1)
+}
+
+func (m *expvarMetrics) EntryDropped(level Level, reason DropReason) {
+	m.dropped.Add(reason.String(), 1)
+	m.dropped.Add(reason.String()+"."+level.String(), 1)
+}
+
+func (m *expvarMetrics) BytesWritten(n int) {
+	m.bytesWritten.Add(int64(n))
+}
+
+func (m *expvarMetrics) WriteError(error) {
+	m.writeErrors.Add(1)
+}
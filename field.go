@@ -21,6 +21,8 @@
 package velo
 
 import (
+	"fmt"
+	"strings"
 	"time"
 	"unsafe"
 )
@@ -55,6 +57,38 @@ const (
 	TimesType
 )
 
+// String returns the lowercase name of the type, such as "string" or "ints".
+func (t FieldType) String() string {
+	switch t {
+	case StringType:
+		return "string"
+	case IntType:
+		return "int"
+	case BoolType:
+		return "bool"
+	case ErrorType:
+		return "error"
+	case TimeType:
+		return "time"
+	case DurationType:
+		return "duration"
+	case AnyType:
+		return "any"
+	case ObjectType:
+		return "object"
+	case ArrayType:
+		return "array"
+	case IntsType:
+		return "ints"
+	case StringsType:
+		return "strings"
+	case TimesType:
+		return "times"
+	default:
+		return fmt.Sprintf("FieldType(%d)", t)
+	}
+}
+
 // Field represents a strongly typed key-value pair.
 //
 // It avoids the interface{} boxing overhead associated with standard variadic
@@ -85,9 +119,10 @@ func Bool(key string, val bool) Field {
 	return Field{Key: key, Type: BoolType, Int: i}
 }
 
-// Time constructs a Field containing a time.Time value.
+// Time constructs a Field containing a time.Time value. It is written in
+// the location of val unless the Logger sets Options.TimeLocation.
 func Time(key string, val time.Time) Field {
-	return Field{Key: key, Type: TimeType, Int: val.UnixNano()}
+	return Field{Key: key, Type: TimeType, Int: val.UnixNano(), Any: timeZone{val.Location()}}
 }
 
 // Duration constructs a Field containing a time.Duration value.
@@ -141,3 +176,35 @@ func Times(key string, val []time.Time) Field {
 	}
 	return Field{Key: key, Type: TimesType, Str: unsafe.String((*byte)(unsafe.Pointer(&val[0])), 1), Int: int64(len(val))}
 }
+
+// appendKeyVals converts loosely typed key-value pairs into Fields and appends them to dst.
+//
+// Non-string keys are rendered with their default formatting. A trailing key
+// without a value is dropped, matching the formatters.
+func appendKeyVals(dst []Field, keyvals []any) []Field {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = formatAny(keyvals[i])
+		}
+		dst = append(dst, Any(key, keyvals[i+1]))
+	}
+	return dst
+}
+
+// compareFieldKeys orders Fields by key for Options.SortFields.
+func compareFieldKeys(a, b Field) int {
+	return strings.Compare(a.Key, b.Key)
+}
+
+// isError reports whether the Field carries an error, including loosely typed
+// values converted by appendKeyVals.
+func (f *Field) isError() bool {
+	return f.Type == ErrorType || (f.Type == AnyType && isError(f.Any))
+}
+
+// isError reports whether v is a non-nil error.
+func isError(v any) bool {
+	_, ok := v.(error)
+	return ok
+}
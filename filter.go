@@ -0,0 +1,61 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+// FilterFunc reports whether an entry should be dropped. The fields are the
+// entry's Logger, context, and call site fields, in that order, and must not
+// be retained.
+type FilterFunc func(level Level, msg string, fields []Field) bool
+
+// NewFilter creates a child Logger that drops every entry for which drop
+// returns true, before it is formatted.
+//
+// Use it to suppress known noisy messages, such as those from third party
+// code, without patching the code that logs them:
+//
+//	logger = velo.NewFilter(logger, func(level velo.Level, msg string, _ []velo.Field) bool {
+//	  return strings.HasPrefix(msg, "http: TLS handshake error")
+//	})
+//
+// The filter runs before the Logger's other hooks and is inherited by the
+// child's own children. Dropped entries are reported to Metrics as DropHook.
+//
+// Performance Note: The filter routes all calls through the Entry path.
+func NewFilter(logger *Logger, drop FilterFunc) *Logger {
+	return logger.WithOptions(func(o *Options) {
+		o.Hooks = append([]Hook{filterHook(drop)}, o.Hooks...)
+	})
+}
+
+// filterHook adapts a FilterFunc to the Hook interface.
+type filterHook FilterFunc
+
+func (f filterHook) Run(e *Entry) error {
+	fields := e.TypedFields
+	if len(e.Fields) > 0 {
+		fields = appendKeyVals(make([]Field, 0, len(e.Fields)/2+len(e.TypedFields)), e.Fields)
+		fields = append(fields, e.TypedFields...)
+	}
+	if f(e.Level, e.Message, fields) {
+		return ErrDropEntry
+	}
+	return nil
+}
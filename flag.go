@@ -0,0 +1,120 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "flag"
+
+// Set parses text into the Level, making *Level a flag.Value.
+func (l *Level) Set(text string) error {
+	return l.UnmarshalText([]byte(text))
+}
+
+// Type names the value for pflag usage messages, making *Level a pflag.Value.
+func (l *Level) Type() string {
+	return "level"
+}
+
+// Set parses text into the Formatter, making *Formatter a flag.Value.
+func (f *Formatter) Set(text string) error {
+	return f.UnmarshalText([]byte(text))
+}
+
+// Type names the value for pflag usage messages, making *Formatter a pflag.Value.
+func (f *Formatter) Type() string {
+	return "format"
+}
+
+// Set parses text into the ColorMode, making *ColorMode a flag.Value.
+func (m *ColorMode) Set(text string) error {
+	return m.UnmarshalText([]byte(text))
+}
+
+// Type names the value for pflag usage messages, making *ColorMode a pflag.Value.
+func (m *ColorMode) Type() string {
+	return "color"
+}
+
+// LevelFlag defines a Level flag with the given name, default value, and
+// usage string on flag.CommandLine. The return value is the address of a
+// Level that stores the flag's value.
+func LevelFlag(name string, defaultLevel Level, usage string) *Level {
+	l := defaultLevel
+	flag.Var(&l, name, usage)
+	return &l
+}
+
+// FormatterFlag defines a Formatter flag with the given name, default value,
+// and usage string on flag.CommandLine. The return value is the address of a
+// Formatter that stores the flag's value.
+func FormatterFlag(name string, defaultFormatter Formatter, usage string) *Formatter {
+	f := defaultFormatter
+	flag.Var(&f, name, usage)
+	return &f
+}
+
+// RegisterFlags defines -log-level and -log-format on fs, wired directly to
+// the global default Logger.
+//
+// Parsing the flags calls SetLevel and SetFormatter, so CLIs get both
+// settings with one call and no glue code:
+//
+//	velo.RegisterFlags(flag.CommandLine)
+//	flag.Parse()
+func RegisterFlags(fs *flag.FlagSet) {
+	fs.Var(defaultLevelFlag{}, "log-level", "minimum log level: debug, info, warn, error, dpanic, panic, or fatal")
+	fs.Var(defaultFormatterFlag{}, "log-format", "log format: text, json, or binary")
+}
+
+// defaultLevelFlag is a flag.Value backed by the level of the default Logger.
+type defaultLevelFlag struct{}
+
+func (defaultLevelFlag) String() string {
+	return Level(Default().level.val.Load()).String()
+}
+
+func (defaultLevelFlag) Set(text string) error {
+	l, err := ParseLevel(text)
+	if err != nil {
+		return err
+	}
+	SetLevel(l)
+	return nil
+}
+
+func (defaultLevelFlag) Type() string { return "level" }
+
+// defaultFormatterFlag is a flag.Value backed by the formatter of the default Logger.
+type defaultFormatterFlag struct{}
+
+func (defaultFormatterFlag) String() string {
+	return Default().config.Load().formatter.String()
+}
+
+func (defaultFormatterFlag) Set(text string) error {
+	var f Formatter
+	if err := f.UnmarshalText([]byte(text)); err != nil {
+		return err
+	}
+	SetFormatter(f)
+	return nil
+}
+
+func (defaultFormatterFlag) Type() string { return "format" }
@@ -21,14 +21,19 @@
 package velo
 
 import (
+	"bytes"
 	"encoding"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 	"unsafe"
+
+	"github.com/mattn/go-runewidth"
 )
 
 var _defaultStyles = DefaultStyles()
@@ -37,222 +42,369 @@ var _defaultStyles = DefaultStyles()
 //
 // It bypasses the Entry struct allocation, providing maximum performance for
 // simple text logs.
-func formatLogText(b *buffer, l *Logger, cfg *loggerConfig, level Level, msg string, callFields []any, callTypedFields []Field, ctxFields []Field, t time.Time) {
-	st := _defaultStyles
+func formatLogText(b *buffer, base *baseFields, cfg *loggerConfig, level Level, msg string, callFields []any, callTypedFields []Field, ctxFields []Field, ci *callerInfo, t time.Time) {
+	st := cfg.styles
+	if st == nil {
+		st = _defaultStyles
+	}
 
 	// timestamp
 	if !t.IsZero() {
-		var buf [64]byte
-		tb := appendTime(buf[:0], t, cfg.timeFormat)
-		b.WriteString(st.Timestamp.Render(string(tb)))
+		b.WriteString(cfg.times.render(t, cfg.timeFormat, st))
 		b.WriteByte(' ')
 	}
 
 	// level
 	if level != noLevel {
-		if s, ok := st.CachedLevelStrings[level]; ok {
-			b.WriteString(s)
-			b.WriteByte(' ')
-		} else if lvlStyle, ok := st.Levels[level]; ok {
-			b.WriteString(lvlStyle.String())
-			b.WriteByte(' ')
-		}
+		appendLevelLabel(b, st, cfg.layout, level)
+	}
+
+	// caller
+	if ci != nil && ci.text != "" {
+		b.B = st.Caller.appendRender(b.B, ci.text, "")
+		b.WriteByte(' ')
 	}
 
 	// prefix
 	if cfg.prefix != "" {
-		b.WriteString(st.Prefix.Render(cfg.prefix + ":"))
+		b.B = st.Prefix.appendRender(b.B, cfg.prefix, ":")
 		b.WriteByte(' ')
 	}
 
 	// message
+	origLen := len(msg)
+	msg, truncated := truncateMessage(msg, cfg.maxMessageBytes)
+	if truncated {
+		msg += TruncationMarker
+	}
+	msg = multilineText(cfg.layout, msg)
 	if msg != "" {
-		b.WriteString(st.Message.Render(msg))
+		b.B = st.messageStyle(level).appendRender(b.B, msg, "")
+	}
+	ln := newTextLine(cfg.layout, msg)
+
+	if cfg.sequence != nil {
+		appendTextField(b, st, &ln, SequenceKey, strconv.FormatUint(cfg.sequence.Add(1), 10), false)
+	}
+	if cfg.name != "" {
+		appendTextField(b, st, &ln, NameKey, cfg.name, false)
 	}
 
-	// Helper to process fields
-	processFields := func(fields []any) {
-		for i := 0; i < len(fields); i += 2 {
-			if i+1 >= len(fields) {
-				break
+	// Logger fields, then context fields, then call fields.
+	base.appendText(b, st, &ln, cfg.fieldFormat())
+	for _, fields := range [...][]Field{base.conditionalAt(level), ctxFields} {
+		for i := range fields {
+			if fields[i].Key != "" {
+				appendTextTypedField(b, st, &ln, &fields[i], cfg.fieldFormat())
 			}
+		}
+	}
+	for i := 0; i+1 < len(callFields); i += 2 {
+		appendTextField(b, st, &ln, formatAny(callFields[i]), textValue(callFields[i+1], cfg.fieldFormat()), isError(callFields[i+1]))
+	}
+	for i := range callTypedFields {
+		if callTypedFields[i].Key != "" {
+			appendTextTypedField(b, st, &ln, &callTypedFields[i], cfg.fieldFormat())
+		}
+	}
 
-			key := formatAny(fields[i])
-			val := formatAny(fields[i+1])
+	appendTextDropped(b, st, &ln)
+	if truncated {
+		appendTextField(b, st, &ln, TruncatedMessageKey, strconv.Itoa(origLen), false)
+	}
+	closeTextFields(b, st, &ln)
 
-			if key == "" {
-				continue
-			}
+	b.WriteByte('\n')
+}
+
+// textLine tracks the column padding owed while a single text entry is written.
+//
+// Padding is deferred until the next field is appended, so a line never ends
+// in whitespace.
+type textLine struct {
+	layout *TextLayout
+	pad    int
+	fields int
+}
+
+// newTextLine starts tracking alignment after msg has been written.
+func newTextLine(layout *TextLayout, msg string) textLine {
+	ln := textLine{layout: layout}
+	if layout != nil && layout.MessageWidth > 0 {
+		ln.pad = layout.MessageWidth - textWidth(msg)
+	}
+	return ln
+}
+
+// multilineText applies TextLayout.Multiline to s.
+//
+// Under MultilineIndent, trailing line breaks are dropped so that a message
+// ending in a newline does not produce an empty continuation line.
+func multilineText(layout *TextLayout, s string) string {
+	if layout == nil || layout.Multiline == MultilineRaw || !strings.ContainsAny(s, "\r\n") {
+		return s
+	}
+	if layout.Multiline == MultilineEscape {
+		return _multilineEscaper.Replace(s)
+	}
+	return _multilineIndenter.Replace(strings.TrimRight(s, "\r\n"))
+}
+
+var (
+	_multilineEscaper  = strings.NewReplacer("\r", `\r`, "\n", `\n`)
+	_multilineIndenter = strings.NewReplacer("\r\n", "\n"+multilineIndent, "\n", "\n"+multilineIndent, "\r", "\n"+multilineIndent)
+)
 
+// truncateValue applies TextLayout.MaxValueWidth and MaxValueWidths to the value of key.
+func truncateValue(layout *TextLayout, key, val string) string {
+	if layout == nil {
+		return val
+	}
+	width, ok := layout.MaxValueWidths[key]
+	if !ok {
+		width = layout.MaxValueWidth
+	}
+	if width <= 0 || len(val) <= width {
+		// A string of n bytes never occupies more than n columns.
+		return val
+	}
+	return runewidth.Truncate(val, width, "…")
+}
+
+// appendLevelLabel writes the styled label for level followed by a space.
+//
+// With TextLayout.AlignLevels, the label is padded to the widest label in st,
+// and a level without a label is replaced by blank space of the same width.
+func appendLevelLabel(b *buffer, st *Styles, layout *TextLayout, level Level) {
+	label, ok := st.CachedLevelStrings[level]
+	if !ok {
+		if lvlStyle, found := st.Levels[level]; found {
+			label, ok = lvlStyle.String(), true
+		}
+	}
+	if layout == nil || !layout.AlignLevels {
+		if ok {
+			b.WriteString(label)
 			b.WriteByte(' ')
+		}
+		return
+	}
+	b.WriteString(label)
+	for n := st.levelWidth - textWidth(label); n > 0; n-- {
+		b.WriteByte(' ')
+	}
+	b.WriteByte(' ')
+}
 
-			keyStr := st.Key.Render(key)
-			if ks, ok := st.Keys[key]; ok {
-				keyStr = ks.Render(key)
-			}
+// appendTextField writes a styled key=value pair, preceded by a space.
+//
+// It applies the per key overrides in Styles.Keys and Styles.Values, styles
+// error values with Styles.ErrorValue, and quotes values containing spaces or
+// equals signs. Pairs with an empty key are skipped.
+func appendTextField(b *buffer, st *Styles, ln *textLine, key, val string, isErr bool) {
+	if key == "" {
+		return
+	}
+	mark, line := len(b.B), *ln
+	writeTextField(b, st, ln, key, val, isErr)
+	if !b.keepField(mark) {
+		*ln = line
+	}
+}
 
-			valStr := st.Value.Render(val)
-			if vs, ok := st.Values[key]; ok {
-				valStr = vs.Render(val)
-			}
+// writeTextField writes one key-value pair for appendTextField.
+func writeTextField(b *buffer, st *Styles, ln *textLine, key, val string, isErr bool) {
+	for ; ln.pad > 0; ln.pad-- {
+		b.WriteByte(' ')
+	}
+	if ln.fields == 0 {
+		b.WriteByte(' ')
+		b.WriteString(st.FieldsOpen)
+	} else if st.FieldSeparator != "" {
+		b.WriteString(st.FieldSeparator)
+	} else {
+		b.WriteByte(' ')
+	}
+	ln.fields++
 
-			sep := st.Separator.Render("=")
+	val = multilineText(ln.layout, val)
+	val = truncateValue(ln.layout, key, val)
+	keyStyle := &st.Key
+	if ks, ok := st.Keys[key]; ok {
+		keyStyle = &ks
+	}
 
-			b.WriteString(keyStr)
-			b.WriteString(sep)
-			if strings.Contains(val, " ") || strings.Contains(val, "=") {
-				b.WriteString(`"` + valStr + `"`)
-			} else {
-				b.WriteString(valStr)
-			}
+	valStyle := &st.Value
+	if vs, ok := st.Values[key]; ok {
+		valStyle = &vs
+	} else if isErr {
+		valStyle = &st.ErrorValue
+	}
+
+	kvSep := st.KeyValueSeparator
+	if kvSep == "" {
+		kvSep = "="
+	}
+
+	b.B = keyStyle.appendRender(b.B, key, "")
+	b.B = st.Separator.appendRender(b.B, kvSep, "")
+	// A separator of only whitespace is covered by the check for spaces.
+	fieldSep := strings.TrimSpace(st.FieldSeparator)
+	quoted := strings.Contains(val, " ") || strings.Contains(val, "=") ||
+		(fieldSep != "" && strings.Contains(val, fieldSep)) ||
+		(st.FieldsClose != "" && strings.Contains(val, st.FieldsClose))
+	if quoted {
+		b.WriteByte('"')
+	}
+	b.B = valStyle.appendRender(b.B, val, "")
+	if quoted {
+		b.WriteByte('"')
+	}
+
+	if ln.layout != nil && ln.layout.FieldWidth > 0 {
+		width := textWidth(key) + textWidth(kvSep) + textWidth(val)
+		if quoted {
+			width += 2
 		}
+		ln.pad = ln.layout.FieldWidth - width
 	}
+}
 
-	processFields(l.fields)
-	processFields(callFields)
+// appendTextDropped ends the field limit on b, attaching the number of fields
+// it dropped under TruncatedFieldsKey.
+func appendTextDropped(b *buffer, st *Styles, ln *textLine) {
+	if n := b.droppedFields(); n > 0 {
+		appendTextField(b, st, ln, TruncatedFieldsKey, strconv.Itoa(n), false)
+	}
+}
 
-	// Helper to process typed fields
-	processTypedFields := func(fields []Field) {
-		for i := 0; i < len(fields); i++ {
-			f := &fields[i]
-			if f.Key == "" {
-				continue
-			}
+// closeTextFields writes Styles.FieldsClose if any field was written on ln.
+func closeTextFields(b *buffer, st *Styles, ln *textLine) {
+	if ln.fields > 0 {
+		b.WriteString(st.FieldsClose)
+	}
+}
 
-			b.WriteByte(' ')
+// textFieldValue renders the value of a strongly typed Field for the TextFormatter.
+//
+// Objects and arrays render as compact JSON.
+func textFieldValue(f *Field, ff fieldFormat) string {
+	return textFieldValueMax(f, ff, 0)
+}
 
-			keyStr := st.Key.Render(f.Key)
-			if ks, ok := st.Keys[f.Key]; ok {
-				keyStr = ks.Render(f.Key)
-			}
+// appendTextTypedField writes f with appendTextField, rendering objects and
+// arrays no further than the limit on b allows.
+func appendTextTypedField(b *buffer, st *Styles, ln *textLine, f *Field, ff fieldFormat) {
+	appendTextField(b, st, ln, f.Key, textFieldValueMax(f, ff, b.room()), f.isError())
+}
 
-			var val string
-			switch f.Type {
-			case StringType:
-				val = f.Str
-			case IntType:
-				val = strconv.FormatInt(f.Int, 10)
-			case BoolType:
-				val = strconv.FormatBool(f.Int == 1)
-			case ErrorType:
-				if f.Any != nil {
-					val = f.Any.(error).Error()
-				}
-			case TimeType:
-				var buf [64]byte
-				tb := appendTime(buf[:0], time.Unix(0, f.Int), cfg.timeFormat)
-				val = string(tb)
-			case DurationType:
-				val = time.Duration(f.Int).String()
-			case ObjectType:
-				var buf buffer
-				sub := getJSONEncoder(&buf)
-				buf.WriteByte('{')
-				if f.Any != nil {
-					f.Any.(ObjectMarshaler).MarshalLogObject(sub)
-				}
-				buf.WriteByte('}')
-				putJSONEncoder(sub)
-				val = string(buf.B)
-			case ArrayType:
-				var buf buffer
-				sub := getJSONEncoder(&buf)
-				buf.WriteByte('[')
-				if f.Any != nil {
-					f.Any.(ArrayMarshaler).MarshalLogArray(sub)
-				}
-				buf.WriteByte(']')
-				putJSONEncoder(sub)
-				val = string(buf.B)
-			case IntsType:
-				var buf buffer
-				buf.WriteByte('[')
-				if f.Int > 0 {
-					slice := unsafe.Slice((*int)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
-					for i, v := range slice {
-						if i > 0 {
-							buf.WriteByte(',')
-						}
-						buf.B = strconv.AppendInt(buf.B, int64(v), 10)
-					}
-				}
-				buf.WriteByte(']')
-				val = string(buf.B)
-			case StringsType:
-				var buf buffer
-				buf.WriteByte('[')
-				if f.Int > 0 {
-					slice := unsafe.Slice((*string)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
-					for i, v := range slice {
-						if i > 0 {
-							buf.WriteByte(',')
-						}
-						appendJSONString(&buf, v)
-					}
-				}
-				buf.WriteByte(']')
-				val = string(buf.B)
-			case TimesType:
-				var buf buffer
-				buf.WriteByte('[')
-				if f.Int > 0 {
-					slice := unsafe.Slice((*time.Time)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
-					for i, v := range slice {
-						if i > 0 {
-							buf.WriteByte(',')
-						}
-						buf.WriteByte('"')
-						buf.B = appendTime(buf.B, v, cfg.timeFormat)
-						buf.WriteByte('"')
-					}
+// textFieldValueMax is textFieldValue with objects and arrays cut short once
+// they pass limit bytes, unless limit is zero.
+func textFieldValueMax(f *Field, ff fieldFormat, limit int) string {
+	switch f.Type {
+	case StringType:
+		return f.Str
+	case IntType:
+		return formatInt(f.Int)
+	case BoolType:
+		return strconv.FormatBool(f.Int == 1)
+	case ErrorType:
+		if f.Any != nil {
+			return f.Any.(error).Error()
+		}
+		return ""
+	case TimeType:
+		var buf [64]byte
+		return string(appendTime(buf[:0], fieldTime(f, ff), ff.layout))
+	case DurationType:
+		return textDuration(time.Duration(f.Int), ff.durations)
+	case ObjectType:
+		buf := buffer{limit: limit}
+		sub := getJSONEncoder(&buf, ff.durations, ff.loc)
+		buf.WriteByte('{')
+		if f.Any != nil {
+			f.Any.(ObjectMarshaler).MarshalLogObject(sub)
+		}
+		buf.WriteByte('}')
+		putJSONEncoder(sub)
+		return string(buf.B)
+	case ArrayType:
+		buf := buffer{limit: limit}
+		sub := getJSONEncoder(&buf, ff.durations, ff.loc)
+		buf.WriteByte('[')
+		if f.Any != nil {
+			f.Any.(ArrayMarshaler).MarshalLogArray(sub)
+		}
+		buf.WriteByte(']')
+		putJSONEncoder(sub)
+		return string(buf.B)
+	case IntsType:
+		var buf buffer
+		buf.WriteByte('[')
+		if f.Int > 0 {
+			slice := unsafe.Slice((*int)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
+			for i, v := range slice {
+				if i > 0 {
+					buf.WriteByte(',')
 				}
-				buf.WriteByte(']')
-				val = string(buf.B)
-			case AnyType:
-				val = formatAny(f.Any)
+				buf.B = appendInt64(buf.B, int64(v))
 			}
-
-			valStr := st.Value.Render(val)
-			if vs, ok := st.Values[f.Key]; ok {
-				valStr = vs.Render(val)
+		}
+		buf.WriteByte(']')
+		return string(buf.B)
+	case StringsType:
+		var buf buffer
+		buf.WriteByte('[')
+		if f.Int > 0 {
+			slice := unsafe.Slice((*string)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
+			for i, v := range slice {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				appendJSONString(&buf, v)
 			}
-
-			sep := st.Separator.Render("=")
-
-			b.WriteString(keyStr)
-			b.WriteString(sep)
-			if strings.Contains(val, " ") || strings.Contains(val, "=") {
-				b.WriteString(`"` + valStr + `"`)
-			} else {
-				b.WriteString(valStr)
+		}
+		buf.WriteByte(']')
+		return string(buf.B)
+	case TimesType:
+		var buf buffer
+		buf.WriteByte('[')
+		if f.Int > 0 {
+			slice := unsafe.Slice((*time.Time)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
+			for i, v := range slice {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				buf.WriteByte('"')
+				buf.B = appendTime(buf.B, ff.in(v), ff.layout)
+				buf.WriteByte('"')
 			}
 		}
+		buf.WriteByte(']')
+		return string(buf.B)
+	case AnyType:
+		return textValue(f.Any, ff)
 	}
-
-	processTypedFields(l.typedFields)
-	processTypedFields(ctxFields)
-	processTypedFields(callTypedFields)
-
-	b.WriteByte('\n')
+	return ""
 }
 
 // formatLogJSON formats a log entry directly onto a pooled buffer as JSON.
 //
 // It bypasses the Entry struct allocation, providing maximum performance for
 // simple JSON logs.
-func formatLogJSON(b *buffer, l *Logger, cfg *loggerConfig, level Level, msg string, callFields []any, callTypedFields []Field, ctxFields []Field, t time.Time) {
+//
+// encoded holds call fields already encoded as JSON, each preceded by a comma,
+// as a Builder writes them. They follow the other call fields.
+func formatLogJSON(b *buffer, base *baseFields, cfg *loggerConfig, level Level, msg string, callFields []any, callTypedFields []Field, encoded []byte, ctxFields []Field, ci *callerInfo, t time.Time) {
 	first := true
 
 	if !t.IsZero() {
 		b.B = append(b.B, '{', '"', 't', 'i', 'm', 'e', '"', ':')
-		switch cfg.timeFormat {
-		case "unix":
-			b.B = strconv.AppendInt(b.B, t.Unix(), 10)
-		case "unix_milli":
-			b.B = strconv.AppendInt(b.B, t.UnixMilli(), 10)
-		default:
-			b.B = append(b.B, '"')
+		if unixFormat(cfg.timeFormat) {
 			b.B = appendTime(b.B, t, cfg.timeFormat)
+		} else {
+			b.B = append(b.B, '"')
+			b.B = cfg.times.appendTime(b.B, t, cfg.timeFormat)
 			b.B = append(b.B, '"')
 		}
 		first = false
@@ -268,6 +420,10 @@ func formatLogJSON(b *buffer, l *Logger, cfg *loggerConfig, level Level, msg str
 		b.B = append(b.B, level.JSONField()...)
 	}
 
+	if ci != nil {
+		first = appendJSONCaller(b, ci.formatted, ci.file, ci.line, ci.fn, cfg.callerObject, first)
+	}
+
 	if cfg.prefix != "" {
 		if !first {
 			b.B = append(b.B, ',', '"', 'p', 'r', 'e', 'f', 'i', 'x', '"', ':')
@@ -278,275 +434,215 @@ func formatLogJSON(b *buffer, l *Logger, cfg *loggerConfig, level Level, msg str
 		appendJSONString(b, cfg.prefix)
 	}
 
-	if msg != "" {
+	origLen := len(msg)
+	msg, truncated := truncateMessage(msg, cfg.maxMessageBytes)
+	if msg != "" || truncated {
 		if !first {
 			b.B = append(b.B, ',', '"', 'm', 's', 'g', '"', ':')
 		} else {
 			b.B = append(b.B, '"', 'm', 's', 'g', '"', ':')
 		}
 		first = false
-		appendJSONString(b, msg)
+		if truncated {
+			// Reopen the closing quote so the ASCII marker lands inside the string.
+			appendJSONString(b, msg)
+			b.B = append(b.B[:len(b.B)-1], TruncationMarker...)
+			b.B = append(b.B, '"')
+		} else {
+			appendJSONString(b, msg)
+		}
+	}
+
+	if cfg.sequence != nil {
+		appendJSONKey(b, SequenceKey, !first)
+		b.B = strconv.AppendUint(b.B, cfg.sequence.Add(1), 10)
+		first = false
+	}
+
+	if cfg.name != "" {
+		appendJSONKey(b, NameKey, !first)
+		appendJSONString(b, cfg.name)
+		first = false
 	}
 
 	// pre-encoded json fields
-	preEncoded := l.preEncodedJSON
-	hasPreEncoded := len(preEncoded) > 0 || (len(l.fields) == 0 && len(l.typedFields) == 0)
-	if hasPreEncoded && len(preEncoded) > 0 {
+	// Under MaxEntryBytes, fields that do not fit are encoded one by one so
+	// that only those past the limit are dropped.
+	preEncoded := base.json != nil && base.jsonFormat == cfg.fieldFormat() && b.fits(base.json.size)
+	hasPreEncoded := preEncoded || (len(base.fields) == 0 && len(base.typedFields) == 0)
+	if preEncoded {
 		if first {
 			// Skip leading comma if this is the first item
-			b.B = append(b.B, preEncoded[1:]...)
+			b.B = base.json.appendTo(b.B, 1)
 		} else {
-			b.B = append(b.B, preEncoded...)
+			b.B = base.json.appendTo(b.B, 0)
 		}
 		first = false
 	}
 
 	// logger fields (if not pre-encoded)
 	if !hasPreEncoded {
-		for i := 0; i < len(l.fields); i += 2 {
-			if i+1 < len(l.fields) {
-				encodeKeyValToJSON(b, l.fields[i], l.fields[i+1], !first)
+		for i := 0; i < len(base.fields); i += 2 {
+			if i+1 < len(base.fields) {
+				encodeKeyValToJSON(b, base.fields[i], base.fields[i+1], cfg.fieldFormat(), !first)
 				first = false
 			}
 		}
-		for i := 0; i < len(l.typedFields); i++ {
-			encodeFieldToJSON(b, &l.typedFields[i], cfg.timeFormat, !first)
+		for i := 0; i < len(base.typedFields); i++ {
+			encodeFieldToJSON(b, &base.typedFields[i], cfg.fieldFormat(), !first)
 			first = false
 		}
 	}
 
+	// level-conditional logger fields
+	for i := range base.conditional {
+		if c := &base.conditional[i]; c.applies(level) {
+			if len(c.preEncodedJSON) > 0 && !first && c.jsonFormat == cfg.fieldFormat() && b.fits(len(c.preEncodedJSON)) {
+				b.B = append(b.B, c.preEncodedJSON...)
+				continue
+			}
+			for j := range c.fields {
+				encodeFieldToJSON(b, &c.fields[j], cfg.fieldFormat(), !first)
+				first = false
+			}
+		}
+	}
+
+	for i := 0; i < len(ctxFields); i++ {
+		encodeFieldToJSON(b, &ctxFields[i], cfg.fieldFormat(), !first)
+		first = false
+	}
+
 	for i := 0; i < len(callFields); i += 2 {
 		if i+1 < len(callFields) {
-			encodeKeyValToJSON(b, callFields[i], callFields[i+1], !first)
+			encodeKeyValToJSON(b, callFields[i], callFields[i+1], cfg.fieldFormat(), !first)
 			first = false
 		}
 	}
 
-	for i := 0; i < len(ctxFields); i++ {
-		encodeFieldToJSON(b, &ctxFields[i], cfg.timeFormat, !first)
+	for i := 0; i < len(callTypedFields); i++ {
+		encodeFieldToJSON(b, &callTypedFields[i], cfg.fieldFormat(), !first)
 		first = false
 	}
 
-	for i := 0; i < len(callTypedFields); i++ {
-		encodeFieldToJSON(b, &callTypedFields[i], cfg.timeFormat, !first)
+	if len(encoded) > 0 {
+		if first {
+			encoded = encoded[1:]
+		}
+		b.B = append(b.B, encoded...)
 		first = false
 	}
 
+	first = appendJSONDropped(b, first)
+	if truncated {
+		appendJSONKey(b, TruncatedMessageKey, !first)
+		b.B = appendInt64(b.B, int64(origLen))
+	}
+
 	b.B = append(b.B, '}', '\n')
 }
 
-// formatEntry formats a log entry into a string or JSON directly onto a pooled buffer.
-func formatEntry(b *buffer, e *Entry) {
+// formatEntry formats a log entry as text, JSON, or a binary record directly onto a pooled buffer.
+func formatEntry(b *buffer, e *Entry, tc *timeCache) {
+	if e.encoder != nil {
+		b.B = e.encoder.AppendEntry(b.B, e)
+		return
+	}
 	switch e.Formatter {
 	case JSONFormatter:
-		formatJSON(b, e)
+		formatJSON(b, e, tc)
+	case BinaryFormatter:
+		formatBinary(b, e)
 	case TextFormatter:
 		fallthrough
 	default:
-		formatText(b, e)
+		formatText(b, e, tc)
 	}
 }
 
-func formatText(b *buffer, e *Entry) {
-	st := _defaultStyles
+func formatText(b *buffer, e *Entry, tc *timeCache) {
+	st := e.Styles
+	if st == nil {
+		st = _defaultStyles
+	}
 
 	// timestamp
 	if !e.Time.IsZero() {
-		// appendTime is a custom zero allocation encoder for common formats
-		var buf [64]byte
-		tb := appendTime(buf[:0], e.Time, e.TimeFormat)
-		b.WriteString(st.Timestamp.Render(string(tb)))
+		b.WriteString(tc.render(e.Time, e.TimeFormat, st))
 		b.WriteByte(' ')
 	}
 
 	// level
 	if e.Level != noLevel {
-		if lvlStyle, ok := st.Levels[e.Level]; ok {
-			b.WriteString(lvlStyle.String())
-			b.WriteByte(' ')
-		}
+		appendLevelLabel(b, st, e.Layout, e.Level)
 	}
 
 	// caller
 	if e.Caller != "" {
 		caller := "<" + e.Caller + ">"
-		b.WriteString(st.Caller.Render(caller))
+		b.B = st.Caller.appendRender(b.B, caller, "")
 		b.WriteByte(' ')
 	}
 
 	// prefix
 	if e.Prefix != "" {
-		b.WriteString(st.Prefix.Render(e.Prefix + ":"))
+		b.B = st.Prefix.appendRender(b.B, e.Prefix, ":")
 		b.WriteByte(' ')
 	}
 
 	// message
-	if e.Message != "" {
-		b.WriteString(st.Message.Render(e.Message))
+	msg := e.Message
+	var expanded *buffer
+	if e.template {
+		expanded = getBuffer()
+		expanded.B = appendTemplate(expanded.B, e)
+		msg = unsafe.String(unsafe.SliceData(expanded.B), len(expanded.B))
+	}
+	msg = multilineText(e.Layout, msg)
+	if msg != "" {
+		b.B = st.messageStyle(e.Level).appendRender(b.B, msg, "")
+	}
+	ln := newTextLine(e.Layout, msg)
+	if expanded != nil {
+		putBuffer(expanded)
 	}
 
-	// fields
-	for i := 0; i < len(e.Fields); i += 2 {
-		if i+1 >= len(e.Fields) {
-			break
-		}
-
-		key := formatAny(e.Fields[i])
-		val := formatAny(e.Fields[i+1])
-
-		if key == "" {
-			continue
-		}
-
-		b.WriteByte(' ')
-
-		keyStr := st.Key.Render(key)
-		if ks, ok := st.Keys[key]; ok {
-			keyStr = ks.Render(key)
-		}
-
-		valStr := st.Value.Render(val)
-		if vs, ok := st.Values[key]; ok {
-			valStr = vs.Render(val)
-		}
-
-		sep := st.Separator.Render("=")
+	if e.Sequence != 0 {
+		appendTextField(b, st, &ln, SequenceKey, strconv.FormatUint(e.Sequence, 10), false)
+	}
 
-		b.WriteString(keyStr)
-		b.WriteString(sep)
-		if strings.Contains(val, " ") || strings.Contains(val, "=") {
-			b.WriteString(`"` + valStr + `"`)
-		} else {
-			b.WriteString(valStr)
-		}
+	// fields
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		appendTextField(b, st, &ln, formatAny(e.Fields[i]), textValue(e.Fields[i+1], e.fieldFormat()), isError(e.Fields[i+1]))
 	}
 
 	// typed fields
-	for i := 0; i < len(e.TypedFields); i++ {
+	for i := range e.TypedFields {
 		f := &e.TypedFields[i]
-		if f.Key == "" {
-			continue
-		}
-
-		b.WriteByte(' ')
-
-		keyStr := st.Key.Render(f.Key)
-		if ks, ok := st.Keys[f.Key]; ok {
-			keyStr = ks.Render(f.Key)
-		}
-
-		var val string
-		switch f.Type {
-		case StringType:
-			val = f.Str
-		case IntType:
-			val = strconv.FormatInt(f.Int, 10)
-		case BoolType:
-			val = strconv.FormatBool(f.Int == 1)
-		case ErrorType:
-			if f.Any != nil {
-				val = f.Any.(error).Error()
-			}
-		case TimeType:
-			var buf [64]byte
-			tb := appendTime(buf[:0], time.Unix(0, f.Int), e.TimeFormat)
-			val = string(tb)
-		case DurationType:
-			val = time.Duration(f.Int).String()
-		case ObjectType:
-			// For text format, we can just use JSON encoding for the object
-			var buf buffer
-			sub := getJSONEncoder(&buf)
-			buf.WriteByte('{')
-			if f.Any != nil {
-				f.Any.(ObjectMarshaler).MarshalLogObject(sub)
-			}
-			buf.WriteByte('}')
-			putJSONEncoder(sub)
-			val = string(buf.B)
-		case ArrayType:
-			var buf buffer
-			sub := getJSONEncoder(&buf)
-			buf.WriteByte('[')
-			if f.Any != nil {
-				f.Any.(ArrayMarshaler).MarshalLogArray(sub)
-			}
-			buf.WriteByte(']')
-			putJSONEncoder(sub)
-			val = string(buf.B)
-		case IntsType:
-			var buf buffer
-			buf.WriteByte('[')
-			if f.Int > 0 {
-				slice := unsafe.Slice((*int)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
-				for i, v := range slice {
-					if i > 0 {
-						buf.WriteByte(',')
-					}
-					buf.B = strconv.AppendInt(buf.B, int64(v), 10)
-				}
-			}
-			buf.WriteByte(']')
-			val = string(buf.B)
-		case StringsType:
-			var buf buffer
-			buf.WriteByte('[')
-			if f.Int > 0 {
-				slice := unsafe.Slice((*string)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
-				for i, v := range slice {
-					if i > 0 {
-						buf.WriteByte(',')
-					}
-					appendJSONString(&buf, v)
-				}
-			}
-			buf.WriteByte(']')
-			val = string(buf.B)
-		case TimesType:
-			var buf buffer
-			buf.WriteByte('[')
-			if f.Int > 0 {
-				slice := unsafe.Slice((*time.Time)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
-				for i, v := range slice {
-					if i > 0 {
-						buf.WriteByte(',')
-					}
-					buf.WriteByte('"')
-					buf.B = appendTime(buf.B, v, e.TimeFormat)
-					buf.WriteByte('"')
-				}
-			}
-			buf.WriteByte(']')
-			val = string(buf.B)
-		case AnyType:
-			val = formatAny(f.Any)
-		}
-
-		valStr := st.Value.Render(val)
-		if vs, ok := st.Values[f.Key]; ok {
-			valStr = vs.Render(val)
-		}
-
-		sep := st.Separator.Render("=")
-
-		b.WriteString(keyStr)
-		b.WriteString(sep)
-		if strings.Contains(val, " ") || strings.Contains(val, "=") {
-			b.WriteString(`"` + valStr + `"`)
-		} else {
-			b.WriteString(valStr)
+		if f.Key != "" {
+			appendTextTypedField(b, st, &ln, f, e.fieldFormat())
 		}
 	}
+	appendTextDropped(b, st, &ln)
+	closeTextFields(b, st, &ln)
 
-	if len(e.Stack) > 0 {
+	if len(e.Stack) > 0 && e.deferStack {
+		b.WriteByte('\n')
+		b.deferStack(e.Stack, st, e.StackDepth, e.StackFilter, false)
+	} else if len(e.Stack) > 0 {
 		b.WriteByte('\n')
-		writeStacktrace(b, e.Stack, st)
+		writeStacktrace(b, e.Stack, st, e.StackDepth, e.StackFilter)
 		// strip trailing newline from buf to avoid double newline since formatText adds one
 		if len(b.B) > 0 && b.B[len(b.B)-1] == '\n' {
 			b.B = b.B[:len(b.B)-1]
 		}
 	}
 
+	if len(e.Goroutines) > 0 {
+		b.WriteByte('\n')
+		b.Write(bytes.TrimRight(e.Goroutines, "\n"))
+	}
+
 	b.WriteByte('\n')
 }
 
@@ -554,7 +650,7 @@ func formatText(b *buffer, e *Entry) {
 //
 // It completely bypasses the standard library's json.Marshal. This eliminates
 // map allocations and reflection, significantly improving serialization speed.
-func formatJSON(b *buffer, e *Entry) {
+func formatJSON(b *buffer, e *Entry, tc *timeCache) {
 	first := true
 	addSep := func() {
 		if !first {
@@ -565,14 +661,11 @@ func formatJSON(b *buffer, e *Entry) {
 
 	if !e.Time.IsZero() {
 		b.B = append(b.B, '{', '"', 't', 'i', 'm', 'e', '"', ':')
-		switch e.TimeFormat {
-		case "unix":
-			b.B = strconv.AppendInt(b.B, e.Time.Unix(), 10)
-		case "unix_milli":
-			b.B = strconv.AppendInt(b.B, e.Time.UnixMilli(), 10)
-		default:
-			b.B = append(b.B, '"')
+		if unixFormat(e.TimeFormat) {
 			b.B = appendTime(b.B, e.Time, e.TimeFormat)
+		} else {
+			b.B = append(b.B, '"')
+			b.B = tc.appendTime(b.B, e.Time, e.TimeFormat)
 			b.B = append(b.B, '"')
 		}
 		first = false
@@ -585,15 +678,7 @@ func formatJSON(b *buffer, e *Entry) {
 		b.B = append(b.B, e.Level.JSONField()...)
 	}
 
-	if e.Caller != "" {
-		if !first {
-			b.B = append(b.B, ',', '"', 'c', 'a', 'l', 'l', 'e', 'r', '"', ':')
-		} else {
-			b.B = append(b.B, '"', 'c', 'a', 'l', 'l', 'e', 'r', '"', ':')
-		}
-		first = false
-		appendJSONString(b, e.Caller)
-	}
+	first = appendJSONCaller(b, e.Caller, e.CallerFile, e.CallerLine, e.CallerFunc, e.callerObject, first)
 
 	if e.Prefix != "" {
 		if !first {
@@ -615,6 +700,12 @@ func formatJSON(b *buffer, e *Entry) {
 		appendJSONString(b, e.Message)
 	}
 
+	if e.Sequence != 0 {
+		appendJSONKey(b, SequenceKey, !first)
+		b.B = strconv.AppendUint(b.B, e.Sequence, 10)
+		first = false
+	}
+
 	// pre-encoded json fields
 	if len(e.PreEncodedJSON) > 0 {
 		if first {
@@ -626,42 +717,117 @@ func formatJSON(b *buffer, e *Entry) {
 		first = false
 	}
 
-	// fields
-	for i := 0; i < len(e.Fields); i += 2 {
-		if i+1 < len(e.Fields) {
-			encodeKeyValToJSON(b, e.Fields[i], e.Fields[i+1], !first)
+	if e.duplicateKeys != DuplicateKeysAllow {
+		// Hooks may have added loosely typed fields; they join the typed
+		// ones so that every key is compared.
+		fields := e.TypedFields
+		if len(e.Fields) > 0 {
+			var scratch [_dedupLinearMax]Field
+			fields = append(appendKeyVals(scratch[:0], e.Fields), e.TypedFields...)
+		}
+		first = appendDedupJSON(b, fields, e.duplicateKeys, e.fieldFormat(), first)
+	} else {
+		// fields
+		for i := 0; i < len(e.Fields); i += 2 {
+			if i+1 < len(e.Fields) {
+				encodeKeyValToJSON(b, e.Fields[i], e.Fields[i+1], e.fieldFormat(), !first)
+				first = false
+			}
+		}
+
+		// typed fields
+		for i := 0; i < len(e.TypedFields); i++ {
+			encodeFieldToJSON(b, &e.TypedFields[i], e.fieldFormat(), !first)
 			first = false
 		}
 	}
+	first = appendJSONDropped(b, first)
 
-	// typed fields
-	for i := 0; i < len(e.TypedFields); i++ {
-		encodeFieldToJSON(b, &e.TypedFields[i], e.TimeFormat, !first)
+	if len(e.Stack) > 0 {
+		appendJSONKey(b, StacktraceKey, !first)
+		if e.deferStack {
+			b.deferStack(e.Stack, nil, e.StackDepth, e.StackFilter, true)
+		} else {
+			appendJSONStacktrace(b, e.Stack, e.StackDepth, e.StackFilter)
+		}
 		first = false
 	}
 
+	if len(e.Goroutines) > 0 {
+		appendJSONKey(b, GoroutinesKey, !first)
+		appendJSONString(b, string(e.Goroutines))
+	}
+
 	b.B = append(b.B, '}', '\n')
 }
 
+// appendJSONCaller appends the caller, as an object of file, line, and func
+// when object is set, or else as its formatted string, and reports whether
+// the JSON object is still empty. It appends nothing for an unknown caller.
+func appendJSONCaller(b *buffer, formatted, file string, line int, fn string, object, first bool) bool {
+	if object && file != "" {
+		appendJSONKey(b, "caller", !first)
+		b.B = append(b.B, `{"file":`...)
+		appendJSONString(b, file)
+		b.B = append(b.B, `,"line":`...)
+		b.B = appendInt64(b.B, int64(line))
+		b.B = append(b.B, `,"func":`...)
+		appendJSONString(b, fn)
+		b.B = append(b.B, '}')
+		return false
+	}
+	if formatted == "" {
+		return first
+	}
+	if !first {
+		b.B = append(b.B, ',', '"', 'c', 'a', 'l', 'l', 'e', 'r', '"', ':')
+	} else {
+		b.B = append(b.B, '"', 'c', 'a', 'l', 'l', 'e', 'r', '"', ':')
+	}
+	appendJSONString(b, formatted)
+	return false
+}
+
+// appendJSONDropped ends the field limit on b, attaching the number of fields
+// it dropped under TruncatedFieldsKey, and reports whether the object is
+// still empty.
+func appendJSONDropped(b *buffer, first bool) bool {
+	if n := b.droppedFields(); n > 0 {
+		appendJSONKey(b, TruncatedFieldsKey, b.B[len(b.B)-1] != '{')
+		b.B = appendInt64(b.B, int64(n))
+		return false
+	}
+	return first
+}
+
 // encodeKeyValToJSON encodes a loosely typed key-value pair to JSON.
-func encodeKeyValToJSON(b *buffer, key, val any, prependComma bool) {
+func encodeKeyValToJSON(b *buffer, key, val any, ff fieldFormat, prependComma bool) {
+	mark := len(b.B)
 	// Optimize for string keys to avoid formatAny call
 	if k, ok := key.(string); ok {
 		appendJSONKey(b, k, prependComma)
 	} else {
 		appendJSONKey(b, formatAny(key), prependComma)
 	}
-	appendJSONAny(b, val)
+	appendJSONAny(b, val, ff)
+	b.keepField(mark)
 }
 
 // encodeFieldToJSON encodes a strongly typed Field to JSON and appends it to the buffer.
-func encodeFieldToJSON(b *buffer, f *Field, timeFormat string, prependComma bool) {
+func encodeFieldToJSON(b *buffer, f *Field, ff fieldFormat, prependComma bool) {
+	mark := len(b.B)
 	appendJSONKey(b, f.Key, prependComma)
+	appendJSONFieldValue(b, f, ff)
+	b.keepField(mark)
+}
+
+// appendJSONFieldValue appends the value of a strongly typed Field as JSON.
+func appendJSONFieldValue(b *buffer, f *Field, ff fieldFormat) {
 	switch f.Type {
 	case StringType:
 		appendJSONString(b, f.Str)
 	case IntType:
-		b.B = strconv.AppendInt(b.B, f.Int, 10)
+		b.B = appendInt64(b.B, f.Int)
 	case BoolType:
 		b.B = strconv.AppendBool(b.B, f.Int == 1)
 	case ErrorType:
@@ -672,13 +838,13 @@ func encodeFieldToJSON(b *buffer, f *Field, timeFormat string, prependComma bool
 		}
 	case TimeType:
 		b.B = append(b.B, '"')
-		b.B = appendTime(b.B, time.Unix(0, f.Int), timeFormat)
+		b.B = appendTime(b.B, fieldTime(f, ff), ff.layout)
 		b.B = append(b.B, '"')
 	case DurationType:
-		b.B = strconv.AppendInt(b.B, f.Int, 10)
+		appendJSONDuration(b, time.Duration(f.Int), ff.durations)
 	case ObjectType:
 		b.B = append(b.B, '{')
-		sub := getJSONEncoder(b)
+		sub := getJSONEncoder(b, ff.durations, ff.loc)
 		if f.Any != nil {
 			f.Any.(ObjectMarshaler).MarshalLogObject(sub)
 		}
@@ -686,7 +852,7 @@ func encodeFieldToJSON(b *buffer, f *Field, timeFormat string, prependComma bool
 		b.B = append(b.B, '}')
 	case ArrayType:
 		b.B = append(b.B, '[')
-		sub := getJSONEncoder(b)
+		sub := getJSONEncoder(b, ff.durations, ff.loc)
 		if f.Any != nil {
 			f.Any.(ArrayMarshaler).MarshalLogArray(sub)
 		}
@@ -700,7 +866,7 @@ func encodeFieldToJSON(b *buffer, f *Field, timeFormat string, prependComma bool
 				if i > 0 {
 					b.B = append(b.B, ',')
 				}
-				b.B = strconv.AppendInt(b.B, int64(v), 10)
+				b.B = appendInt64(b.B, int64(v))
 			}
 		}
 		b.B = append(b.B, ']')
@@ -725,13 +891,13 @@ func encodeFieldToJSON(b *buffer, f *Field, timeFormat string, prependComma bool
 					b.B = append(b.B, ',')
 				}
 				b.B = append(b.B, '"')
-				b.B = appendTime(b.B, v, timeFormat)
+				b.B = appendTime(b.B, ff.in(v), ff.layout)
 				b.B = append(b.B, '"')
 			}
 		}
 		b.B = append(b.B, ']')
 	case AnyType:
-		appendJSONAny(b, f.Any)
+		appendJSONAny(b, f.Any, ff)
 	}
 }
 
@@ -748,19 +914,20 @@ func init() {
 var _hex = "0123456789abcdef"
 
 // appendJSONKey appends a JSON key to the buffer without allocating memory.
+//
+// While fields are limited, the comma is left out directly after the opening
+// brace, where a dropped field would otherwise have preceded it.
 func appendJSONKey(b *buffer, s string, prependComma bool) {
-	if prependComma {
+	if prependComma && (b.limit == 0 || b.B[len(b.B)-1] != '{') {
 		b.B = append(b.B, ',', '"')
 	} else {
 		b.B = append(b.B, '"')
 	}
-	for i := 0; i < len(s); i++ {
-		if _noEscape[s[i]] {
-			b.B = append(b.B, s[:i]...)
-			appendJSONStringEscape(b, s, i)
-			b.B = append(b.B, '"', ':')
-			return
-		}
+	if i := indexJSONEscape(s); i >= 0 {
+		b.B = append(b.B, s[:i]...)
+		appendJSONStringEscape(b, s, i)
+		b.B = append(b.B, '"', ':')
+		return
 	}
 	b.B = append(b.B, s...)
 	b.B = append(b.B, '"', ':')
@@ -768,75 +935,75 @@ func appendJSONKey(b *buffer, s string, prependComma bool) {
 
 // appendJSONString appends a properly escaped JSON string to the buffer without allocating memory.
 //
-// It uses chunked memory copies for maximum performance, mirroring Zap's safeSet approach.
+// It uses chunked memory copies for maximum performance, mirroring Zap's safeSet
+// approach, and finds the bytes to escape eight at a time with indexJSONEscape.
 func appendJSONString(b *buffer, s string) {
 	b.B = append(b.B, '"')
-	for i := 0; i < len(s); i++ {
-		if _noEscape[s[i]] {
-			b.B = append(b.B, s[:i]...)
-			appendJSONStringEscape(b, s, i)
-			b.B = append(b.B, '"')
-			return
-		}
+	if i := indexJSONEscape(s); i >= 0 {
+		b.B = append(b.B, s[:i]...)
+		appendJSONStringEscape(b, s, i)
+		b.B = append(b.B, '"')
+		return
 	}
 	b.B = append(b.B, s...)
 	b.B = append(b.B, '"')
 }
 
+// appendJSONStringEscape appends s[i:], escaping as needed. The byte at i
+// must need escaping, and s[:i] must already be appended.
 func appendJSONStringEscape(b *buffer, s string, i int) {
-	start := i
-	for ; i < len(s); i++ {
-		if _noEscape[s[i]] {
-			if start < i {
-				b.B = append(b.B, s[start:i]...)
-			}
-			c := s[i]
-			switch c {
-			case '"':
-				b.B = append(b.B, '\\', '"')
-			case '\\':
-				b.B = append(b.B, '\\', '\\')
-			case '\n':
-				b.B = append(b.B, '\\', 'n')
-			case '\r':
-				b.B = append(b.B, '\\', 'r')
-			case '\t':
-				b.B = append(b.B, '\\', 't')
-			case '\b':
-				b.B = append(b.B, '\\', 'b')
-			case '\f':
-				b.B = append(b.B, '\\', 'f')
-			default:
-				b.B = append(b.B, '\\', 'u', '0', '0', _hex[c>>4], _hex[c&0xF])
-			}
-			start = i + 1
+	for {
+		switch c := s[i]; c {
+		case '"':
+			b.B = append(b.B, '\\', '"')
+		case '\\':
+			b.B = append(b.B, '\\', '\\')
+		case '\n':
+			b.B = append(b.B, '\\', 'n')
+		case '\r':
+			b.B = append(b.B, '\\', 'r')
+		case '\t':
+			b.B = append(b.B, '\\', 't')
+		case '\b':
+			b.B = append(b.B, '\\', 'b')
+		case '\f':
+			b.B = append(b.B, '\\', 'f')
+		default:
+			b.B = append(b.B, '\\', 'u', '0', '0', _hex[c>>4], _hex[c&0xF])
 		}
-	}
-	if start < len(s) {
-		b.B = append(b.B, s[start:]...)
+		s = s[i+1:]
+		if i = indexJSONEscape(s); i < 0 {
+			b.B = append(b.B, s...)
+			return
+		}
+		b.B = append(b.B, s[:i]...)
 	}
 }
 
 // appendJSONAny appends an arbitrary value to the buffer as json without allocating for common types.
-func appendJSONAny(b *buffer, v any) {
+func appendJSONAny(b *buffer, v any, ff fieldFormat) {
+	if enc, ok := lookupEncoder(v); ok {
+		appendEncoded(b, enc, v, ff)
+		return
+	}
 	switch val := v.(type) {
 	case string:
 		appendJSONString(b, val)
 	case int:
-		b.B = strconv.AppendInt(b.B, int64(val), 10)
+		b.B = appendInt64(b.B, int64(val))
 	case int64:
-		b.B = strconv.AppendInt(b.B, val, 10)
+		b.B = appendInt64(b.B, val)
 	case bool:
 		b.B = strconv.AppendBool(b.B, val)
 	case ObjectMarshaler:
 		b.B = append(b.B, '{')
-		enc := getJSONEncoder(b)
+		enc := getJSONEncoder(b, ff.durations, ff.loc)
 		val.MarshalLogObject(enc)
 		putJSONEncoder(enc)
 		b.B = append(b.B, '}')
 	case ArrayMarshaler:
 		b.B = append(b.B, '[')
-		enc := getJSONEncoder(b)
+		enc := getJSONEncoder(b, ff.durations, ff.loc)
 		val.MarshalLogArray(enc)
 		putJSONEncoder(enc)
 		b.B = append(b.B, ']')
@@ -844,10 +1011,10 @@ func appendJSONAny(b *buffer, v any) {
 		appendJSONString(b, val.Error())
 	case time.Time:
 		b.B = append(b.B, '"')
-		b.B = appendTime(b.B, val, time.RFC3339Nano)
+		b.B = appendTime(b.B, ff.in(val), time.RFC3339Nano)
 		b.B = append(b.B, '"')
 	case int32:
-		b.B = strconv.AppendInt(b.B, int64(val), 10)
+		b.B = appendInt64(b.B, int64(val))
 	case uint:
 		b.B = strconv.AppendUint(b.B, uint64(val), 10)
 	case uint64:
@@ -872,7 +1039,7 @@ func appendJSONAny(b *buffer, v any) {
 			if i > 0 {
 				b.B = append(b.B, ',')
 			}
-			b.B = strconv.AppendInt(b.B, int64(v), 10)
+			b.B = appendInt64(b.B, int64(v))
 		}
 		b.B = append(b.B, ']')
 	case []string:
@@ -890,20 +1057,19 @@ func appendJSONAny(b *buffer, v any) {
 			if i > 0 {
 				b.B = append(b.B, ',')
 			}
-			appendJSONAny(b, v)
+			appendJSONAny(b, v, ff)
 		}
 		b.B = append(b.B, ']')
 	case map[string]any:
+		// Sort keys so identical maps always encode identically.
 		b.B = append(b.B, '{')
-		first := true
-		for k, v := range val {
-			if !first {
+		for i, k := range slices.Sorted(maps.Keys(val)) {
+			if i > 0 {
 				b.B = append(b.B, ',')
 			}
-			first = false
 			appendJSONString(b, k)
 			b.B = append(b.B, ':')
-			appendJSONAny(b, v)
+			appendJSONAny(b, val[k], ff)
 		}
 		b.B = append(b.B, '}')
 	case []time.Time:
@@ -913,14 +1079,14 @@ func appendJSONAny(b *buffer, v any) {
 				b.B = append(b.B, ',')
 			}
 			b.B = append(b.B, '"')
-			b.B = appendTime(b.B, v, time.RFC3339Nano)
+			b.B = appendTime(b.B, ff.in(v), time.RFC3339Nano)
 			b.B = append(b.B, '"')
 		}
 		b.B = append(b.B, ']')
 	case int8:
-		b.B = strconv.AppendInt(b.B, int64(val), 10)
+		b.B = appendInt64(b.B, int64(val))
 	case int16:
-		b.B = strconv.AppendInt(b.B, int64(val), 10)
+		b.B = appendInt64(b.B, int64(val))
 	case uint8:
 		b.B = strconv.AppendUint(b.B, uint64(val), 10)
 	case uint16:
@@ -928,7 +1094,9 @@ func appendJSONAny(b *buffer, v any) {
 	case []byte:
 		appendJSONString(b, string(val))
 	case time.Duration:
-		b.B = strconv.AppendInt(b.B, int64(val), 10)
+		appendJSONDuration(b, val, ff.durations)
+	case rawJSON:
+		b.B = append(b.B, val...)
 	case fmt.Stringer:
 		appendJSONString(b, val.String())
 	case encoding.TextMarshaler:
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runawayObject adds fields without end, as a buggy marshaler might.
+type runawayObject struct {
+	calls *int
+}
+
+func (r runawayObject) MarshalLogObject(enc ObjectEncoder) error {
+	for i := 0; i < 100000; i++ {
+		*r.calls++
+		enc.AddString("k", strings.Repeat("v", 100))
+	}
+	return nil
+}
+
+func TestMaxEntryBytes(t *testing.T) {
+	const limit = 200
+	big := strings.Repeat("x", 1000)
+
+	for _, f := range []Formatter{TextFormatter, JSONFormatter, BinaryFormatter} {
+		t.Run(f.String(), func(t *testing.T) {
+			tests := []struct {
+				name string
+				log  func(l *Logger)
+			}{
+				{"call", func(l *Logger) { l.InfoFields("msg", String("big", big)) }},
+				{"With", func(l *Logger) { l.With("big", big).Info("msg") }},
+				{"WithFields", func(l *Logger) { l.WithFields(String("big", big)).Info("msg") }},
+				{"WithFieldsAt", func(l *Logger) { l.WithFieldsAt(InfoLevel, String("big", big)).Info("msg") }},
+				{"object", func(l *Logger) {
+					var calls int
+					l.InfoFields("msg", Object("obj", runawayObject{&calls}))
+				}},
+				{"entry", func(l *Logger) {
+					l.WithFields(String("big", big)).InfoFields("msg", Object("obj", runawayObject{new(int)}))
+				}},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					var buf bytes.Buffer
+					opts := Options{Formatter: f, MaxEntryBytes: limit, Styles: PlainStyles()}
+					if tt.name == "entry" {
+						opts.Hooks = []Hook{HookFunc(func(*Entry) error { return nil })}
+					}
+					l := NewWithOptions(&buf, opts)
+					tt.log(l)
+					l.Sync()
+					if n := buf.Len(); n == 0 || n > limit {
+						t.Fatalf("entry is %d bytes, want 1 to %d:\n%s", n, limit, buf.String())
+					}
+					if f != BinaryFormatter && !strings.Contains(buf.String(), TruncatedFieldsKey) {
+						t.Errorf("entry does not report dropped fields:\n%s", buf.String())
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestMaxEntryBytesKeepsFittingFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithOptions(&buf, Options{Formatter: JSONFormatter, MaxEntryBytes: 200})
+	l.With("small", "kept").Info("msg", "big", strings.Repeat("x", 1000))
+	l.Sync()
+	got := buf.String()
+	if !strings.Contains(got, `"small":"kept"`) || strings.Contains(got, `"big"`) {
+		t.Errorf("got %s, want small kept and big dropped", got)
+	}
+}
+
+func TestJSONEncoderStopsAtLimit(t *testing.T) {
+	b := buffer{limit: 500}
+	enc := getJSONEncoder(&b, DurationDefault, nil)
+	runawayObject{new(int)}.MarshalLogObject(enc)
+	putJSONEncoder(enc)
+	if n := len(b.B); n > 1000 {
+		t.Errorf("encoder wrote %d bytes past a limit of 500", n)
+	}
+}
+
+func TestTextQuoting(t *testing.T) {
+	tests := []struct {
+		sep  string
+		val  string
+		want string
+	}{
+		{"", "plain", "k=plain"},
+		{" ", "plain", "k=plain"},
+		{"\t", "plain", "k=plain"},
+		{" ", "two words", `k="two words"`},
+		{" | ", "a|b", `k="a|b"`},
+		{" | ", "plain", "k=plain"},
+	}
+	for _, tt := range tests {
+		st := PlainStyles()
+		st.FieldSeparator = tt.sep
+		var buf bytes.Buffer
+		l := NewWithOptions(&buf, Options{Styles: st})
+		l.Info("msg", "k", tt.val)
+		l.Sync()
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("separator %q, value %q: got %q, want %s", tt.sep, tt.val, buf.String(), tt.want)
+		}
+	}
+}
+
+// timeObject marshals a time both as an object field and as an array
+// element.
+type timeObject time.Time
+
+func (o timeObject) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddTime("at", time.Time(o))
+	return nil
+}
+
+func (o timeObject) MarshalLogArray(enc ArrayEncoder) error {
+	enc.AppendTime(time.Time(o))
+	return nil
+}
+
+func TestTimeLocationInValues(t *testing.T) {
+	zone := time.FixedZone("UTC+1", 3600)
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	fields := []Field{
+		Any("any", at), Any("anys", []time.Time{at}), Times("times", []time.Time{at}),
+		Object("object", timeObject(at)), Array("array", timeObject(at)),
+	}
+	values := `"anys":["2026-01-02T04:04:05+01:00"],"times":["2026-01-02T04:04:05+01:00"],` +
+		`"object":{"at":"2026-01-02T04:04:05+01:00"},"array":["2026-01-02T04:04:05+01:00"]`
+	want := `"any":"2026-01-02T04:04:05+01:00",` + values
+
+	var buf bytes.Buffer
+	New(&buf, WithFormatter(JSONFormatter), WithTimeLocation(zone), WithTimestamp(time.RFC3339)).InfoFields("msg", fields...)
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("JSON wrote %s, want %s", buf.String(), want)
+	}
+
+	// Under the BinaryFormatter, the times within JSON values are written in
+	// the location of the Logger, and replayed as written. Plain times are
+	// replayed as Time fields.
+	var bin bytes.Buffer
+	New(&bin, WithFormatter(BinaryFormatter), WithTimeLocation(zone)).InfoFields("msg", fields...)
+	buf.Reset()
+	if err := NewBinaryDecoder(&bin).Replay(NewCore(&buf, WithFormatter(JSONFormatter), WithTimeLocation(zone))); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), values) {
+		t.Errorf("binary replayed %s, want %s", buf.String(), values)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "sync"
+
+// Group collects the entries of one operation, such as a request or a
+// transaction, and writes them to the Logger's destination together, so
+// entries from other goroutines never interleave with them. Create one with
+// Logger.Begin.
+//
+// A Group is a Logger: log through it, or through children derived from it,
+// as usual. Nothing reaches the destination until Commit, which writes every
+// collected entry in a single write, or Rollback, which discards them. One of
+// the two must be called to release the Group, in place of Close; entries
+// logged afterwards are discarded. Sync writes the entries collected so far
+// without ending the Group.
+//
+// PanicLevel and FatalLevel entries commit the Group before the Logger panics
+// or exits, so the entries leading up to them are not lost. Loggers with a
+// Core hand entries to it as they are logged; grouping has no effect on them.
+type Group struct {
+	*Logger
+
+	parent *Logger
+	cfg    *loggerConfig
+
+	mu    sync.Mutex
+	buf   *buffer
+	ended bool
+}
+
+// groupMetrics forwards measurements from a Group's entries to the Logger's
+// MetricsHook, except for bytes written, which are counted once the Group is
+// committed.
+type groupMetrics struct {
+	MetricsHook
+}
+
+func (groupMetrics) BytesWritten(int) {}
+
+// Begin starts a Group of entries that are written contiguously on Commit or
+// discarded on Rollback.
+//
+//	gl := logger.Begin()
+//	defer gl.Rollback()
+//	gl.Info("charging card", "order", id)
+//	...
+//	gl.Commit()
+//
+// The Group shares l's level, sampler, and accumulated fields. It holds its
+// entries in memory until Commit or Rollback, so keep groups short lived.
+func (l *Logger) Begin() *Group {
+	if l == nil {
+		return &Group{ended: true}
+	}
+	g := &Group{parent: l.Clone(), cfg: l.config.Load()}
+	if g.cfg.core != nil {
+		g.Logger = l.Clone()
+		return g
+	}
+
+	cfg := *g.cfg
+	cfg.onWrite = nil
+	if cfg.metrics != nil {
+		cfg.metrics = groupMetrics{cfg.metrics}
+	}
+	gl := &Logger{
+		out:     &syncWriter{out: (*groupWriter)(g)},
+		level:   l.level,
+		sampler: l.sampler,
+		trigger: l.trigger,
+	}
+	gl.base.Store(l.base.Load())
+	gl.config.Store(&cfg)
+	g.Logger = gl
+	return g
+}
+
+// groupWriter is the destination of a Group's Logger, collecting its
+// formatted entries.
+type groupWriter Group
+
+// Write collects p, a formatted entry, until the Group ends.
+func (g *groupWriter) Write(p []byte) (int, error) {
+	g.mu.Lock()
+	if !g.ended {
+		if g.buf == nil {
+			g.buf = getBufferSize(len(p))
+		}
+		g.buf.B = append(g.buf.B, p...)
+	}
+	g.mu.Unlock()
+	return len(p), nil
+}
+
+// Sync writes the entries collected so far to the Logger's destination,
+// without ending the Group, and flushes it. The Logger calls it before
+// panicking or exiting.
+func (g *groupWriter) Sync() error {
+	g.mu.Lock()
+	b := g.buf
+	g.buf = nil
+	g.mu.Unlock()
+	if b != nil {
+		g.parent.submit(g.cfg, b)
+	}
+	return g.parent.Sync()
+}
+
+// Commit writes the collected entries to the Logger's destination in a single
+// write and ends the Group. Calling Commit after the Group has ended has no
+// effect.
+func (g *Group) Commit() {
+	g.end(true)
+}
+
+// Rollback discards the collected entries and ends the Group. Calling
+// Rollback after the Group has ended has no effect, so it is safe to defer
+// alongside an explicit Commit.
+func (g *Group) Rollback() {
+	g.end(false)
+}
+
+// end writes or discards the collected entries and releases the Group.
+func (g *Group) end(commit bool) {
+	g.mu.Lock()
+	if g.ended {
+		g.mu.Unlock()
+		return
+	}
+	b := g.buf
+	g.buf, g.ended = nil, true
+	g.mu.Unlock()
+
+	if b != nil {
+		if commit {
+			g.parent.submit(g.cfg, b)
+		} else {
+			putBuffer(b)
+		}
+	}
+	g.Logger.Close()
+	g.parent.Close()
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"testing"
+)
+
+// writeCounter records each write it receives.
+type writeCounter struct {
+	writes [][]byte
+}
+
+func (w *writeCounter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, bytes.Clone(p))
+	return len(p), nil
+}
+
+func TestGroupCommit(t *testing.T) {
+	var out writeCounter
+	l := New(&out)
+	g := l.Begin()
+	g.Info("one")
+	g.With("k", "v").Info("two")
+	if len(out.writes) != 0 {
+		t.Fatalf("Group wrote %q before Commit", out.writes)
+	}
+	g.Commit()
+	if len(out.writes) != 1 {
+		t.Fatalf("Commit made %d writes, want 1", len(out.writes))
+	}
+	if got, want := string(out.writes[0]), "INFO one\nINFO two k=v\n"; got != want {
+		t.Errorf("Commit wrote %q, want %q", got, want)
+	}
+
+	g.Info("late")
+	g.Rollback()
+	if len(out.writes) != 1 {
+		t.Errorf("entries logged after Commit were written: %q", out.writes[1:])
+	}
+}
+
+func TestGroupRollback(t *testing.T) {
+	var out writeCounter
+	l := New(&out)
+	g := l.Begin()
+	g.Info("discarded")
+	g.Rollback()
+	g.Commit()
+	l.Info("kept")
+	if len(out.writes) != 1 || string(out.writes[0]) != "INFO kept\n" {
+		t.Errorf("got writes %q, want only the entry logged outside the Group", out.writes)
+	}
+}
+
+func TestGroupSync(t *testing.T) {
+	var out writeCounter
+	g := New(&out).Begin()
+	defer g.Rollback()
+	g.Info("one")
+	g.Sync()
+	g.Info("two")
+	if len(out.writes) != 1 || string(out.writes[0]) != "INFO one\n" {
+		t.Errorf("got writes %q, want the entry logged before Sync", out.writes)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "errors"
+
+// Hook inspects, enriches, or vetoes entries before the Logger formats them.
+//
+// Hooks run in order on every entry, after all fields, the caller, and any
+// stack trace have been attached and before the Observer sees the entry. A
+// hook may add fields, rewrite the message, or change other Entry fields. If
+// Run returns a non-nil error, conventionally ErrDropEntry, the entry is
+// discarded, the remaining hooks are skipped, and Metrics receives an
+// EntryDropped with DropHook.
+//
+// Changing Level only changes how the entry is rendered; a PanicLevel or
+// FatalLevel entry still panics or exits, even when dropped. The Entry is pooled, so
+// implementations must not retain it after Run returns.
+//
+// Performance Note: Setting any hook routes all calls through the Entry path.
+type Hook interface {
+	Run(e *Entry) error
+}
+
+// HookFunc adapts an ordinary function to the Hook interface.
+type HookFunc func(e *Entry) error
+
+// Run calls f(e).
+func (f HookFunc) Run(e *Entry) error {
+	return f(e)
+}
+
+// ErrDropEntry is returned by a Hook to discard the entry it was given.
+var ErrDropEntry = errors.New("velo: entry dropped by hook")
+
+// runHooks runs hooks on e, reporting whether the entry should be written.
+func runHooks(hooks []Hook, e *Entry) bool {
+	for _, h := range hooks {
+		if h.Run(e) != nil {
+			return false
+		}
+	}
+	return true
+}
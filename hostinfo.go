@@ -0,0 +1,72 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"path"
+	"runtime/debug"
+	"sync"
+)
+
+// Field keys used by Options.IncludeHostInfo.
+const (
+	HostnameKey       = "hostname"
+	PIDKey            = "pid"
+	ServiceKey        = "service"
+	ServiceVersionKey = "version"
+)
+
+// hostInfo returns the key-value pairs attached by Options.IncludeHostInfo.
+//
+// The service name and version default to the last element of the main
+// module path and its version, as recorded in the binary's build info. Either
+// is omitted if it is empty or unknown, as in a binary built with go run.
+func hostInfo(service, version string) []any {
+	kv := []any{HostnameKey, _hostname, PIDKey, _pid}
+	bs, bv := buildService()
+	if service == "" {
+		service = bs
+	}
+	if version == "" {
+		version = bv
+	}
+	if service != "" {
+		kv = append(kv, ServiceKey, service)
+	}
+	if version != "" {
+		kv = append(kv, ServiceVersionKey, version)
+	}
+	return kv
+}
+
+// buildService returns the service name and version derived from the build
+// info of the running binary. It reads the build info only once.
+var buildService = sync.OnceValues(func() (string, string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Path == "" {
+		return "", ""
+	}
+	version := info.Main.Version
+	if version == "(devel)" {
+		version = ""
+	}
+	return path.Base(info.Main.Path), version
+})
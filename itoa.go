@@ -0,0 +1,76 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "strconv"
+
+// _intStrings holds the decimal form of the integers below its length, so the
+// TextFormatter renders common values such as counts, small sizes, and
+// status codes without allocating.
+var _intStrings = func() (s [1024]string) {
+	for i := range s {
+		s[i] = strconv.Itoa(i)
+	}
+	return s
+}()
+
+// formatInt returns the decimal form of v.
+func formatInt(v int64) string {
+	if v >= 0 && v < int64(len(_intStrings)) {
+		return _intStrings[v]
+	}
+	return strconv.FormatInt(v, 10)
+}
+
+// appendInt64 appends the decimal form of v to b.
+//
+// Performance Note: Values with up to six digits, such as status codes,
+// ports, and sizes, are written two digits at a time from _smallsString
+// instead of going through strconv.
+func appendInt64(b []byte, v int64) []byte {
+	if v <= -1e6 || v >= 1e6 {
+		return strconv.AppendInt(b, v, 10)
+	}
+	if v < 0 {
+		b = append(b, '-')
+		v = -v
+	}
+	u := uint(v)
+	switch {
+	case u < 10:
+		return append(b, byte('0'+u))
+	case u < 100:
+		i := u * 2
+		return append(b, _smallsString[i], _smallsString[i+1])
+	case u < 1000:
+		i := (u % 100) * 2
+		return append(b, byte('0'+u/100), _smallsString[i], _smallsString[i+1])
+	case u < 10000:
+		i, j := (u/100)*2, (u%100)*2
+		return append(b, _smallsString[i], _smallsString[i+1], _smallsString[j], _smallsString[j+1])
+	case u < 100000:
+		i, j := (u/100%100)*2, (u%100)*2
+		return append(b, byte('0'+u/10000), _smallsString[i], _smallsString[i+1], _smallsString[j], _smallsString[j+1])
+	default:
+		h, i, j := (u/10000)*2, (u/100%100)*2, (u%100)*2
+		return append(b, _smallsString[h], _smallsString[h+1], _smallsString[i], _smallsString[i+1], _smallsString[j], _smallsString[j+1])
+	}
+}
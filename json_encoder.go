@@ -32,8 +32,10 @@ import (
 // uses this internally to serialize complex, user defined types without relying
 // on the standard library's reflection heavy json package.
 type JSONEncoder struct {
-	buf   *buffer
-	first bool
+	buf       *buffer
+	first     bool
+	durations DurationEncoding
+	loc       *time.Location
 }
 
 var _jsonEncoderPool = sync.Pool{
@@ -42,18 +44,39 @@ var _jsonEncoderPool = sync.Pool{
 	},
 }
 
-func getJSONEncoder(b *buffer) *JSONEncoder {
+// getJSONEncoder returns an encoder appending to b, which writes durations in
+// durations and times in loc, if it is not nil.
+func getJSONEncoder(b *buffer, durations DurationEncoding, loc *time.Location) *JSONEncoder {
 	enc := _jsonEncoderPool.Get().(*JSONEncoder)
 	enc.buf = b
 	enc.first = true
+	enc.durations = durations
+	enc.loc = loc
 	return enc
 }
 
 func putJSONEncoder(enc *JSONEncoder) {
 	enc.buf = nil
+	enc.loc = nil
 	_jsonEncoderPool.Put(enc)
 }
 
+// full reports whether the buffer has grown past the limit of the entry
+// being formatted. The field being encoded is then dropped whole, so the
+// encoder appends nothing more, and a runaway marshaler cannot grow the
+// buffer far beyond Options.MaxEntryBytes.
+func (enc *JSONEncoder) full() bool {
+	return enc.buf.limit != 0 && len(enc.buf.B) > enc.buf.limit
+}
+
+// in converts t to the location of enc, if it has one.
+func (enc *JSONEncoder) in(t time.Time) time.Time {
+	if enc.loc != nil {
+		return t.In(enc.loc)
+	}
+	return t
+}
+
 func (enc *JSONEncoder) addKey(key string) {
 	appendJSONKey(enc.buf, key, !enc.first)
 	enc.first = false
@@ -68,43 +91,67 @@ func (enc *JSONEncoder) addSep() {
 
 // ObjectEncoder implementation
 func (enc *JSONEncoder) AddString(key, value string) {
+	if enc.full() {
+		return
+	}
 	enc.addKey(key)
 	appendJSONString(enc.buf, value)
 }
 
 func (enc *JSONEncoder) AddInt(key string, value int) {
+	if enc.full() {
+		return
+	}
 	enc.addKey(key)
-	enc.buf.B = strconv.AppendInt(enc.buf.B, int64(value), 10)
+	enc.buf.B = appendInt64(enc.buf.B, int64(value))
 }
 
 func (enc *JSONEncoder) AddInt64(key string, value int64) {
+	if enc.full() {
+		return
+	}
 	enc.addKey(key)
-	enc.buf.B = strconv.AppendInt(enc.buf.B, value, 10)
+	enc.buf.B = appendInt64(enc.buf.B, value)
 }
 
 func (enc *JSONEncoder) AddBool(key string, value bool) {
+	if enc.full() {
+		return
+	}
 	enc.addKey(key)
 	enc.buf.B = strconv.AppendBool(enc.buf.B, value)
 }
 
 func (enc *JSONEncoder) AddFloat64(key string, value float64) {
+	if enc.full() {
+		return
+	}
 	enc.addKey(key)
 	enc.buf.B = strconv.AppendFloat(enc.buf.B, value, 'f', -1, 64)
 }
 
 func (enc *JSONEncoder) AddTime(key string, value time.Time) {
+	if enc.full() {
+		return
+	}
 	enc.addKey(key)
 	enc.buf.WriteByte('"')
-	enc.buf.B = appendTime(enc.buf.B, value, time.RFC3339Nano)
+	enc.buf.B = appendTime(enc.buf.B, enc.in(value), time.RFC3339Nano)
 	enc.buf.WriteByte('"')
 }
 
 func (enc *JSONEncoder) AddDuration(key string, value time.Duration) {
+	if enc.full() {
+		return
+	}
 	enc.addKey(key)
-	enc.buf.B = strconv.AppendInt(enc.buf.B, value.Nanoseconds(), 10)
+	appendJSONDuration(enc.buf, value, enc.durations)
 }
 
 func (enc *JSONEncoder) AddObject(key string, marshaler ObjectMarshaler) error {
+	if enc.full() {
+		return nil
+	}
 	enc.addKey(key)
 	enc.buf.WriteByte('{')
 	if marshaler != nil {
@@ -117,6 +164,9 @@ func (enc *JSONEncoder) AddObject(key string, marshaler ObjectMarshaler) error {
 }
 
 func (enc *JSONEncoder) AddArray(key string, marshaler ArrayMarshaler) error {
+	if enc.full() {
+		return nil
+	}
 	enc.addKey(key)
 	enc.buf.WriteByte('[')
 	if marshaler != nil {
@@ -130,43 +180,67 @@ func (enc *JSONEncoder) AddArray(key string, marshaler ArrayMarshaler) error {
 
 // ArrayEncoder implementation
 func (enc *JSONEncoder) AppendString(value string) {
+	if enc.full() {
+		return
+	}
 	enc.addSep()
 	appendJSONString(enc.buf, value)
 }
 
 func (enc *JSONEncoder) AppendInt(value int) {
+	if enc.full() {
+		return
+	}
 	enc.addSep()
-	enc.buf.B = strconv.AppendInt(enc.buf.B, int64(value), 10)
+	enc.buf.B = appendInt64(enc.buf.B, int64(value))
 }
 
 func (enc *JSONEncoder) AppendInt64(value int64) {
+	if enc.full() {
+		return
+	}
 	enc.addSep()
-	enc.buf.B = strconv.AppendInt(enc.buf.B, value, 10)
+	enc.buf.B = appendInt64(enc.buf.B, value)
 }
 
 func (enc *JSONEncoder) AppendBool(value bool) {
+	if enc.full() {
+		return
+	}
 	enc.addSep()
 	enc.buf.B = strconv.AppendBool(enc.buf.B, value)
 }
 
 func (enc *JSONEncoder) AppendFloat64(value float64) {
+	if enc.full() {
+		return
+	}
 	enc.addSep()
 	enc.buf.B = strconv.AppendFloat(enc.buf.B, value, 'f', -1, 64)
 }
 
 func (enc *JSONEncoder) AppendTime(value time.Time) {
+	if enc.full() {
+		return
+	}
 	enc.addSep()
 	enc.buf.WriteByte('"')
-	enc.buf.B = appendTime(enc.buf.B, value, time.RFC3339Nano)
+	enc.buf.B = appendTime(enc.buf.B, enc.in(value), time.RFC3339Nano)
 	enc.buf.WriteByte('"')
 }
 
 func (enc *JSONEncoder) AppendDuration(value time.Duration) {
+	if enc.full() {
+		return
+	}
 	enc.addSep()
-	enc.buf.B = strconv.AppendInt(enc.buf.B, value.Nanoseconds(), 10)
+	appendJSONDuration(enc.buf, value, enc.durations)
 }
 
 func (enc *JSONEncoder) AppendObject(marshaler ObjectMarshaler) error {
+	if enc.full() {
+		return nil
+	}
 	enc.addSep()
 	enc.buf.WriteByte('{')
 	if marshaler != nil {
@@ -179,6 +253,9 @@ func (enc *JSONEncoder) AppendObject(marshaler ObjectMarshaler) error {
 }
 
 func (enc *JSONEncoder) AppendArray(marshaler ArrayMarshaler) error {
+	if enc.full() {
+		return nil
+	}
 	enc.addSep()
 	enc.buf.WriteByte('[')
 	if marshaler != nil {
@@ -45,14 +45,15 @@ const (
 	// ErrorLevel designates error events that might still allow the application
 	// to continue running.
 	ErrorLevel
-	// DPanicLevel designates critical errors. In development, the Logger panics
+	// DPanicLevel designates critical errors. With Options.Development, the Logger panics
 	// after writing the message.
 	DPanicLevel
 	// PanicLevel designates severe errors. The Logger panics after writing the
 	// message.
 	PanicLevel
 	// FatalLevel designates very severe error events. The Logger calls os.Exit(1)
-	// after writing the message.
+	// after writing the message, or panics on TinyGo and WebAssembly builds.
+	// Options.FatalBehavior and Options.FatalExitCode change this.
 	FatalLevel
 
 	noLevel Level = 100
@@ -21,16 +21,17 @@
 package velo
 
 import (
+	"bytes"
 	"context"
-	"fmt"
 	"io"
+	"math"
 	"os"
 	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
-
-	"golang.org/x/sys/cpu"
+	"unsafe"
 )
 
 var (
@@ -69,15 +70,66 @@ func SetDefault(logger *Logger) {
 
 // New constructs a new Logger writing to the provided io.Writer.
 //
-// It applies the default Options. If the provided writer is nil, it defaults
-// to standard error. This provides a quick way to instantiate a Logger without
-// configuring advanced features.
-func New(w io.Writer) *Logger {
-	return NewWithOptions(w, Options{})
+// It applies opts, in order, on top of the default Options. If the provided
+// writer is nil, it defaults to standard error. This provides a quick way to
+// instantiate a Logger without spelling out an Options struct:
+//
+//	logger := velo.New(os.Stderr, velo.WithLevel(velo.DebugLevel), velo.WithAsync(8192, velo.OverflowDrop))
+func New(w io.Writer, opts ...Option) *Logger {
+	var o Options
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return NewWithOptions(w, o)
+}
+
+// Nop returns a Logger that discards every entry.
+//
+// It rejects all levels before any formatting happens, so calls cost a single
+// atomic load. Libraries can use it, or a nil *Logger, to default an optional
+// *Logger instead of nil checking at every call site.
+func Nop() *Logger {
+	l := NewWithOptions(io.Discard, Options{})
+	l.level.val.Store(math.MaxInt64)
+	return l
+}
+
+// TestingT is the subset of testing.TB used by NewTest.
+type TestingT interface {
+	Logf(format string, args ...any)
+	Cleanup(func())
+}
+
+// NewTest constructs a Logger that writes every entry at DebugLevel or above
+// through t.Logf.
+//
+// Output appears alongside the test that produced it and is only printed for
+// failing tests or when running with -v. The Logger uses PlainStyles and is
+// closed automatically when the test finishes.
+func NewTest(t TestingT) *Logger {
+	l := NewWithOptions(testWriter{t}, Options{
+		Level:  DebugLevel,
+		Styles: PlainStyles(),
+	})
+	t.Cleanup(l.Close)
+	return l
+}
+
+// testWriter adapts a TestingT to an io.Writer, emitting one Logf call per entry.
+type testWriter struct {
+	t TestingT
+}
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Logf("%s", bytes.TrimSuffix(p, []byte{'\n'}))
+	return len(p), nil
 }
 
 type loggerAlloc struct {
 	logger Logger
+	base   baseFields
 	level  levelState
 	out    syncWriter
 	config loggerConfig
@@ -90,56 +142,155 @@ type loggerAlloc struct {
 // defaults to standard error. This is the recommended way to instantiate a
 // Logger for production applications.
 func NewWithOptions(w io.Writer, o Options) *Logger {
-	if o.BufferSize == 0 {
-		o.BufferSize = 8192
-	}
+	o.BufferSize = ringSize(o.BufferSize)
 	if w == nil {
 		w = os.Stderr
 	}
+	if o.PublishExpvar {
+		if m := publishExpvar(); m != nil {
+			o.Metrics = combineMetrics(o.Metrics, m)
+		}
+	}
 
 	alloc := &loggerAlloc{}
 	l := &alloc.logger
 
-	alloc.config = loggerConfig{
+	alloc.config = newLoggerConfig(w, &o)
+
+	l.level = &alloc.level
+	alloc.base.fields = o.Fields
+	if o.IncludeHostInfo {
+		alloc.base.fields = append(hostInfo(o.ServiceName, o.ServiceVersion), o.Fields...)
+	}
+	alloc.base.encode(&alloc.config)
+	l.base.Store(&alloc.base)
+
+	switch {
+	case o.Core != nil:
+		// The Core formats and writes entries.
+	case o.Async:
+		l.worker = newWorker(w, o.BufferSize, o.OverflowStrategy, o.Metrics, o.OnWrite, o.ErrorHandler, o.Spill, o.WriteTimeout)
+	default:
+		alloc.out.out = w
+		l.out = &alloc.out
+	}
+	if o.TriggerBuffer > 0 {
+		l.trigger = newTriggerRing(o.TriggerBuffer)
+	}
+
+	l.level.val.Store(int64(o.Level))
+	l.config.Store(&alloc.config)
+
+	return l
+}
+
+// NewWithOptionsE is like NewWithOptions, but validates o first.
+//
+// It returns an error describing the first out of range value, such as an
+// unknown Level or Formatter, a negative CallerOffset, or an odd number of
+// Fields, instead of silently accepting it. BufferSize is rounded up to the
+// next power of two.
+func NewWithOptionsE(w io.Writer, o Options) (*Logger, error) {
+	if err := o.normalize(); err != nil {
+		return nil, err
+	}
+	return NewWithOptions(w, o), nil
+}
+
+// newLoggerConfig derives the configuration of a Logger writing to w from o.
+func newLoggerConfig(w io.Writer, o *Options) loggerConfig {
+	cfg := loggerConfig{
 		prefix:           o.Prefix,
+		name:             o.Name,
+		maxMessageBytes:  o.MaxMessageBytes,
+		maxEntryBytes:    o.MaxEntryBytes,
 		timeFunc:         o.TimeFunction,
+		clock:            o.Clock,
 		timeFormat:       o.TimeFormat,
+		timeLocation:     o.TimeLocation,
+		durations:        o.DurationEncoding,
 		callerOffset:     o.CallerOffset,
 		callerFormatter:  o.CallerFormatter,
+		callerObject:     o.CallerObject,
 		formatter:        o.Formatter,
+		encoder:          o.Encoder,
 		contextExtractor: o.ContextExtractor,
+		providers:        slices.Clip(o.FieldProviders),
+		observer:         o.Observer,
+		hooks:            slices.Clone(o.Hooks),
+		processors:       slices.Clone(o.Processors),
+		metrics:          o.Metrics,
+		fieldMetrics:     o.FieldMetrics,
+		styles:           o.Styles,
+		baseStyles:       o.Styles,
+		levelLabels:      o.LevelLabels,
+		color:            o.Color,
+		sortFields:       o.SortFields,
+		duplicateKeys:    o.DuplicateKeys,
 		reportTimestamp:  o.ReportTimestamp,
 		reportCaller:     o.ReportCaller,
 		reportStacktrace: o.ReportStacktrace,
+		stackLevel:       newStackLevel(o.StacktraceLevel),
+		stackDepth:       o.StacktraceDepth,
+		stackFilter:      o.StackFrameFilter,
+		dumpGoroutines:   o.DumpGoroutines,
+		dumpLimit:        o.GoroutineDumpLimit,
+		development:      o.Development,
+		onFatal:          o.OnFatal,
+		exitFunc:         o.ExitFunc,
+		fatalBehavior:    o.FatalBehavior,
+		fatalExitCode:    o.FatalExitCode,
+		onWrite:          o.OnWrite,
+		triggerLevel:     ErrorLevel,
+		schema:           o.Schema,
+		errorHandler:     o.ErrorHandler,
 	}
 
-	if alloc.config.callerFormatter == nil {
-		alloc.config.callerFormatter = ShortCallerFormatter
+	if cfg.callerFormatter == nil {
+		cfg.callerFormatter = ShortCallerFormatter
 	}
-	if alloc.config.timeFormat == "" {
-		alloc.config.timeFormat = DefaultTimeFormat
+	cfg.callers = new(callerCache)
+	cfg.times = new(timeCache)
+	cfg.sizes = new(sizeEstimator)
+	if len(cfg.processors) > 0 {
+		cfg.process = composeProcessors(cfg.processors, writeProcessed)
 	}
-
-	l.level = &alloc.level
-	l.fields = o.Fields
-
-	if o.Async {
-		l.worker = newWorker(w, o.BufferSize, o.OverflowStrategy)
+	if o.Core != nil {
+		cfg.core = &coreHandle{Core: o.Core}
+		cfg.core.refs.Store(1)
+	}
+	if cfg.timeFormat == "" {
+		cfg.timeFormat = DefaultTimeFormat
+	}
+	if o.TriggerLevel != nil {
+		cfg.triggerLevel = *o.TriggerLevel
+	}
+	if cfg.styles != nil {
+		prepareStyles(cfg.styles)
 	} else {
-		alloc.out.out = w
-		l.out = &alloc.out
+		cfg.styles = stylesFor(w, o.Color)
 	}
-
-	l.level.val.Store(int64(o.Level))
-	l.config.Store(&alloc.config)
-
-	return l
+	if len(o.LevelLabels) > 0 {
+		base := cfg.styles
+		if base == nil {
+			base = _defaultStyles
+		}
+		cfg.styles = withLevelLabels(base, o.LevelLabels)
+	}
+	if o.TextLayout.enabled() {
+		layout := o.TextLayout
+		cfg.layout = &layout
+	}
+	if o.ReportSequence {
+		cfg.sequence = new(atomic.Uint64)
+	}
+	return cfg
 }
 
 type levelState struct {
-	_   cpu.CacheLinePad
+	_   cacheLinePad
 	val atomic.Int64
-	_   cpu.CacheLinePad
+	_   cacheLinePad
 }
 
 type syncWriter struct {
@@ -163,15 +314,118 @@ func (s *syncWriter) Sync() error {
 
 type loggerConfig struct {
 	prefix           string
+	name             string
+	maxMessageBytes  int
+	maxEntryBytes    int
 	timeFunc         TimeFunction
+	clock            Clock
+	sequence         *atomic.Uint64
 	timeFormat       string
+	timeLocation     *time.Location
+	durations        DurationEncoding
 	callerOffset     int
 	callerFormatter  CallerFormatter
+	callers          *callerCache
+	times            *timeCache
+	sizes            *sizeEstimator
+	callerObject     bool
 	formatter        Formatter
+	encoder          EntryEncoder
 	contextExtractor ContextExtractor
+	providers        []FieldProvider
+	observer         EntryObserver
+	hooks            []Hook
+	processors       []Processor
+	process          func(*Entry)
+	core             *coreHandle
+	metrics          MetricsHook
+	fieldMetrics     FieldMetrics
+	styles           *Styles
+	baseStyles       *Styles
+	levelLabels      map[Level]string
+	color            ColorMode
+	layout           *TextLayout
+	sortFields       bool
+	duplicateKeys    DuplicateKeys
 	reportTimestamp  bool
 	reportCaller     bool
 	reportStacktrace bool
+	stackLevel       stackLevel
+	stackDepth       int
+	stackFilter      FrameFilter
+	dumpGoroutines   bool
+	dumpLimit        int
+	development      bool
+	onFatal          func(*Entry)
+	exitFunc         func(code int)
+	fatalBehavior    FatalBehavior
+	fatalExitCode    int
+	onWrite          func(WriteStats)
+	triggerLevel     Level
+	schema           *Schema
+	errorHandler     func(error)
+
+	// recovered is set on the configuration RecoverAndLog writes with, so
+	// that the PanicLevel entry does not panic a second time.
+	recovered bool
+
+	// held is set on the configuration that diverts writes into a trigger
+	// buffer. See triggerRing.config.
+	held *triggerRing
+}
+
+// textStyles returns the Styles the TextFormatter uses for c.
+func (c *loggerConfig) textStyles() *Styles {
+	if c.styles == nil {
+		return _defaultStyles
+	}
+	return c.styles
+}
+
+// now returns the timestamp for a new entry, or the zero time if timestamps are disabled.
+func (c *loggerConfig) now() time.Time {
+	if !c.reportTimestamp {
+		return time.Time{}
+	}
+	if c.clock != nil {
+		return c.at(c.clock.Now())
+	}
+	return c.at(time.Now())
+}
+
+// at returns t as the timestamp for a new entry, or the zero time if
+// timestamps are disabled.
+func (c *loggerConfig) at(t time.Time) time.Time {
+	if !c.reportTimestamp {
+		return time.Time{}
+	}
+	if c.timeLocation != nil {
+		t = t.In(c.timeLocation)
+	}
+	if c.timeFunc != nil {
+		t = c.timeFunc(t)
+	}
+	return t
+}
+
+// fieldFormat returns how c writes field values.
+func (c *loggerConfig) fieldFormat() fieldFormat {
+	return fieldFormat{layout: c.timeFormat, loc: c.timeLocation, durations: c.durations}
+}
+
+// needsEntry reports whether a log call must assemble a pooled Entry instead
+// of formatting directly onto the buffer.
+func (c *loggerConfig) needsEntry(level Level) bool {
+	return c.reportStacktrace || c.encoder != nil || c.observer != nil || len(c.hooks) > 0 || c.process != nil || c.core != nil || c.sortFields || c.schema != nil ||
+		(c.duplicateKeys != DuplicateKeysAllow && c.formatter == JSONFormatter) ||
+		(c.dumpGoroutines && level >= PanicLevel && level != noLevel) ||
+		(c.onFatal != nil && level == FatalLevel)
+}
+
+// developmentCaller reports whether Development mode adds the caller to
+// entries at level.
+func (c *loggerConfig) developmentCaller(level Level) bool {
+	return c.development && level >= WarnLevel && level != noLevel
 }
 
 // Logger provides fast, leveled, and structured logging.
@@ -182,23 +436,27 @@ type loggerConfig struct {
 // exists, instantiating local instances via NewWithOptions and injecting them
 // as dependencies avoids global state and improves testability. All methods
 // are safe for concurrent use.
+//
+// A nil *Logger is valid and discards everything: its logging methods,
+// including those at PanicLevel and FatalLevel, do nothing, its With methods
+// return nil, and it reports every level as disabled. Libraries can accept an
+// optional *Logger and treat nil as logging disabled.
 type Logger struct {
 	level *levelState
-	_     cpu.CacheLinePad
+	_     cacheLinePad
 
 	closed atomic.Uint32
-	_      cpu.CacheLinePad
+	_      cacheLinePad
 
 	config atomic.Pointer[loggerConfig]
 
-	fields         []any
-	typedFields    []Field
-	preEncodedJSON []byte
+	base atomic.Pointer[baseFields]
 
 	worker *worker
 	out    *syncWriter
 
 	sampler *sampler
+	trigger *triggerRing
 }
 
 // Close stops the background worker and flushes all remaining log entries.
@@ -208,12 +466,29 @@ type Logger struct {
 // worker when the count reaches zero. Calling Close on a synchronous Logger
 // has no effect.
 func (l *Logger) Close() {
+	if l == nil {
+		return
+	}
 	if l.closed.CompareAndSwap(0, 1) {
 		if l.worker != nil {
 			if l.worker.refCount.Add(-1) == 0 {
 				l.worker.stop()
 			}
 		}
+		if c := l.config.Load().core; c != nil && c.refs.Add(-1) == 0 {
+			closeCore(c.Core)
+		}
+	}
+}
+
+// retain registers a new child of l, which shares its worker and Core and
+// must be closed separately.
+func (l *Logger) retain() {
+	if l.worker != nil {
+		l.worker.refCount.Add(1)
+	}
+	if c := l.config.Load().core; c != nil {
+		c.refs.Add(1)
 	}
 }
 
@@ -223,6 +498,12 @@ func (l *Logger) Close() {
 // Sync on the underlying io.Writer if it implements the interface. Use this
 // to ensure critical logs are written immediately.
 func (l *Logger) Sync() error {
+	if l == nil {
+		return nil
+	}
+	if c := l.config.Load().core; c != nil {
+		return c.Sync()
+	}
 	if l.worker != nil {
 		return l.worker.sync()
 	}
@@ -232,15 +513,43 @@ func (l *Logger) Sync() error {
 	return nil
 }
 
-func (l *Logger) submit(b *buffer) {
+// submit hands a formatted buffer to the worker or the synchronous writer.
+//
+// It reports false if the worker's overflow strategy discarded the buffer.
+func (l *Logger) submit(cfg *loggerConfig, b *buffer) bool {
 	if l.worker != nil {
-		l.worker.submit(b)
-	} else if l.out != nil {
-		l.out.Write(b.B)
-		putBuffer(b)
-	} else {
-		putBuffer(b)
+		return l.worker.submit(b)
 	}
+	if l.out != nil {
+		var start time.Time
+		if cfg.onWrite != nil {
+			start = time.Now()
+		}
+		n, err := l.out.Write(b.B)
+		if cfg.onWrite != nil {
+			cfg.onWrite(WriteStats{Entries: 1, Bytes: n, Duration: time.Since(start), Err: err})
+		}
+		if cfg.metrics != nil {
+			if err != nil {
+				cfg.metrics.WriteError(err)
+			} else {
+				cfg.metrics.BytesWritten(n)
+			}
+		}
+	}
+	putBuffer(b)
+	return true
+}
+
+// sample reports whether the sampler, if any, lets the entry through.
+func (l *Logger) sample(cfg *loggerConfig, level Level, msg string, t time.Time) bool {
+	if l.sampler == nil || l.sampler.check(level, msg, t) {
+		return true
+	}
+	if cfg.metrics != nil {
+		cfg.metrics.EntryDropped(level, DropSampled)
+	}
+	return false
 }
 
 // LogContext writes a message with loosely typed key-value pairs at the specified level.
@@ -252,58 +561,41 @@ func (l *Logger) submit(b *buffer) {
 // For absolute maximum performance and zero allocations, use the strongly typed
 // LogContextFields method instead.
 func (l *Logger) LogContext(ctx context.Context, level Level, msg string, keyvals ...any) {
+	l.logContext(0, ctx, level, msg, keyvals)
+}
+
+func (l *Logger) logContext(skip int, ctx context.Context, level Level, msg string, keyvals []any) {
+	if l == nil {
+		return
+	}
 	if l.level.val.Load() > int64(level) {
+		if l.trigger != nil {
+			l.hold(skip, ctx, level, msg, keyvals, nil, false)
+		}
 		return
 	}
-	l.logContext(ctx, level, msg, keyvals)
-}
 
-func (l *Logger) logContext(ctx context.Context, level Level, msg string, keyvals []any) {
 	cfg := l.config.Load()
 
-	var t time.Time
-	if cfg.reportTimestamp {
-		if cfg.timeFunc != nil {
-			t = cfg.timeFunc(time.Now())
-		} else {
-			t = time.Now()
-		}
-	}
+	t := cfg.now()
 
-	if l.sampler != nil && !l.sampler.check(level, msg, t) {
+	if !l.sample(cfg, level, msg, t) {
 		return
 	}
 
-	var ctxFields []Field
-	if cfg.contextExtractor != nil && ctx != nil {
-		ctxFields = cfg.contextExtractor(ctx)
+	var scratch [1]Field
+	ctxFields := l.contextFields(cfg, ctx, &scratch)
+	if l.trigger != nil {
+		l.trip(cfg, triggerRingFrom(ctx, nil), level)
 	}
 
-	if cfg.reportStacktrace || cfg.reportCaller {
-		l.logWithEntry(level, msg, keyvals, nil, ctxFields, cfg, t)
+	if cfg.needsEntry(level) {
+		l.logWithEntry(skip, level, msg, keyvals, nil, ctxFields, cfg, t, false)
 		return
 	}
 
 	// Fast path: direct formatting
-	b := getBuffer()
-
-	if cfg.formatter == JSONFormatter {
-		formatLogJSON(b, l, cfg, level, msg, keyvals, nil, ctxFields, t)
-	} else {
-		formatLogText(b, l, cfg, level, msg, keyvals, nil, ctxFields, t)
-	}
-
-	l.submit(b)
-
-	if level == PanicLevel {
-		l.Sync()
-		panic(msg)
-	}
-
-	if level == FatalLevel {
-		flushAllWorkers()
-		os.Exit(1)
-	}
+	l.output(skip, cfg, level, msg, keyvals, nil, ctxFields, t)
 }
 
 // LogContextFields writes a message with strongly typed fields at the specified level.
@@ -312,58 +604,44 @@ func (l *Logger) logContext(ctx context.Context, level Level, msg string, keyval
 // method guarantees zero allocations on the hot path, making it ideal for
 // extreme high throughput, latency critical applications.
 func (l *Logger) LogContextFields(ctx context.Context, level Level, msg string, fields ...Field) {
+	l.logContextFields(0, ctx, level, msg, fields)
+}
+
+func (l *Logger) logContextFields(skip int, ctx context.Context, level Level, msg string, fields []Field) {
+	if l == nil {
+		return
+	}
 	if l.level.val.Load() > int64(level) {
+		if l.trigger != nil {
+			l.hold(skip, ctx, level, msg, nil, fields, false)
+		}
 		return
 	}
-	l.logContextFields(ctx, level, msg, fields)
-}
 
-func (l *Logger) logContextFields(ctx context.Context, level Level, msg string, fields []Field) {
 	cfg := l.config.Load()
-
-	var t time.Time
-	if cfg.reportTimestamp {
-		if cfg.timeFunc != nil {
-			t = cfg.timeFunc(time.Now())
-		} else {
-			t = time.Now()
-		}
+	if cfg.fieldMetrics != nil {
+		recordMetrics(cfg.fieldMetrics, fields)
 	}
 
-	if l.sampler != nil && !l.sampler.check(level, msg, t) {
+	t, fields := cfg.stamp(fields)
+
+	if !l.sample(cfg, level, msg, t) {
 		return
 	}
 
-	var ctxFields []Field
-	if cfg.contextExtractor != nil && ctx != nil {
-		ctxFields = cfg.contextExtractor(ctx)
+	var scratch [1]Field
+	ctxFields := l.contextFields(cfg, ctx, &scratch)
+	if l.trigger != nil {
+		l.trip(cfg, triggerRingFrom(ctx, nil), level)
 	}
 
-	if cfg.reportStacktrace || cfg.reportCaller {
-		l.logWithEntry(level, msg, nil, fields, ctxFields, cfg, t)
+	if cfg.needsEntry(level) {
+		l.logWithEntry(skip, level, msg, nil, fields, ctxFields, cfg, t, false)
 		return
 	}
 
 	// Fast path: direct formatting
-	b := getBuffer()
-
-	if cfg.formatter == JSONFormatter {
-		formatLogJSON(b, l, cfg, level, msg, nil, fields, ctxFields, t)
-	} else {
-		formatLogText(b, l, cfg, level, msg, nil, fields, ctxFields, t)
-	}
-
-	l.submit(b)
-
-	if level == PanicLevel {
-		l.Sync()
-		panic(msg)
-	}
-
-	if level == FatalLevel {
-		flushAllWorkers()
-		os.Exit(1)
-	}
+	l.output(skip, cfg, level, msg, nil, fields, ctxFields, t)
 }
 
 // With creates a child Logger that includes the provided loosely typed key-value pairs.
@@ -373,43 +651,14 @@ func (l *Logger) logContextFields(ctx context.Context, level Level, msg string,
 // serialization on every log call. Use this to attach contextual data to a
 // Logger for a specific scope or request.
 func (l *Logger) With(keyvals ...any) *Logger {
+	if l == nil {
+		return nil
+	}
 	if len(keyvals) == 0 {
 		return l
 	}
-	newFields := make([]any, len(l.fields), len(l.fields)+len(keyvals))
-	copy(newFields, l.fields)
-	newFields = append(newFields, keyvals...)
-
-	nl := &Logger{
-		fields:      newFields,
-		typedFields: l.typedFields,
-		worker:      l.worker,
-		out:         l.out,
-		level:       l.level,
-		sampler:     l.sampler,
-	}
-	nl.config.Store(l.config.Load())
-
-	// Pre-encode JSON fields if using JSONFormatter
-	cfg := l.config.Load()
-	if cfg.formatter == JSONFormatter {
-		b := getBuffer()
-		if len(l.preEncodedJSON) > 0 {
-			b.Write(l.preEncodedJSON)
-		}
-		for i := 0; i < len(keyvals); i += 2 {
-			if i+1 < len(keyvals) {
-				encodeKeyValToJSON(b, keyvals[i], keyvals[i+1], true)
-			}
-		}
-		nl.preEncodedJSON = make([]byte, len(b.B))
-		copy(nl.preEncodedJSON, b.B)
-		putBuffer(b)
-	}
-
-	if l.worker != nil {
-		l.worker.refCount.Add(1)
-	}
+	nl := l.Clone()
+	nl.base.Store(l.base.Load().with(keyvals, nil, l.config.Load()))
 	return nl
 }
 
@@ -420,60 +669,638 @@ func (l *Logger) With(keyvals ...any) *Logger {
 // serialization on every log call. This provides the highest performance when
 // attaching contextual data to a Logger.
 func (l *Logger) WithFields(fields ...Field) *Logger {
+	if l == nil {
+		return nil
+	}
 	if len(fields) == 0 {
 		return l
 	}
-	newFields := make([]Field, len(l.typedFields), len(l.typedFields)+len(fields))
-	copy(newFields, l.typedFields)
-	newFields = append(newFields, fields...)
+	nl := l.Clone()
+	nl.base.Store(l.base.Load().with(nil, fields, l.config.Load()))
+	return nl
+}
 
-	nl := &Logger{
-		fields:      l.fields,
-		typedFields: newFields,
-		worker:      l.worker,
-		out:         l.out,
-		level:       l.level,
-		sampler:     l.sampler,
+// baseFields holds the fields a Logger attaches to every entry. It is
+// replaced as a whole, never modified, so log calls can read it without locks.
+type baseFields struct {
+	fields      []any
+	typedFields []Field
+
+	// json chains the fields encoded for the JSONFormatter, each with a
+	// leading comma. It is nil if there are no encoded fields, and jsonFormat
+	// records how their values were encoded.
+	json       *segment
+	jsonFormat fieldFormat
+
+	// text chains the fields rendered for the TextFormatter, each preceded by
+	// the field separator. It is only used by entries formatted with
+	// textStyles and textFormat and without a TextLayout.
+	text       *segment
+	textStyles *Styles
+	textFormat fieldFormat
+
+	// conditional holds the groups of fields added by WithFieldsAt. They
+	// follow the other fields and are never part of json.
+	conditional []conditionalFields
+}
+
+// conditionalFields is a group of fields attached only to entries at or
+// above a level. preEncodedJSON holds them encoded for the JSONFormatter,
+// each with a leading comma, and jsonFormat records how their values were
+// encoded.
+type conditionalFields struct {
+	level          Level
+	fields         []Field
+	preEncodedJSON []byte
+	jsonFormat     fieldFormat
+}
+
+// encodeJSON fills preEncodedJSON from the fields.
+func (c *conditionalFields) encodeJSON(ff fieldFormat) {
+	b := getBuffer()
+	for i := range c.fields {
+		encodeFieldToJSON(b, &c.fields[i], ff, true)
 	}
-	nl.config.Store(l.config.Load())
+	c.preEncodedJSON = bytes.Clone(b.B)
+	c.jsonFormat = ff
+	putBuffer(b)
+}
 
-	// Pre-encode JSON fields if using JSONFormatter
-	cfg := l.config.Load()
-	if cfg.formatter == JSONFormatter {
-		b := getBuffer()
-		if len(l.preEncodedJSON) > 0 {
-			b.Write(l.preEncodedJSON)
+// applies reports whether the group is attached to entries at level. Print
+// entries, which have no level, never carry conditional fields.
+func (c *conditionalFields) applies(level Level) bool {
+	return level >= c.level && level != noLevel
+}
+
+// conditionalAt returns the conditional fields attached to entries at level.
+// It only allocates when several groups apply.
+func (bf *baseFields) conditionalAt(level Level) []Field {
+	var fields []Field
+	for i := range bf.conditional {
+		if c := &bf.conditional[i]; c.applies(level) {
+			if fields == nil {
+				fields = c.fields
+			} else {
+				fields = append(slices.Clip(fields), c.fields...)
+			}
+		}
+	}
+	return fields
+}
+
+// appendConditional appends the conditional fields attached to entries at
+// level to dst.
+func (bf *baseFields) appendConditional(dst []Field, level Level) []Field {
+	for i := range bf.conditional {
+		if c := &bf.conditional[i]; c.applies(level) {
+			dst = append(dst, c.fields...)
+		}
+	}
+	return dst
+}
+
+// with returns a copy of bf extended by keyvals and fields, encoding the
+// result for the formatter cfg uses.
+//
+// Performance Note: When bf is already encoded for cfg, only keyvals and
+// fields are encoded, into a new segment chained to those of bf.
+func (bf *baseFields) with(keyvals []any, fields []Field, cfg *loggerConfig) *baseFields {
+	nb := &baseFields{
+		fields:      bf.fields,
+		typedFields: bf.typedFields,
+		conditional: bf.conditional,
+	}
+	if len(keyvals) > 0 {
+		nb.fields = append(slices.Clip(bf.fields), keyvals...)
+	}
+	if len(fields) > 0 {
+		nb.typedFields = append(slices.Clip(bf.typedFields), fields...)
+	}
+	added := &baseFields{fields: keyvals, typedFields: fields}
+
+	switch {
+	case cfg.formatter == JSONFormatter:
+		if bf.json == nil || bf.jsonFormat != cfg.fieldFormat() {
+			nb.encodeJSON(cfg.fieldFormat())
+			break
 		}
-		for i := 0; i < len(fields); i++ {
-			encodeFieldToJSON(b, &fields[i], cfg.timeFormat, true)
+		b := getBuffer()
+		added.appendJSON(b, cfg.fieldFormat())
+		nb.json = bf.json.extend(bytes.Clone(b.B), 0)
+		nb.jsonFormat = cfg.fieldFormat()
+		putBuffer(b)
+	case cfg.formatter == BinaryFormatter:
+		// Binary records encode their fields as they are written.
+	case cfg.layout == nil:
+		// The TextFormatter writes loosely typed fields before typed ones, so
+		// keyvals can only be chained after a Logger without typed fields.
+		st := cfg.textStyles()
+		if bf.text == nil || bf.textStyles != st || bf.textFormat != cfg.fieldFormat() ||
+			(len(keyvals) > 0 && len(bf.typedFields) > 0) {
+			nb.renderText(st, cfg.fieldFormat())
+			break
 		}
-		nl.preEncodedJSON = make([]byte, len(b.B))
-		copy(nl.preEncodedJSON, b.B)
+		b := getBuffer()
+		ln := textLine{fields: 1}
+		added.appendText(b, st, &ln, cfg.fieldFormat())
+		nb.text = bf.text.extend(bytes.Clone(b.B), ln.fields-1)
+		nb.textStyles, nb.textFormat = st, cfg.fieldFormat()
 		putBuffer(b)
 	}
+	return nb
+}
 
-	if l.worker != nil {
-		l.worker.refCount.Add(1)
+// encode caches the fields in the form the formatter of cfg writes them.
+func (bf *baseFields) encode(cfg *loggerConfig) {
+	switch {
+	case cfg.formatter == JSONFormatter:
+		bf.encodeJSON(cfg.fieldFormat())
+	case cfg.formatter == BinaryFormatter:
+	case cfg.layout == nil:
+		bf.renderText(cfg.textStyles(), cfg.fieldFormat())
+	}
+}
+
+// renderText fills text from the fields.
+func (bf *baseFields) renderText(st *Styles, ff fieldFormat) {
+	b := getBuffer()
+	ln := textLine{fields: 1}
+	bf.appendText(b, st, &ln, ff)
+	bf.text = (*segment)(nil).extend(bytes.Clone(b.B), ln.fields-1)
+	bf.textStyles, bf.textFormat = st, ff
+	putBuffer(b)
+}
+
+// appendText renders the fields onto b, continuing the fields on ln. It uses
+// text when it matches st, ff, and the layout of ln, and fits within the
+// limit on b.
+//
+// Performance Note: A Logger carrying many fields from With and WithFields
+// then costs a few copies per entry instead of formatting every field again.
+func (bf *baseFields) appendText(b *buffer, st *Styles, ln *textLine, ff fieldFormat) {
+	if bf.text != nil && bf.textStyles == st && bf.textFormat == ff && ln.layout == nil && b.fits(bf.text.size+len(st.FieldsOpen)) {
+		skip := 0
+		if ln.fields == 0 {
+			// Open the field list in place of the first separator.
+			b.WriteByte(' ')
+			b.WriteString(st.FieldsOpen)
+			skip = max(len(st.FieldSeparator), 1)
+		}
+		b.B = bf.text.appendTo(b.B, skip)
+		ln.fields += bf.text.fields
+		return
+	}
+	for i := 0; i+1 < len(bf.fields); i += 2 {
+		appendTextField(b, st, ln, formatAny(bf.fields[i]), textValue(bf.fields[i+1], ff), isError(bf.fields[i+1]))
+	}
+	for i := range bf.typedFields {
+		if bf.typedFields[i].Key != "" {
+			appendTextTypedField(b, st, ln, &bf.typedFields[i], ff)
+		}
+	}
+}
+
+// encodeJSON fills json from the fields, and encodes again the conditional
+// groups encoded with another format.
+func (bf *baseFields) encodeJSON(ff fieldFormat) {
+	b := getBuffer()
+	bf.appendJSON(b, ff)
+	bf.json = (*segment)(nil).extend(bytes.Clone(b.B), 0)
+	bf.jsonFormat = ff
+	putBuffer(b)
+
+	stale := func(c conditionalFields) bool { return c.preEncodedJSON == nil || c.jsonFormat != ff }
+	if slices.ContainsFunc(bf.conditional, stale) {
+		// The groups are shared with the Logger bf was copied from.
+		bf.conditional = slices.Clone(bf.conditional)
+		for i := range bf.conditional {
+			if stale(bf.conditional[i]) {
+				bf.conditional[i].encodeJSON(ff)
+			}
+		}
 	}
+}
+
+// appendJSON encodes the fields onto b, each with a leading comma.
+func (bf *baseFields) appendJSON(b *buffer, ff fieldFormat) {
+	for i := 0; i+1 < len(bf.fields); i += 2 {
+		encodeKeyValToJSON(b, bf.fields[i], bf.fields[i+1], ff, true)
+	}
+	for i := range bf.typedFields {
+		encodeFieldToJSON(b, &bf.typedFields[i], ff, true)
+	}
+}
+
+// SetFields replaces all of the Logger's own fields, including those from
+// Options.Fields, With, WithFields, and WithFieldsAt, with fields.
+//
+// Use it, together with ReplaceField and RemoveField, to update fields such as
+// a version or a leader flag after startup. The change is atomic: every entry
+// carries either the old or the new set. Children created before the call
+// keep the fields they were created with.
+func (l *Logger) SetFields(fields ...Field) {
+	if l == nil {
+		return
+	}
+	cfg := l.config.Load()
+	nb := &baseFields{typedFields: slices.Clone(fields)}
+	nb.encode(cfg)
+	l.base.Store(nb)
+}
+
+// ReplaceField sets the Logger's field with f's key to f, keeping its position
+// among the typed fields, or appends f if the Logger has no such field.
+//
+// Loosely typed fields with the key are removed and f is appended in their
+// place. Like SetFields, the change is atomic and does not affect existing
+// children.
+func (l *Logger) ReplaceField(f Field) {
+	l.updateFields(func(bf *baseFields) {
+		bf.fields = removeKeyVals(bf.fields, f.Key)
+		i := slices.IndexFunc(bf.typedFields, func(tf Field) bool { return tf.Key == f.Key })
+		if i < 0 {
+			bf.typedFields = append(bf.typedFields, f)
+			return
+		}
+		bf.typedFields[i] = f
+		rest := slices.DeleteFunc(bf.typedFields[i+1:], func(tf Field) bool { return tf.Key == f.Key })
+		bf.typedFields = bf.typedFields[:i+1+len(rest)]
+	})
+}
+
+// RemoveField removes every field with key from the Logger. Like SetFields,
+// the change is atomic and does not affect existing children.
+func (l *Logger) RemoveField(key string) {
+	l.updateFields(func(bf *baseFields) {
+		bf.fields = removeKeyVals(bf.fields, key)
+		bf.typedFields = slices.DeleteFunc(bf.typedFields, func(tf Field) bool { return tf.Key == key })
+	})
+}
+
+// updateFields applies update to a private copy of the Logger's fields and
+// swaps it in, retrying if the fields were replaced concurrently.
+func (l *Logger) updateFields(update func(*baseFields)) {
+	if l == nil {
+		return
+	}
+	for {
+		old := l.base.Load()
+		cfg := l.config.Load()
+		nb := &baseFields{
+			fields:      slices.Clone(old.fields),
+			typedFields: slices.Clone(old.typedFields),
+			conditional: old.conditional,
+		}
+		update(nb)
+		nb.encode(cfg)
+		if l.base.CompareAndSwap(old, nb) {
+			return
+		}
+	}
+}
+
+// removeKeyVals removes the key-value pairs with key from keyvals in place.
+func removeKeyVals(keyvals []any, key string) []any {
+	out := keyvals[:0]
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && k == key {
+			continue
+		}
+		out = append(out, keyvals[i], keyvals[i+1])
+	}
+	return out
+}
+
+// WithFieldsAt creates a child Logger that attaches fields only to entries at
+// or above level.
+//
+// Use it to share one Logger while keeping verbose context, such as the full
+// connection state, out of routine entries:
+//
+//	conn := logger.WithFieldsAt(velo.ErrorLevel, velo.Any("state", state))
+//	conn.Info("query done")    // no state
+//	conn.Error("query failed") // includes state
+//
+// Conditional fields follow the Logger's other fields, including those added
+// later with With. Entries written with Print never include them.
+func (l *Logger) WithFieldsAt(level Level, fields ...Field) *Logger {
+	if l == nil {
+		return nil
+	}
+	if len(fields) == 0 {
+		return l
+	}
+	cfg := l.config.Load()
+	base := l.base.Load()
+	c := conditionalFields{level: level, fields: slices.Clone(fields)}
+	if cfg.formatter == JSONFormatter {
+		c.encodeJSON(cfg.fieldFormat())
+	}
+	nb := *base
+	nb.conditional = append(slices.Clip(base.conditional), c)
+
+	nl := l.Clone()
+	nl.base.Store(&nb)
+	return nl
+}
+
+// Named creates a child Logger whose name is the parent's name, if any,
+// followed by a dot and name, so that a Logger named "db" has a child named
+// "db.pool". The name is written under NameKey and inherited by the child's
+// own children.
+//
+// Use names for routing and tuning, for example in a FilterFunc, which sees
+// the name among the fields, or as the name a Logger is registered under
+// with a Reloader. Keep Prefix for labels meant to be read. Unlike With, the
+// child has its own level, starting at the parent's current level, so that a
+// Reloader can tune "db" and "db.pool" independently. An empty name returns l
+// itself.
+func (l *Logger) Named(name string) *Logger {
+	if l == nil {
+		return nil
+	}
+	if name == "" {
+		return l
+	}
+	nl := l.Clone()
+	nl.level = new(levelState)
+	nl.level.val.Store(l.level.val.Load())
+	cfg := *l.config.Load()
+	if cfg.name != "" {
+		name = cfg.name + "." + name
+	}
+	cfg.name = name
+	nl.config.Store(&cfg)
 	return nl
 }
 
+// Name returns the dot-separated name of the Logger, or the empty string if
+// it has none.
+func (l *Logger) Name() string {
+	if l == nil {
+		return ""
+	}
+	return l.config.Load().name
+}
+
 // WithPrefix creates a child Logger that prepends the specified prefix to all messages.
 //
 // It copies the parent's configuration and updates the prefix. Use this to
 // visually group logs from a specific component or subsystem.
 func (l *Logger) WithPrefix(prefix string) *Logger {
-	nl := l.With()
+	if l == nil {
+		return nil
+	}
+	nl := l.Clone()
 	nl.SetPrefix(prefix)
 	return nl
 }
 
+// Clone creates a child Logger sharing the parent's fields, writer, and
+// configuration.
+//
+// The child starts with an identical configuration, but its setters, such as
+// SetPrefix or SetFormatter, do not affect the parent. The level is shared, as
+// with With. Close the child when done, like any other child Logger.
+func (l *Logger) Clone() *Logger {
+	if l == nil {
+		return nil
+	}
+	nl := &Logger{
+		worker:  l.worker,
+		out:     l.out,
+		level:   l.level,
+		sampler: l.sampler,
+		trigger: l.trigger,
+	}
+	nl.base.Store(l.base.Load())
+	nl.config.Store(l.config.Load())
+	l.retain()
+	return nl
+}
+
+// WithOptions creates a child Logger whose configuration is the parent's with
+// opts applied, keeping the parent's accumulated fields.
+//
+// The opts receive the parent's current settings; fields attached through
+// Options.Fields and Options.IncludeHostInfo are added after the parent's. Set Options.Output to write
+// the child to a different writer. The child shares the parent's writer or
+// background worker unless Output, Async, BufferSize, OverflowStrategy, Spill,
+// or WriteTimeout change, in which case it gets its own, and shares the parent's level unless
+// Level changes. Options.Core is nil in the Options passed to opts; leave it
+// nil to keep the parent's Core, or set it to replace it. Close the child when
+// done, like any other child Logger.
+//
+//	audit := logger.WithOptions(func(o *velo.Options) {
+//	  o.Output = auditFile
+//	  o.Formatter = velo.JSONFormatter
+//	})
+func (l *Logger) WithOptions(opts ...Option) *Logger {
+	if l == nil {
+		return nil
+	}
+	cur := l.config.Load()
+	o := l.options(cur)
+	level := o.Level
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	o.BufferSize = ringSize(o.BufferSize)
+	if o.PublishExpvar {
+		if m := publishExpvar(); m != nil {
+			o.Metrics = combineMetrics(o.Metrics, m)
+		}
+	}
+
+	retarget := o.Output != nil || o.Async != (l.worker != nil) ||
+		(l.worker != nil && (o.BufferSize != l.worker.queue.capacity() || o.OverflowStrategy != l.worker.strategy || o.Spill != l.worker.spill || o.WriteTimeout != l.worker.timeout))
+	w := o.Output
+	if w == nil {
+		w = l.writer()
+	}
+
+	cfg := newLoggerConfig(w, &o)
+	if cfg.sequence != nil && cur.sequence != nil {
+		cfg.sequence = cur.sequence
+	}
+
+	nl := &Logger{
+		level:   l.level,
+		sampler: l.sampler,
+	}
+	base := l.base.Load()
+	if o.IncludeHostInfo {
+		o.Fields = append(hostInfo(o.ServiceName, o.ServiceVersion), o.Fields...)
+	}
+	if len(o.Fields) > 0 || cfg.formatter != cur.formatter || cfg.timeFormat != cur.timeFormat || cfg.styles != cur.styles || cfg.layout != cur.layout {
+		base = base.with(o.Fields, nil, &cfg)
+	}
+	nl.base.Store(base)
+	if o.Level != level {
+		nl.level = new(levelState)
+		nl.level.val.Store(int64(o.Level))
+	}
+	if o.Core == nil && cur.core != nil {
+		cfg.core = cur.core
+		cfg.core.refs.Add(1)
+	}
+	switch {
+	case cfg.core != nil:
+		// The Core formats and writes entries.
+	case !retarget:
+		nl.worker = l.worker
+		nl.out = l.out
+		if l.worker != nil {
+			l.worker.refCount.Add(1)
+		}
+	case o.Async:
+		nl.worker = newWorker(w, o.BufferSize, o.OverflowStrategy, o.Metrics, o.OnWrite, o.ErrorHandler, o.Spill, o.WriteTimeout)
+	default:
+		nl.out = &syncWriter{out: w}
+	}
+	// Retained entries are written wherever the trigger entry goes, so a
+	// child with its own destination gets its own trigger buffer.
+	switch {
+	case o.TriggerBuffer <= 0:
+	case !retarget && l.trigger != nil && len(l.trigger.bufs) == o.TriggerBuffer:
+		nl.trigger = l.trigger
+	default:
+		nl.trigger = newTriggerRing(o.TriggerBuffer)
+	}
+	nl.config.Store(&cfg)
+	return nl
+}
+
+// options reconstructs the Options that produce cfg, leaving Output and Fields unset.
+func (l *Logger) options(cfg *loggerConfig) Options {
+	o := Options{
+		Level:              Level(l.level.val.Load()),
+		ReportTimestamp:    cfg.reportTimestamp,
+		TimeFormat:         cfg.timeFormat,
+		TimeLocation:       cfg.timeLocation,
+		DurationEncoding:   cfg.durations,
+		TimeFunction:       cfg.timeFunc,
+		Clock:              cfg.clock,
+		ReportSequence:     cfg.sequence != nil,
+		ReportCaller:       cfg.reportCaller,
+		CallerOffset:       cfg.callerOffset,
+		CallerFormatter:    cfg.callerFormatter,
+		CallerObject:       cfg.callerObject,
+		ReportStacktrace:   cfg.reportStacktrace,
+		DumpGoroutines:     cfg.dumpGoroutines,
+		GoroutineDumpLimit: cfg.dumpLimit,
+		Development:        cfg.development,
+		OnFatal:            cfg.onFatal,
+		ExitFunc:           cfg.exitFunc,
+		FatalBehavior:      cfg.fatalBehavior,
+		FatalExitCode:      cfg.fatalExitCode,
+		OnWrite:            cfg.onWrite,
+		TriggerLevel:       new(cfg.triggerLevel),
+		Schema:             cfg.schema,
+		ErrorHandler:       cfg.errorHandler,
+		StacktraceLevel:    cfg.stackLevel.option(),
+		StacktraceDepth:    cfg.stackDepth,
+		StackFrameFilter:   cfg.stackFilter,
+		Prefix:             cfg.prefix,
+		Name:               cfg.name,
+		MaxMessageBytes:    cfg.maxMessageBytes,
+		MaxEntryBytes:      cfg.maxEntryBytes,
+		SortFields:         cfg.sortFields,
+		DuplicateKeys:      cfg.duplicateKeys,
+		Styles:             cfg.baseStyles,
+		LevelLabels:        cfg.levelLabels,
+		Color:              cfg.color,
+		Formatter:          cfg.formatter,
+		Encoder:            cfg.encoder,
+		ContextExtractor:   cfg.contextExtractor,
+		FieldProviders:     cfg.providers,
+		Observer:           cfg.observer,
+		Hooks:              cfg.hooks,
+		Processors:         cfg.processors,
+		Metrics:            cfg.metrics,
+		FieldMetrics:       cfg.fieldMetrics,
+	}
+	if cfg.layout != nil {
+		o.TextLayout = *cfg.layout
+	}
+	if l.worker != nil {
+		o.Async = true
+		o.BufferSize = l.worker.queue.capacity()
+		o.OverflowStrategy = l.worker.strategy
+		o.Spill = l.worker.spill
+		o.WriteTimeout = l.worker.timeout
+	}
+	if l.trigger != nil {
+		o.TriggerBuffer = len(l.trigger.bufs)
+	}
+	return o
+}
+
+// writer returns the writer the Logger's output ultimately goes to.
+func (l *Logger) writer() io.Writer {
+	if l.worker != nil {
+		return l.worker.output
+	}
+	if l.out != nil {
+		return l.out.out
+	}
+	return os.Stderr
+}
+
+// WithCallerSkip creates a child Logger that skips n additional stack frames
+// when identifying the caller.
+//
+// The skip adds to the parent's CallerOffset. Give each wrapper helper its own
+// child, created with the helper's nesting depth, so that wrappers at
+// different depths all report the code that called them.
+func (l *Logger) WithCallerSkip(n int) *Logger {
+	if l == nil {
+		return nil
+	}
+	nl := l.Clone()
+	nl.SetCallerOffset(l.config.Load().callerOffset + n)
+	return nl
+}
+
 // Logf formats and writes a message at the specified level.
 //
-// It uses fmt.Sprintf to construct the message. This incurs allocation and
-// formatting overhead. Avoid using this in performance critical paths.
+// It accepts the same formats as fmt.Sprintf and produces the same message.
+//
+// Performance Note: The message is formatted straight into a pooled buffer,
+// so on the fast path a call allocates nothing beyond what the arguments
+// themselves need, such as boxing them into the variadic slice. Simple verbs
+// such as %s and %d on basic types avoid reflection entirely. Strongly typed
+// fields remain cheaper still.
 func (l *Logger) Logf(level Level, format string, args ...any) {
-	l.Log(level, fmt.Sprintf(format, args...))
+	l.logf(0, level, format, args)
+}
+
+// logf formats the message only once the level is known to be enabled.
+func (l *Logger) logf(skip int, level Level, format string, args []any) {
+	if l == nil {
+		return
+	}
+	if l.level.val.Load() > int64(level) {
+		if l.trigger != nil {
+			l.hold(skip, nil, level, string(appendf(nil, format, args)), nil, nil, false)
+		}
+		return
+	}
+	b := getBuffer()
+	b.B = appendf(b.B, format, args)
+
+	// On the fast path nothing can retain the message, since no Entry reaches
+	// hooks, observers, or cores, no sampler hook sees it, and no panic
+	// carries it, so it may alias the buffer.
+	cfg := l.config.Load()
+	if l.sampler == nil && (level < DPanicLevel || level == noLevel) && !cfg.needsEntry(level) {
+		l.output(skip, cfg, level, unsafe.String(unsafe.SliceData(b.B), len(b.B)), nil, nil, nil, cfg.now())
+	} else {
+		l.log(skip+1, level, string(b.B), nil)
+	}
+	putBuffer(b)
 }
 
 // SetLevel changes the minimum logging level for this Logger dynamically.
@@ -482,6 +1309,9 @@ func (l *Logger) Logf(level Level, format string, args ...any) {
 // messages below this level. Use this to adjust verbosity at runtime without
 // restarting the application.
 func (l *Logger) SetLevel(level Level) {
+	if l == nil {
+		return
+	}
 	l.level.val.Store(int64(level))
 }
 
@@ -491,6 +1321,9 @@ func (l *Logger) SetLevel(level Level) {
 // slightly improve performance and reduce log volume if your log aggregator
 // already assigns timestamps.
 func (l *Logger) SetReportTimestamp(report bool) {
+	if l == nil {
+		return
+	}
 	cfg := l.config.Load()
 	newCfg := *cfg
 	newCfg.reportTimestamp = report
@@ -505,6 +1338,9 @@ func (l *Logger) SetReportTimestamp(report bool) {
 // penalty because it requires unwinding the stack using runtime.Caller. Use
 // with caution in high throughput, latency critical paths.
 func (l *Logger) SetReportCaller(report bool) {
+	if l == nil {
+		return
+	}
 	cfg := l.config.Load()
 	newCfg := *cfg
 	newCfg.reportCaller = report
@@ -515,23 +1351,45 @@ func (l *Logger) SetReportCaller(report bool) {
 //
 // It safely updates the Logger's configuration. When enabled, the Logger
 // captures a stack trace whenever it writes an entry at ErrorLevel or higher,
-// or when an error field is present.
+// or when an error field is present. SetStacktraceLevel replaces this rule
+// with a plain level threshold.
 //
 // Performance Note: Capturing stack traces incurs a significant performance
 // penalty. Use this feature primarily for debugging or in environments where
 // error rates are low.
 func (l *Logger) SetReportStacktrace(report bool) {
+	if l == nil {
+		return
+	}
 	cfg := l.config.Load()
 	newCfg := *cfg
 	newCfg.reportStacktrace = report
 	l.config.Store(&newCfg)
 }
 
+// SetStacktraceLevel changes the minimum level at which stack traces are captured.
+//
+// It only takes effect while stack trace reporting is enabled, and replaces
+// the default of capturing at ErrorLevel or higher and for entries carrying
+// an error field with a plain level threshold.
+func (l *Logger) SetStacktraceLevel(level Level) {
+	if l == nil {
+		return
+	}
+	cfg := l.config.Load()
+	newCfg := *cfg
+	newCfg.stackLevel = stackLevel(level)
+	l.config.Store(&newCfg)
+}
+
 // SetPrefix changes the prefix prepended to all messages for this Logger.
 //
 // It safely updates the Logger's configuration. Use this to dynamically label
 // logs from a specific component.
 func (l *Logger) SetPrefix(prefix string) {
+	if l == nil {
+		return
+	}
 	cfg := l.config.Load()
 	newCfg := *cfg
 	newCfg.prefix = prefix
@@ -543,6 +1401,9 @@ func (l *Logger) SetPrefix(prefix string) {
 // It safely updates the Logger's configuration. The format string must conform
 // to the layout expected by the standard time package.
 func (l *Logger) SetTimeFormat(format string) {
+	if l == nil {
+		return
+	}
 	cfg := l.config.Load()
 	newCfg := *cfg
 	newCfg.timeFormat = format
@@ -554,6 +1415,9 @@ func (l *Logger) SetTimeFormat(format string) {
 // It safely updates the Logger's configuration. Use this to inject a custom
 // clock for testing or to apply specific timezone adjustments.
 func (l *Logger) SetTimeFunction(f TimeFunction) {
+	if l == nil {
+		return
+	}
 	cfg := l.config.Load()
 	newCfg := *cfg
 	newCfg.timeFunc = f
@@ -563,9 +1427,12 @@ func (l *Logger) SetTimeFunction(f TimeFunction) {
 // SetFormatter changes the Formatter used to serialize log entries.
 //
 // It safely updates the Logger's configuration. You can switch between built in
-// formatters like JSONFormatter and TextFormatter, or provide a custom
-// implementation.
+// formatters like JSONFormatter and TextFormatter. For a format of your own,
+// set Options.Encoder, which takes precedence over the Formatter.
 func (l *Logger) SetFormatter(f Formatter) {
+	if l == nil {
+		return
+	}
 	cfg := l.config.Load()
 	newCfg := *cfg
 	newCfg.formatter = f
@@ -577,9 +1444,13 @@ func (l *Logger) SetFormatter(f Formatter) {
 // It safely updates the Logger's configuration. Use this to customize how file
 // paths and line numbers appear in your logs.
 func (l *Logger) SetCallerFormatter(f CallerFormatter) {
+	if l == nil {
+		return
+	}
 	cfg := l.config.Load()
 	newCfg := *cfg
 	newCfg.callerFormatter = f
+	newCfg.callers = new(callerCache)
 	l.config.Store(&newCfg)
 }
 
@@ -589,71 +1460,105 @@ func (l *Logger) SetCallerFormatter(f CallerFormatter) {
 // the Logger in custom helper functions to ensure the reported caller reflects
 // the actual log origin.
 func (l *Logger) SetCallerOffset(offset int) {
+	if l == nil {
+		return
+	}
 	cfg := l.config.Load()
 	newCfg := *cfg
 	newCfg.callerOffset = offset
+	newCfg.callers = new(callerCache)
 	l.config.Store(&newCfg)
 }
 
 // Debug writes a message at DebugLevel with loosely typed key-value pairs.
-func (l *Logger) Debug(msg string, keyvals ...any) { l.Log(DebugLevel, msg, keyvals...) }
+func (l *Logger) Debug(msg string, keyvals ...any) { l.log(0, DebugLevel, msg, keyvals) }
 
 // Info writes a message at InfoLevel with loosely typed key-value pairs.
-func (l *Logger) Info(msg string, keyvals ...any) { l.Log(InfoLevel, msg, keyvals...) }
+func (l *Logger) Info(msg string, keyvals ...any) { l.log(0, InfoLevel, msg, keyvals) }
 
 // Warn writes a message at WarnLevel with loosely typed key-value pairs.
-func (l *Logger) Warn(msg string, keyvals ...any) { l.Log(WarnLevel, msg, keyvals...) }
+func (l *Logger) Warn(msg string, keyvals ...any) { l.log(0, WarnLevel, msg, keyvals) }
 
 // Error writes a message at ErrorLevel with loosely typed key-value pairs.
-func (l *Logger) Error(msg string, keyvals ...any) { l.Log(ErrorLevel, msg, keyvals...) }
+func (l *Logger) Error(msg string, keyvals ...any) { l.log(0, ErrorLevel, msg, keyvals) }
+
+// DPanic writes a message at DPanicLevel with loosely typed key-value pairs, then panics if Options.Development is set.
+func (l *Logger) DPanic(msg string, keyvals ...any) { l.log(0, DPanicLevel, msg, keyvals) }
 
 // Panic writes a message at PanicLevel with loosely typed key-value pairs, then panics.
-func (l *Logger) Panic(msg string, keyvals ...any) { l.Log(PanicLevel, msg, keyvals...) }
+func (l *Logger) Panic(msg string, keyvals ...any) { l.log(0, PanicLevel, msg, keyvals) }
 
-// Fatal writes a message at FatalLevel with loosely typed key-value pairs, then calls os.Exit(1).
-func (l *Logger) Fatal(msg string, keyvals ...any) { l.Log(FatalLevel, msg, keyvals...) }
+// Fatal writes a message at FatalLevel with loosely typed key-value pairs, then exits with status 1 unless Options.FatalBehavior says otherwise.
+func (l *Logger) Fatal(msg string, keyvals ...any) { l.log(0, FatalLevel, msg, keyvals) }
 
 // Print writes a message with no level and loosely typed key-value pairs.
-func (l *Logger) Print(msg string, keyvals ...any) { l.Log(noLevel, msg, keyvals...) }
+func (l *Logger) Print(msg string, keyvals ...any) { l.log(0, noLevel, msg, keyvals) }
 
 // Debugf formats and writes a message at DebugLevel.
-func (l *Logger) Debugf(format string, args ...any) { l.Log(DebugLevel, fmt.Sprintf(format, args...)) }
+func (l *Logger) Debugf(format string, args ...any) { l.logf(0, DebugLevel, format, args) }
 
 // Infof formats and writes a message at InfoLevel.
-func (l *Logger) Infof(format string, args ...any) { l.Log(InfoLevel, fmt.Sprintf(format, args...)) }
+func (l *Logger) Infof(format string, args ...any) { l.logf(0, InfoLevel, format, args) }
 
 // Warnf formats and writes a message at WarnLevel.
-func (l *Logger) Warnf(format string, args ...any) { l.Log(WarnLevel, fmt.Sprintf(format, args...)) }
+func (l *Logger) Warnf(format string, args ...any) { l.logf(0, WarnLevel, format, args) }
 
 // Errorf formats and writes a message at ErrorLevel.
-func (l *Logger) Errorf(format string, args ...any) { l.Log(ErrorLevel, fmt.Sprintf(format, args...)) }
+func (l *Logger) Errorf(format string, args ...any) { l.logf(0, ErrorLevel, format, args) }
+
+// DPanicf formats and writes a message at DPanicLevel, then panics if Options.Development is set.
+func (l *Logger) DPanicf(format string, args ...any) { l.logf(0, DPanicLevel, format, args) }
 
 // Panicf formats and writes a message at PanicLevel, then panics.
-func (l *Logger) Panicf(format string, args ...any) { l.Log(PanicLevel, fmt.Sprintf(format, args...)) }
+func (l *Logger) Panicf(format string, args ...any) { l.logf(0, PanicLevel, format, args) }
 
-// Fatalf formats and writes a message at FatalLevel, then calls os.Exit(1).
-func (l *Logger) Fatalf(format string, args ...any) { l.Log(FatalLevel, fmt.Sprintf(format, args...)) }
+// Fatalf formats and writes a message at FatalLevel, then exits with status 1 unless Options.FatalBehavior says otherwise.
+func (l *Logger) Fatalf(format string, args ...any) { l.logf(0, FatalLevel, format, args) }
 
 // Printf formats and writes a message with no level.
-func (l *Logger) Printf(format string, args ...any) { l.Log(noLevel, fmt.Sprintf(format, args...)) }
+func (l *Logger) Printf(format string, args ...any) { l.logf(0, noLevel, format, args) }
+
+// Debugt writes a message at DebugLevel from a template filled in by fields. See Logt.
+func (l *Logger) Debugt(template string, fields ...Field) { l.logt(0, DebugLevel, template, fields) }
+
+// Infot writes a message at InfoLevel from a template filled in by fields. See Logt.
+func (l *Logger) Infot(template string, fields ...Field) { l.logt(0, InfoLevel, template, fields) }
+
+// Warnt writes a message at WarnLevel from a template filled in by fields. See Logt.
+func (l *Logger) Warnt(template string, fields ...Field) { l.logt(0, WarnLevel, template, fields) }
+
+// Errort writes a message at ErrorLevel from a template filled in by fields. See Logt.
+func (l *Logger) Errort(template string, fields ...Field) { l.logt(0, ErrorLevel, template, fields) }
+
+// DPanict writes a message at DPanicLevel from a template filled in by fields, then panics if Options.Development is set. See Logt.
+func (l *Logger) DPanict(template string, fields ...Field) { l.logt(0, DPanicLevel, template, fields) }
+
+// Panict writes a message at PanicLevel from a template filled in by fields, then panics. See Logt.
+func (l *Logger) Panict(template string, fields ...Field) { l.logt(0, PanicLevel, template, fields) }
+
+// Fatalt writes a message at FatalLevel from a template filled in by fields, then exits with status 1 unless Options.FatalBehavior says otherwise. See Logt.
+func (l *Logger) Fatalt(template string, fields ...Field) { l.logt(0, FatalLevel, template, fields) }
 
 // DebugFields writes a message at DebugLevel with strongly typed fields, guaranteeing zero allocations.
-func (l *Logger) DebugFields(msg string, fields ...Field) { l.LogFields(DebugLevel, msg, fields...) }
+func (l *Logger) DebugFields(msg string, fields ...Field) { l.logFields(0, DebugLevel, msg, fields) }
 
 // InfoFields writes a message at InfoLevel with strongly typed fields, guaranteeing zero allocations.
-func (l *Logger) InfoFields(msg string, fields ...Field) { l.LogFields(InfoLevel, msg, fields...) }
+func (l *Logger) InfoFields(msg string, fields ...Field) { l.logFields(0, InfoLevel, msg, fields) }
 
 // WarnFields writes a message at WarnLevel with strongly typed fields, guaranteeing zero allocations.
-func (l *Logger) WarnFields(msg string, fields ...Field) { l.LogFields(WarnLevel, msg, fields...) }
+func (l *Logger) WarnFields(msg string, fields ...Field) { l.logFields(0, WarnLevel, msg, fields) }
 
 // ErrorFields writes a message at ErrorLevel with strongly typed fields, guaranteeing zero allocations.
-func (l *Logger) ErrorFields(msg string, fields ...Field) { l.LogFields(ErrorLevel, msg, fields...) }
+func (l *Logger) ErrorFields(msg string, fields ...Field) { l.logFields(0, ErrorLevel, msg, fields) }
+
+// DPanicFields writes a message at DPanicLevel with strongly typed fields, guaranteeing zero allocations, then panics if Options.Development is set.
+func (l *Logger) DPanicFields(msg string, fields ...Field) { l.logFields(0, DPanicLevel, msg, fields) }
 
 // PanicFields writes a message at PanicLevel with strongly typed fields, guaranteeing zero allocations, then panics.
-func (l *Logger) PanicFields(msg string, fields ...Field) { l.LogFields(PanicLevel, msg, fields...) }
+func (l *Logger) PanicFields(msg string, fields ...Field) { l.logFields(0, PanicLevel, msg, fields) }
 
-// FatalFields writes a message at FatalLevel with strongly typed fields, guaranteeing zero allocations, then calls os.Exit(1).
-func (l *Logger) FatalFields(msg string, fields ...Field) { l.LogFields(FatalLevel, msg, fields...) }
+// FatalFields writes a message at FatalLevel with strongly typed fields, guaranteeing zero allocations, then exits with status 1 unless Options.FatalBehavior says otherwise.
+func (l *Logger) FatalFields(msg string, fields ...Field) { l.logFields(0, FatalLevel, msg, fields) }
 
 // getCaller identifies the file, line, and function name of the calling code.
 //
@@ -678,25 +1583,25 @@ func (l *Logger) getCaller(skip int) (string, int, string) {
 // For absolute maximum performance and zero allocations, use the strongly typed
 // LogFields method instead.
 func (l *Logger) Log(level Level, msg string, keyvals ...any) {
+	l.log(0, level, msg, keyvals)
+}
+
+func (l *Logger) log(skip int, level Level, msg string, keyvals []any) {
+	if l == nil {
+		return
+	}
 	if l.level.val.Load() > int64(level) {
+		if l.trigger != nil {
+			l.hold(skip, nil, level, msg, keyvals, nil, false)
+		}
 		return
 	}
-	l.log(level, msg, keyvals)
-}
 
-func (l *Logger) log(level Level, msg string, keyvals []any) {
 	cfg := l.config.Load()
 
-	var t time.Time
-	if cfg.reportTimestamp {
-		if cfg.timeFunc != nil {
-			t = cfg.timeFunc(time.Now())
-		} else {
-			t = time.Now()
-		}
-	}
+	t := cfg.now()
 
-	if l.sampler != nil && !l.sampler.check(level, msg, t) {
+	if !l.sample(cfg, level, msg, t) {
 		return
 	}
 
@@ -704,173 +1609,319 @@ func (l *Logger) log(level Level, msg string, keyvals []any) {
 	// OR we can just handle them here.
 	// For maximum performance on the hot path (no stack/caller), we skip Entry.
 
-	if cfg.reportStacktrace || cfg.reportCaller {
+	if cfg.needsEntry(level) {
 		// Fallback to full Entry path for complex cases
-		l.logWithEntry(level, msg, keyvals, nil, nil, cfg, t)
+		l.logWithEntry(skip, level, msg, keyvals, nil, nil, cfg, t, false)
 		return
 	}
 
 	// Fast path: direct formatting
-	b := getBuffer()
-
-	if cfg.formatter == JSONFormatter {
-		formatLogJSON(b, l, cfg, level, msg, keyvals, nil, nil, t)
-	} else {
-		formatLogText(b, l, cfg, level, msg, keyvals, nil, nil, t)
-	}
-
-	l.submit(b)
-
-	if level == PanicLevel {
-		l.Sync()
-		panic(msg)
-	}
+	l.output(skip, cfg, level, msg, keyvals, nil, nil, t)
+}
 
-	if level == FatalLevel {
-		flushAllWorkers()
-		os.Exit(1)
+// logWithEntry assembles and writes a pooled Entry. template marks msg as a
+// template for Logt.
+func (l *Logger) logWithEntry(skip int, level Level, msg string, keyvals []any, typedFields []Field, ctxFields []Field, cfg *loggerConfig, t time.Time, template bool) {
+	// An entry the Core does not want is still assembled at DPanicLevel and
+	// above, so that it can panic or apply FatalBehavior.
+	disabled := cfg.core != nil && !cfg.core.Enabled(level)
+	if disabled && (level < DPanicLevel || level == noLevel) {
+		return
 	}
-}
+	l.trip(cfg, l.trigger, level)
 
-func (l *Logger) logWithEntry(level Level, msg string, keyvals []any, typedFields []Field, ctxFields []Field, cfg *loggerConfig, t time.Time) {
 	e := getEntry()
 	e.Level = level
 	e.Time = t
 	e.Message = msg
+	e.template = template
 	e.Prefix = cfg.prefix
 	e.Formatter = cfg.formatter
+	e.encoder = cfg.encoder
 	e.TimeFormat = cfg.timeFormat
-
-	// append logger fields
-	if cfg.formatter == JSONFormatter && (len(l.preEncodedJSON) > 0 || (len(l.fields) == 0 && len(l.typedFields) == 0)) {
-		e.PreEncodedJSON = l.preEncodedJSON
-	} else {
-		if len(l.fields) > 0 {
-			e.Fields = append(e.Fields, l.fields...)
-		}
-		if len(l.typedFields) > 0 {
-			e.TypedFields = append(e.TypedFields, l.typedFields...)
-		}
+	e.timeLocation = cfg.timeLocation
+	e.durations = cfg.durations
+	e.Styles = cfg.styles
+	e.Layout = cfg.layout
+	e.duplicateKeys = cfg.duplicateKeys
+	if cfg.sequence != nil {
+		e.Sequence = cfg.sequence.Add(1)
 	}
 
-	// append log-specific fields
-	if len(keyvals) > 0 {
-		e.Fields = append(e.Fields, keyvals...)
+	// Logger fields come first, then context fields, then call fields. Loosely
+	// typed call pairs become Fields so they keep their place after any typed
+	// logger or context fields.
+	base := l.base.Load()
+	if cfg.name != "" {
+		e.TypedFields = append(e.TypedFields, String(NameKey, cfg.name))
+	}
+	switch {
+	case cfg.sortFields || cfg.duplicateKeys != DuplicateKeysAllow || (cfg.name != "" && len(base.fields) > 0):
+		// Loosely typed fields are written first, so they join the typed
+		// fields to keep their place after the name.
+		e.TypedFields = appendKeyVals(e.TypedFields, base.fields)
+		e.TypedFields = append(e.TypedFields, base.typedFields...)
+	case cfg.name == "" && cfg.formatter == JSONFormatter && cfg.encoder == nil && cfg.maxEntryBytes == 0 && cfg.observer == nil && len(cfg.hooks) == 0 && cfg.process == nil && cfg.core == nil && cfg.schema == nil && (base.json != nil || (len(base.fields) == 0 && len(base.typedFields) == 0)):
+		e.PreEncodedJSON = base.json.bytes()
+	default:
+		e.Fields = append(e.Fields, base.fields...)
+		e.TypedFields = append(e.TypedFields, base.typedFields...)
+	}
+	e.TypedFields = base.appendConditional(e.TypedFields, level)
+	for _, p := range cfg.providers {
+		e.TypedFields = append(e.TypedFields, p()...)
 	}
-	if len(ctxFields) > 0 {
-		e.TypedFields = append(e.TypedFields, ctxFields...)
+	e.TypedFields = append(e.TypedFields, ctxFields...)
+	e.TypedFields = appendKeyVals(e.TypedFields, keyvals)
+	e.TypedFields = append(e.TypedFields, typedFields...)
+
+	if short, ok := truncateMessage(msg, cfg.maxMessageBytes); ok {
+		e.Message = short + TruncationMarker
+		e.TypedFields = append(e.TypedFields, Int(TruncatedMessageKey, len(msg)))
 	}
-	if len(typedFields) > 0 {
-		e.TypedFields = append(e.TypedFields, typedFields...)
+
+	if cfg.sortFields {
+		slices.SortStableFunc(e.TypedFields, compareFieldKeys)
 	}
 
 	if cfg.reportStacktrace {
-		hasErr := level >= ErrorLevel
-
-		if !hasErr {
-			for i := 0; i < len(keyvals); i++ {
-				if _, ok := keyvals[i].(error); ok {
-					hasErr = true
-					break
-				}
-			}
-			for _, f := range ctxFields {
-				if f.Type == ErrorType {
-					hasErr = true
-					break
-				}
-			}
-			for _, f := range typedFields {
-				if f.Type == ErrorType {
-					hasErr = true
-					break
-				}
-			}
-		}
+		hasErr := cfg.stackLevel.captures(level, keyvals, ctxFields, typedFields)
 
 		if hasErr {
-			var pcs [32]uintptr
-			n := runtime.Callers(4, pcs[:]) // +1 for logWithEntry
-			e.Stack = append(e.Stack[:0], pcs[:n]...)
+			// Leave headroom for the frames the filter hides. The PCs are
+			// captured straight into the Entry's own storage.
+			buf := e.Stack[:cap(e.Stack)]
+			if n := max(cfg.stackDepth, DefaultStacktraceDepth) + 16; n > len(buf) {
+				buf = make([]uintptr, n)
+			}
+			n := runtime.Callers(4, buf) // +1 for logWithEntry
+			e.Stack = buf[:n]
+			e.StackDepth = cfg.stackDepth
+			e.StackFilter = cfg.stackFilter
+			// The worker symbolizes the trace off the logging goroutine.
+			e.deferStack = l.worker != nil && cfg.encoder == nil
 		}
 	}
 
-	if cfg.reportCaller {
-		file, line, fn := l.getCaller(cfg.callerOffset + 4) // +1 for logWithEntry
-		if file != "" && cfg.callerFormatter != nil {
-			e.Caller = cfg.callerFormatter(file, line, fn)
+	if cfg.dumpGoroutines && level >= PanicLevel && level != noLevel {
+		e.Goroutines = dumpGoroutines(cfg.dumpLimit)
+	}
+
+	if cfg.reportCaller || cfg.developmentCaller(level) {
+		if ci := cfg.callers.caller(l, cfg.callerOffset+skip+4, cfg.callerFormatter); ci != nil { // +1 for logWithEntry
+			e.Caller = ci.formatted
+			e.CallerFile, e.CallerLine, e.CallerFunc = ci.file, ci.line, ci.fn
+			e.callerObject = cfg.callerObject
 		}
 	}
 
-	b := getBuffer()
-	formatEntry(b, e)
+	if !disabled {
+		l.deliver(cfg, e)
+	}
+	if level == FatalLevel && cfg.onFatal != nil {
+		l.Sync()
+		cfg.onFatal(e)
+	}
 	putEntry(e)
+	l.terminate(cfg, level, msg)
+}
+
+// deliver runs e through the hooks and processors and writes it. The caller
+// keeps ownership of e.
+func (l *Logger) deliver(cfg *loggerConfig, e *Entry) {
+	level := e.Level
+	if len(cfg.hooks) > 0 && !runHooks(cfg.hooks, e) {
+		if cfg.metrics != nil {
+			cfg.metrics.EntryDropped(level, DropHook)
+		}
+		return
+	}
 
-	l.submit(b)
+	if cfg.process != nil {
+		e.logger, e.cfg = l, cfg
+		cfg.process(e)
+		if !e.written && cfg.metrics != nil {
+			cfg.metrics.EntryDropped(level, DropHook)
+		}
+		return
+	}
+	l.writeEntry(cfg, e)
+}
+
+// writeEntry observes, formats, and submits e. The caller keeps ownership of e.
+func (l *Logger) writeEntry(cfg *loggerConfig, e *Entry) {
+	if cfg.schema != nil {
+		cfg.schema.check(e, cfg.reportError)
+	}
+	if cfg.observer != nil {
+		cfg.observer.ObserveEntry(e)
+	}
+
+	if cfg.core != nil {
+		if err := cfg.core.Write(e); err != nil && cfg.metrics != nil {
+			cfg.metrics.WriteError(err)
+		}
+		return
+	}
+
+	var start time.Time
+	if cfg.metrics != nil {
+		start = time.Now()
+	}
+
+	b := getBufferSize(max(e.sizeHint(), cfg.sizes.estimate()))
+	b.limitEntry(cfg.maxEntryBytes)
+	formatEntry(b, e, cfg.times)
+	l.emit(cfg, b, e.Level, start)
+}
+
+// output formats an entry directly onto a pooled buffer, bypassing the Entry
+// struct, and writes it. The caller, when reported, costs one lookup in the
+// caller cache rather than the Entry path.
+func (l *Logger) output(skip int, cfg *loggerConfig, level Level, msg string, keyvals []any, fields []Field, ctxFields []Field, t time.Time) {
+	var start time.Time
+	if cfg.metrics != nil {
+		start = time.Now()
+	}
 
-	if level == PanicLevel {
+	var ci *callerInfo
+	if cfg.reportCaller || cfg.developmentCaller(level) {
+		ci = cfg.callers.caller(l, cfg.callerOffset+skip+4, cfg.callerFormatter) // +1 for output
+	}
+
+	if cfg.providers != nil {
+		ctxFields = cfg.provide(ctxFields)
+	}
+	l.trip(cfg, l.trigger, level)
+	b := getBufferSize(cfg.sizes.estimate())
+	b.limitEntry(cfg.maxEntryBytes)
+
+	switch cfg.formatter {
+	case JSONFormatter:
+		formatLogJSON(b, l.base.Load(), cfg, level, msg, keyvals, fields, nil, ctxFields, ci, t)
+	case BinaryFormatter:
+		formatLogBinary(b, l.base.Load(), cfg, level, msg, keyvals, fields, ctxFields, ci, t)
+	default:
+		formatLogText(b, l.base.Load(), cfg, level, msg, keyvals, fields, ctxFields, ci, t)
+	}
+
+	l.write(cfg, b, level, msg, start)
+}
+
+// write hands a formatted buffer to the destination and then applies the
+// terminal behavior of PanicLevel and FatalLevel.
+//
+// The start time marks when formatting began and is only consulted when a
+// MetricsHook is configured.
+func (l *Logger) write(cfg *loggerConfig, b *buffer, level Level, msg string, start time.Time) {
+	l.emit(cfg, b, level, start)
+	l.terminate(cfg, level, msg)
+}
+
+// emit submits a formatted buffer and records its metrics.
+func (l *Logger) emit(cfg *loggerConfig, b *buffer, level Level, start time.Time) {
+	if cfg.held != nil {
+		cfg.held.push(b)
+		return
+	}
+	cfg.sizes.observe(len(b.B))
+	if cfg.metrics != nil {
+		cfg.metrics.EntryLogged(level, time.Since(start))
+		if !l.submit(cfg, b) {
+			cfg.metrics.EntryDropped(level, DropOverflow)
+		}
+	} else {
+		l.submit(cfg, b)
+	}
+}
+
+// terminate panics for PanicLevel, and for DPanicLevel in development, and
+// applies Options.FatalBehavior for FatalLevel, after flushing buffered
+// entries.
+func (l *Logger) terminate(cfg *loggerConfig, level Level, msg string) {
+	if cfg.recovered {
+		return
+	}
+	if level == PanicLevel || (level == DPanicLevel && cfg.development) {
 		l.Sync()
 		panic(msg)
 	}
 
 	if level == FatalLevel {
+		switch cfg.fatalBehavior {
+		case FatalPanic:
+			l.Sync()
+			panic(msg)
+		case FatalCallback:
+			l.Sync()
+			return
+		}
+		l.Sync()
 		flushAllWorkers()
-		os.Exit(1)
+		code := cfg.fatalExitCode
+		if code == 0 {
+			code = 1
+		}
+		if cfg.exitFunc != nil {
+			cfg.exitFunc(code)
+			return
+		}
+		exit(code)
 	}
 }
 
+// LogWithSkip writes a message like Log, skipping skip additional stack frames
+// when identifying the caller.
+//
+// Use this in logging helpers, passing the number of helper frames between the
+// call site you want reported and LogWithSkip itself.
+func (l *Logger) LogWithSkip(skip int, level Level, msg string, keyvals ...any) {
+	l.log(skip, level, msg, keyvals)
+}
+
+// LogFieldsWithSkip writes a message like LogFields, skipping skip additional
+// stack frames when identifying the caller.
+func (l *Logger) LogFieldsWithSkip(skip int, level Level, msg string, fields ...Field) {
+	l.logFields(skip, level, msg, fields)
+}
+
 // LogFields writes a message with strongly typed fields at the specified level.
 //
 // This method guarantees zero allocations on the hot path, making it ideal for
 // extreme high throughput, latency critical applications.
 func (l *Logger) LogFields(level Level, msg string, fields ...Field) {
+	l.logFields(0, level, msg, fields)
+}
+
+func (l *Logger) logFields(skip int, level Level, msg string, fields []Field) {
+	if l == nil {
+		return
+	}
 	if l.level.val.Load() > int64(level) {
+		if l.trigger != nil {
+			l.hold(skip, nil, level, msg, nil, fields, false)
+		}
 		return
 	}
-	l.logFields(level, msg, fields)
-}
 
-func (l *Logger) logFields(level Level, msg string, fields []Field) {
 	cfg := l.config.Load()
-
-	var t time.Time
-	if cfg.reportTimestamp {
-		if cfg.timeFunc != nil {
-			t = cfg.timeFunc(time.Now())
-		} else {
-			t = time.Now()
-		}
+	if cfg.fieldMetrics != nil {
+		recordMetrics(cfg.fieldMetrics, fields)
 	}
 
-	if l.sampler != nil && !l.sampler.check(level, msg, t) {
+	t, fields := cfg.stamp(fields)
+
+	if !l.sample(cfg, level, msg, t) {
 		return
 	}
 
-	if cfg.reportStacktrace || cfg.reportCaller {
-		l.logWithEntry(level, msg, nil, fields, nil, cfg, t)
+	if cfg.needsEntry(level) {
+		l.logWithEntry(skip, level, msg, nil, fields, nil, cfg, t, false)
 		return
 	}
 
 	// Fast path: direct formatting
-	b := getBuffer()
-
-	if cfg.formatter == JSONFormatter {
-		formatLogJSON(b, l, cfg, level, msg, nil, fields, nil, t)
-	} else {
-		formatLogText(b, l, cfg, level, msg, nil, fields, nil, t)
-	}
-
-	l.submit(b)
-
-	if level == PanicLevel {
-		l.Sync()
-		panic(msg)
-	}
-
-	if level == FatalLevel {
-		flushAllWorkers()
-		os.Exit(1)
-	}
+	l.output(skip, cfg, level, msg, nil, fields, nil, t)
 }
 
 // Global functions
@@ -912,67 +1963,100 @@ func WithFields(fields ...Field) *Logger { return Default().WithFields(fields...
 func WithPrefix(prefix string) *Logger { return Default().WithPrefix(prefix) }
 
 // Log writes a message to the global default Logger at the specified level.
-func Log(level Level, msg string, keyvals ...any) { Default().Log(level, msg, keyvals...) }
+func Log(level Level, msg string, keyvals ...any) { Default().log(0, level, msg, keyvals) }
 
 // Debug writes a message to the global default Logger at DebugLevel.
-func Debug(msg string, keyvals ...any) { Default().Log(DebugLevel, msg, keyvals...) }
+func Debug(msg string, keyvals ...any) { Default().log(0, DebugLevel, msg, keyvals) }
 
 // Info writes a message to the global default Logger at InfoLevel.
-func Info(msg string, keyvals ...any) { Default().Log(InfoLevel, msg, keyvals...) }
+func Info(msg string, keyvals ...any) { Default().log(0, InfoLevel, msg, keyvals) }
 
 // Warn writes a message to the global default Logger at WarnLevel.
-func Warn(msg string, keyvals ...any) { Default().Log(WarnLevel, msg, keyvals...) }
+func Warn(msg string, keyvals ...any) { Default().log(0, WarnLevel, msg, keyvals) }
 
 // Error writes a message to the global default Logger at ErrorLevel.
-func Error(msg string, keyvals ...any) { Default().Log(ErrorLevel, msg, keyvals...) }
+func Error(msg string, keyvals ...any) { Default().log(0, ErrorLevel, msg, keyvals) }
+
+// DPanic writes a message to the global default Logger at DPanicLevel, then panics if Options.Development is set.
+func DPanic(msg string, keyvals ...any) { Default().log(0, DPanicLevel, msg, keyvals) }
 
 // Panic writes a message to the global default Logger at PanicLevel, then panics.
-func Panic(msg string, keyvals ...any) { Default().Log(PanicLevel, msg, keyvals...) }
+func Panic(msg string, keyvals ...any) { Default().log(0, PanicLevel, msg, keyvals) }
 
-// Fatal writes a message to the global default Logger at FatalLevel, then calls os.Exit(1).
-func Fatal(msg string, keyvals ...any) { Default().Log(FatalLevel, msg, keyvals...) }
+// Fatal writes a message to the global default Logger at FatalLevel, then exits with status 1 unless Options.FatalBehavior says otherwise.
+func Fatal(msg string, keyvals ...any) { Default().log(0, FatalLevel, msg, keyvals) }
 
 // Print writes a message to the global default Logger with no level.
-func Print(msg string, keyvals ...any) { Default().Log(noLevel, msg, keyvals...) }
+func Print(msg string, keyvals ...any) { Default().log(0, noLevel, msg, keyvals) }
 
 // Logf formats and writes a message to the global default Logger at the specified level.
-func Logf(level Level, format string, args ...any) { Default().Logf(level, format, args...) }
+func Logf(level Level, format string, args ...any) { Default().logf(0, level, format, args) }
 
 // Debugf formats and writes a message to the global default Logger at DebugLevel.
-func Debugf(format string, args ...any) { Default().Debugf(format, args...) }
+func Debugf(format string, args ...any) { Default().logf(0, DebugLevel, format, args) }
 
 // Infof formats and writes a message to the global default Logger at InfoLevel.
-func Infof(format string, args ...any) { Default().Infof(format, args...) }
+func Infof(format string, args ...any) { Default().logf(0, InfoLevel, format, args) }
 
 // Warnf formats and writes a message to the global default Logger at WarnLevel.
-func Warnf(format string, args ...any) { Default().Warnf(format, args...) }
+func Warnf(format string, args ...any) { Default().logf(0, WarnLevel, format, args) }
 
 // Errorf formats and writes a message to the global default Logger at ErrorLevel.
-func Errorf(format string, args ...any) { Default().Errorf(format, args...) }
+func Errorf(format string, args ...any) { Default().logf(0, ErrorLevel, format, args) }
+
+// DPanicf formats and writes a message to the global default Logger at DPanicLevel, then panics if Options.Development is set.
+func DPanicf(format string, args ...any) { Default().logf(0, DPanicLevel, format, args) }
 
 // Panicf formats and writes a message to the global default Logger at PanicLevel, then panics.
-func Panicf(format string, args ...any) { Default().Panicf(format, args...) }
+func Panicf(format string, args ...any) { Default().logf(0, PanicLevel, format, args) }
 
-// Fatalf formats and writes a message to the global default Logger at FatalLevel, then calls os.Exit(1).
-func Fatalf(format string, args ...any) { Default().Fatalf(format, args...) }
+// Fatalf formats and writes a message to the global default Logger at FatalLevel, then exits with status 1 unless Options.FatalBehavior says otherwise.
+func Fatalf(format string, args ...any) { Default().logf(0, FatalLevel, format, args) }
 
 // Printf formats and writes a message to the global default Logger with no level.
-func Printf(format string, args ...any) { Default().Printf(format, args...) }
+func Printf(format string, args ...any) { Default().logf(0, noLevel, format, args) }
+
+// Logt writes a message to the global default Logger at the specified level from a template filled in by fields.
+func Logt(level Level, template string, fields ...Field) { Default().logt(0, level, template, fields) }
+
+// Debugt writes a message to the global default Logger at DebugLevel from a template filled in by fields.
+func Debugt(template string, fields ...Field) { Default().logt(0, DebugLevel, template, fields) }
+
+// Infot writes a message to the global default Logger at InfoLevel from a template filled in by fields.
+func Infot(template string, fields ...Field) { Default().logt(0, InfoLevel, template, fields) }
+
+// Warnt writes a message to the global default Logger at WarnLevel from a template filled in by fields.
+func Warnt(template string, fields ...Field) { Default().logt(0, WarnLevel, template, fields) }
+
+// Errort writes a message to the global default Logger at ErrorLevel from a template filled in by fields.
+func Errort(template string, fields ...Field) { Default().logt(0, ErrorLevel, template, fields) }
+
+// DPanict writes a message to the global default Logger at DPanicLevel from a template filled in by fields, then panics if Options.Development is set.
+func DPanict(template string, fields ...Field) { Default().logt(0, DPanicLevel, template, fields) }
+
+// Panict writes a message to the global default Logger at PanicLevel from a template filled in by fields, then panics.
+func Panict(template string, fields ...Field) { Default().logt(0, PanicLevel, template, fields) }
+
+// Fatalt writes a message to the global default Logger at FatalLevel from a template filled in by fields, then exits with status 1 unless Options.FatalBehavior says otherwise.
+func Fatalt(template string, fields ...Field) { Default().logt(0, FatalLevel, template, fields) }
 
 // DebugFields writes a message to the global default Logger at DebugLevel with strongly typed fields.
-func DebugFields(msg string, fields ...Field) { Default().LogFields(DebugLevel, msg, fields...) }
+func DebugFields(msg string, fields ...Field) { Default().logFields(0, DebugLevel, msg, fields) }
 
 // InfoFields writes a message to the global default Logger at InfoLevel with strongly typed fields.
-func InfoFields(msg string, fields ...Field) { Default().LogFields(InfoLevel, msg, fields...) }
+func InfoFields(msg string, fields ...Field) { Default().logFields(0, InfoLevel, msg, fields) }
 
 // WarnFields writes a message to the global default Logger at WarnLevel with strongly typed fields.
-func WarnFields(msg string, fields ...Field) { Default().LogFields(WarnLevel, msg, fields...) }
+func WarnFields(msg string, fields ...Field) { Default().logFields(0, WarnLevel, msg, fields) }
 
 // ErrorFields writes a message to the global default Logger at ErrorLevel with strongly typed fields.
-func ErrorFields(msg string, fields ...Field) { Default().LogFields(ErrorLevel, msg, fields...) }
+func ErrorFields(msg string, fields ...Field) { Default().logFields(0, ErrorLevel, msg, fields) }
+
+// DPanicFields writes a message to the global default Logger at DPanicLevel with strongly typed fields, then panics if Options.Development is set.
+func DPanicFields(msg string, fields ...Field) { Default().logFields(0, DPanicLevel, msg, fields) }
 
 // PanicFields writes a message to the global default Logger at PanicLevel with strongly typed fields, then panics.
-func PanicFields(msg string, fields ...Field) { Default().LogFields(PanicLevel, msg, fields...) }
+func PanicFields(msg string, fields ...Field) { Default().logFields(0, PanicLevel, msg, fields) }
 
-// FatalFields writes a message to the global default Logger at FatalLevel with strongly typed fields, then calls os.Exit(1).
-func FatalFields(msg string, fields ...Field) { Default().LogFields(FatalLevel, msg, fields...) }
+// FatalFields writes a message to the global default Logger at FatalLevel with strongly typed fields, then exits with status 1 unless Options.FatalBehavior says otherwise.
+func FatalFields(msg string, fields ...Field) { Default().logFields(0, FatalLevel, msg, fields) }
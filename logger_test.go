@@ -0,0 +1,135 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithFieldsAtFollowsTimeFormat(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithOptions(&buf, Options{Formatter: JSONFormatter, TimeFormat: time.RFC3339})
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	child := l.WithFieldsAt(InfoLevel, Time("at", at))
+	child.SetTimeFormat(time.Kitchen)
+	child.Info("msg")
+	child.Sync()
+	if got := buf.String(); !strings.Contains(got, `"at":"3:04AM"`) {
+		t.Errorf("got %s, want the conditional field in the new format", got)
+	}
+}
+
+func TestNamedHasOwnLevel(t *testing.T) {
+	l := New(nil, WithLevel(InfoLevel))
+	db := l.Named("db")
+	pool := db.Named("pool")
+	if pool.Enabled(DebugLevel) || !pool.Enabled(InfoLevel) {
+		t.Fatal("child does not start at the parent's level")
+	}
+	db.SetLevel(WarnLevel)
+	pool.SetLevel(DebugLevel)
+	if db.Enabled(InfoLevel) {
+		t.Error("db enables InfoLevel after SetLevel(WarnLevel)")
+	}
+	if !pool.Enabled(DebugLevel) {
+		t.Error("db.pool does not enable DebugLevel after SetLevel(DebugLevel)")
+	}
+	if !l.Enabled(InfoLevel) || l.Enabled(DebugLevel) {
+		t.Error("root level changed with its children")
+	}
+}
+
+// levelCore is a Core that enables no level below min and discards entries.
+type levelCore struct {
+	min Level
+}
+
+func (c levelCore) Enabled(level Level) bool { return level >= c.min }
+func (c levelCore) With([]Field) Core        { return c }
+func (c levelCore) Write(*Entry) error       { return nil }
+func (c levelCore) Sync() error              { return nil }
+
+func TestFatalBehaviorWithDisabledCore(t *testing.T) {
+	core := levelCore{min: noLevel}
+
+	var code int
+	var called bool
+	l := NewWithOptions(nil, Options{
+		Core:     core,
+		ExitFunc: func(c int) { code = c },
+		OnFatal:  func(*Entry) { called = true },
+	})
+	l.Fatal("exit")
+	if code != 1 || !called {
+		t.Errorf("FatalExit: exit code %d, OnFatal called %v; want 1 and true", code, called)
+	}
+
+	l = NewWithOptions(nil, Options{Core: core, FatalBehavior: FatalPanic})
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("FatalPanic did not panic")
+			}
+		}()
+		l.Fatal("panic")
+	}()
+}
+
+func TestCheckWithDisabledCore(t *testing.T) {
+	l := NewWithOptions(nil, Options{Core: levelCore{min: WarnLevel}, Level: DebugLevel})
+	if ce := l.Check(InfoLevel, "hidden"); ce != nil {
+		t.Error("Check returned an entry for a level the Core disables")
+	}
+	ce := l.Check(WarnLevel, "shown")
+	if ce == nil {
+		t.Fatal("Check returned nil for a level the Core enables")
+	}
+	ce.Write()
+}
+
+func TestAsyncWriteErrorHandler(t *testing.T) {
+	var mu sync.Mutex
+	var errs []error
+	l := New(failingWriter{}, WithAsync(16, OverflowBlock), WithErrorHandler(func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}))
+	l.Info("one")
+	l.Sync()
+	l.Info("two")
+	l.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) == 0 {
+		t.Fatal("ErrorHandler received no write errors")
+	}
+	for _, err := range errs {
+		if err.Error() != "unavailable" {
+			t.Errorf("ErrorHandler received %v", err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+// FieldMetrics receives the values of Counter and Gauge fields, so that a log
+// call on a rare path also updates a metric without a second call. The
+// veloprom and velootel modules provide implementations.
+//
+// Implementations must be safe for concurrent use and return quickly, because
+// they run on the logging goroutine.
+type FieldMetrics interface {
+	// AddCounter adds delta to the counter named name.
+	AddCounter(name string, delta int64)
+	// SetGauge sets the gauge named name to value.
+	SetGauge(name string, value float64)
+}
+
+// metricKind marks the fields forwarded to FieldMetrics. Counters store it in
+// Field.Any, gauges in Field.Int, the member each type leaves unused.
+type metricKind uint8
+
+const (
+	metricCounter metricKind = iota + 1
+	metricGauge
+)
+
+// Counter constructs an integer field that also adds delta to the counter
+// named key of the Logger's FieldMetrics:
+//
+//	logger.WarnFields("cache miss", velo.String("key", k), velo.Counter("cache_misses", 1))
+//
+// It is written like Int64.
+func Counter(key string, delta int64) Field {
+	return Field{Key: key, Type: IntType, Int: delta, Any: metricCounter}
+}
+
+// Gauge constructs a field that also sets the gauge named key of the Logger's
+// FieldMetrics to value. It is written like Any.
+func Gauge(key string, value float64) Field {
+	return Field{Key: key, Type: AnyType, Any: value, Int: int64(metricGauge)}
+}
+
+// recordMetrics forwards the Counter and Gauge fields among fields to m.
+func recordMetrics(m FieldMetrics, fields []Field) {
+	for i := range fields {
+		f := &fields[i]
+		switch {
+		case f.Type == IntType && f.Any == metricCounter:
+			m.AddCounter(f.Key, f.Int)
+		case f.Type == AnyType && f.Int == int64(metricGauge):
+			if v, ok := f.Any.(float64); ok {
+				m.SetGauge(f.Key, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "time"
+
+// DropReason identifies why a Logger discarded an entry.
+type DropReason uint8
+
+const (
+	// DropSampled indicates that the Sampler discarded the entry.
+	DropSampled DropReason = iota
+	// DropOverflow indicates that the asynchronous buffer was full and the
+	// OverflowDrop strategy discarded the entry.
+	DropOverflow
+	// DropHook indicates that a Hook vetoed the entry, or that a Processor
+	// did not pass it on.
+	DropHook
+)
+
+// String returns the lowercase ASCII representation of the reason.
+func (r DropReason) String() string {
+	switch r {
+	case DropSampled:
+		return "sampled"
+	case DropOverflow:
+		return "overflow"
+	case DropHook:
+		return "hook"
+	default:
+		return "unknown"
+	}
+}
+
+// MetricsHook receives measurements describing the Logger's own behavior.
+//
+// Implementations must be safe for concurrent use and should return quickly,
+// because the Logger calls them on the logging hot path and from the
+// background worker. The veloprom module provides a Prometheus backed
+// implementation.
+type MetricsHook interface {
+	// EntryLogged fires once for every entry the Logger formats, along with the
+	// time spent formatting it.
+	EntryLogged(level Level, formatLatency time.Duration)
+	// EntryDropped fires whenever the Logger discards an entry.
+	EntryDropped(level Level, reason DropReason)
+	// BytesWritten fires after a successful write to the destination.
+	BytesWritten(n int)
+	// WriteError fires whenever writing to the destination fails.
+	WriteError(err error)
+}
+
+// WriteStats describes one write of formatted entries to a Logger's
+// destination. See Options.OnWrite.
+type WriteStats struct {
+	// Entries is the number of entries in the write.
+	Entries int
+	// Bytes is the number of bytes written.
+	Bytes int
+	// Duration is the time spent writing. For the background worker, it runs
+	// from buffering the first entry of the batch until the flush returns.
+	Duration time.Duration
+	// Err is the error returned by the destination, if any.
+	Err error
+}
+
+// metricsTee fans measurements out to several hooks.
+type metricsTee []MetricsHook
+
+// combineMetrics merges the non-nil hooks into a single MetricsHook.
+//
+// It returns nil when no hooks remain, preserving the fast path check.
+func combineMetrics(hooks ...MetricsHook) MetricsHook {
+	var tee metricsTee
+	for _, h := range hooks {
+		if h != nil {
+			tee = append(tee, h)
+		}
+	}
+	switch len(tee) {
+	case 0:
+		return nil
+	case 1:
+		return tee[0]
+	default:
+		return tee
+	}
+}
+
+func (t metricsTee) EntryLogged(level Level, formatLatency time.Duration) {
+	for _, h := range t {
+		h.EntryLogged(level, formatLatency)
+	}
+}
+
+func (t metricsTee) EntryDropped(level Level, reason DropReason) {
+	for _, h := range t {
+		h.EntryDropped(level, reason)
+	}
+}
+
+func (t metricsTee) BytesWritten(n int) {
+	for _, h := range t {
+		h.BytesWritten(n)
+	}
+}
+
+func (t metricsTee) WriteError(err error) {
+	for _, h := range t {
+		h.WriteError(err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"maps"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Once returns l the first time the calling line runs and a Logger that
+// discards every entry afterwards, so that hot code can report a condition
+// once per process:
+//
+//	logger.Once().Warn("falling back to the slow path")
+//
+// The state is kept per call site and shared by every Logger. Skipped entries
+// have no effect, including those at PanicLevel and FatalLevel.
+//
+// Performance Note: Finding the call site walks one stack frame, which costs
+// about as much as ReportCaller, and never allocates after the first call.
+func (l *Logger) Once() *Logger {
+	if l == nil {
+		return nil
+	}
+	if callSite(2).count.Add(1) == 1 {
+		return l
+	}
+	return _skipped
+}
+
+// EveryN returns l on the first and then every nth run of the calling line,
+// and a Logger that discards every entry otherwise. Like Once, the count is
+// kept per call site. An n below 2 returns l every time.
+func (l *Logger) EveryN(n int) *Logger {
+	if l == nil {
+		return nil
+	}
+	if n < 2 || (callSite(2).count.Add(1)-1)%uint64(n) == 0 {
+		return l
+	}
+	return _skipped
+}
+
+// Every returns l when the calling line runs for the first time or at least d
+// after it last returned l, and a Logger that discards every entry otherwise.
+// Like Once, the time is kept per call site; concurrent callers race for each
+// slot and exactly one of them wins it.
+func (l *Logger) Every(d time.Duration) *Logger {
+	if l == nil {
+		return nil
+	}
+	site := callSite(2)
+	now := time.Now().UnixNano()
+	next := site.next.Load()
+	if now >= next && site.next.CompareAndSwap(next, now+int64(d)) {
+		return l
+	}
+	return _skipped
+}
+
+// _skipped is the Logger returned to call sites whose turn has not come.
+var _skipped = Nop()
+
+// siteState is the state of a call site of Once, EveryN, or Every.
+type siteState struct {
+	count atomic.Uint64
+	next  atomic.Int64
+}
+
+// _sites maps program counters to their state. The map is replaced, never
+// modified, so lookups take no lock; call sites are few and added once.
+var (
+	_sites   atomic.Pointer[map[uintptr]*siteState]
+	_sitesMu sync.Mutex
+)
+
+// callSite returns the state of the call site skip frames above callSite.
+func callSite(skip int) *siteState {
+	var pcs [1]uintptr
+	runtime.Callers(skip+1, pcs[:])
+	pc := pcs[0]
+	if m := _sites.Load(); m != nil {
+		if s, ok := (*m)[pc]; ok {
+			return s
+		}
+	}
+
+	_sitesMu.Lock()
+	defer _sitesMu.Unlock()
+	old := _sites.Load()
+	if old != nil {
+		if s, ok := (*old)[pc]; ok {
+			return s
+		}
+	}
+	var m map[uintptr]*siteState
+	if old != nil {
+		m = maps.Clone(*old)
+	} else {
+		m = make(map[uintptr]*siteState)
+	}
+	s := new(siteState)
+	m[pc] = s
+	_sites.Store(&m)
+	return s
+}
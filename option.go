@@ -0,0 +1,228 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "time"
+
+// Option configures a Logger constructed by New.
+//
+// Options are applied in order to a zero Options value, so later Options win.
+// Libraries can expose partial configuration as a []Option without depending
+// on the full Options struct, and any func(*Options) can be converted into an
+// Option for settings without a dedicated constructor:
+//
+//	logger := velo.New(os.Stderr,
+//	  velo.WithLevel(velo.DebugLevel),
+//	  velo.WithFormatter(velo.JSONFormatter),
+//	  velo.Option(func(o *velo.Options) { o.SortFields = true }),
+//	)
+type Option func(*Options)
+
+// WithLevel sets the minimum logging priority.
+func WithLevel(level Level) Option {
+	return func(o *Options) { o.Level = level }
+}
+
+// WithFormatter selects how the Logger serializes entries.
+func WithFormatter(f Formatter) Option {
+	return func(o *Options) { o.Formatter = f }
+}
+
+// WithEncoder serializes entries with enc in place of the Formatter.
+func WithEncoder(enc EntryEncoder) Option {
+	return func(o *Options) { o.Encoder = enc }
+}
+
+// WithAsync routes entries through a background worker with a queue of
+// bufferSize entries, handling a full queue according to strategy.
+func WithAsync(bufferSize int, strategy OverflowStrategy) Option {
+	return func(o *Options) {
+		o.Async = true
+		o.BufferSize = bufferSize
+		o.OverflowStrategy = strategy
+	}
+}
+
+// WithSpill keeps the entries an asynchronous Logger drops or fails to write
+// in s. See Options.Spill.
+func WithSpill(s *SpillFile) Option {
+	return func(o *Options) { o.Spill = s }
+}
+
+// WithWriteTimeout bounds each write of an asynchronous Logger to a
+// DeadlineWriter destination by d. See Options.WriteTimeout.
+func WithWriteTimeout(d time.Duration) Option {
+	return func(o *Options) { o.WriteTimeout = d }
+}
+
+// WithTriggerBuffer retains up to size entries below the Logger's level and
+// writes them ahead of the next entry at level or above. See
+// Options.TriggerBuffer.
+func WithTriggerBuffer(size int, level Level) Option {
+	return func(o *Options) {
+		o.TriggerBuffer = size
+		o.TriggerLevel = &level
+	}
+}
+
+// WithTimeLocation converts timestamps and the values of Time and Times
+// fields to loc. See Options.TimeLocation.
+func WithTimeLocation(loc *time.Location) Option {
+	return func(o *Options) { o.TimeLocation = loc }
+}
+
+// WithDurationEncoding selects how durations are written. See
+// Options.DurationEncoding.
+func WithDurationEncoding(d DurationEncoding) Option {
+	return func(o *Options) { o.DurationEncoding = d }
+}
+
+// WithTimestamp includes a timestamp in every entry, using layout, or
+// DefaultTimeFormat when layout is empty.
+func WithTimestamp(layout string) Option {
+	return func(o *Options) {
+		o.ReportTimestamp = true
+		o.TimeFormat = layout
+	}
+}
+
+// WithCaller includes the calling file and line number in every entry.
+func WithCaller() Option {
+	return func(o *Options) { o.ReportCaller = true }
+}
+
+// WithStacktrace includes a stack trace for entries at ErrorLevel or higher.
+func WithStacktrace() Option {
+	return func(o *Options) { o.ReportStacktrace = true }
+}
+
+// WithMessagePrefix prepends a static string to every log message.
+func WithMessagePrefix(prefix string) Option {
+	return func(o *Options) { o.Prefix = prefix }
+}
+
+// WithDefaultFields attaches loosely typed key-value pairs to every entry,
+// after any added by earlier Options.
+func WithDefaultFields(keyvals ...any) Option {
+	return func(o *Options) { o.Fields = append(o.Fields, keyvals...) }
+}
+
+// WithHostInfo attaches the hostname, process ID, and service name and
+// version to every entry. An empty service or version defaults to the value
+// from the binary's build info. See Options.IncludeHostInfo.
+func WithHostInfo(service, version string) Option {
+	return func(o *Options) {
+		o.IncludeHostInfo = true
+		o.ServiceName = service
+		o.ServiceVersion = version
+	}
+}
+
+// WithStyles overrides the visual appearance of the TextFormatter.
+func WithStyles(s *Styles) Option {
+	return func(o *Options) { o.Styles = s }
+}
+
+// WithColor controls ANSI styling when no Styles are set.
+func WithColor(mode ColorMode) Option {
+	return func(o *Options) { o.Color = mode }
+}
+
+// WithClock replaces time.Now as the source of timestamps.
+func WithClock(c Clock) Option {
+	return func(o *Options) { o.Clock = c }
+}
+
+// WithContextExtractor sets the hook that pulls fields from a context.Context.
+func WithContextExtractor(extract ContextExtractor) Option {
+	return func(o *Options) { o.ContextExtractor = extract }
+}
+
+// WithFieldProvider adds a FieldProvider whose fields are computed for every
+// entry. It may be given more than once.
+func WithFieldProvider(p FieldProvider) Option {
+	return func(o *Options) { o.FieldProviders = append(o.FieldProviders, p) }
+}
+
+// WithDuplicateKeys sets how the JSONFormatter writes fields that share a
+// key. See DuplicateKeys.
+func WithDuplicateKeys(d DuplicateKeys) Option {
+	return func(o *Options) { o.DuplicateKeys = d }
+}
+
+// WithHooks appends hooks to the Logger's Hooks.
+func WithHooks(hooks ...Hook) Option {
+	return func(o *Options) { o.Hooks = append(o.Hooks, hooks...) }
+}
+
+// WithProcessors appends processors to the Logger's Processors.
+func WithProcessors(processors ...Processor) Option {
+	return func(o *Options) { o.Processors = append(o.Processors, processors...) }
+}
+
+// WithObserver sets the EntryObserver that receives every entry.
+func WithObserver(obs EntryObserver) Option {
+	return func(o *Options) { o.Observer = obs }
+}
+
+// WithOnWrite sets the callback that runs after every write to the
+// destination. See Options.OnWrite.
+func WithOnWrite(fn func(WriteStats)) Option {
+	return func(o *Options) { o.OnWrite = fn }
+}
+
+// WithName sets the dot-separated name of the Logger. See Options.Name.
+func WithName(name string) Option {
+	return func(o *Options) { o.Name = name }
+}
+
+// WithFatalBehavior sets what happens after a FatalLevel entry. See
+// Options.FatalBehavior.
+func WithFatalBehavior(b FatalBehavior) Option {
+	return func(o *Options) { o.FatalBehavior = b }
+}
+
+// WithFatalExitCode sets the exit status used after a FatalLevel entry.
+func WithFatalExitCode(code int) Option {
+	return func(o *Options) { o.FatalExitCode = code }
+}
+
+// WithSchema sets the Schema every entry is checked against.
+func WithSchema(s *Schema) Option {
+	return func(o *Options) { o.Schema = s }
+}
+
+// WithErrorHandler sets the function that receives problems the Logger finds
+// with its own entries. See Options.ErrorHandler.
+func WithErrorHandler(fn func(error)) Option {
+	return func(o *Options) { o.ErrorHandler = fn }
+}
+
+// WithFieldMetrics sets the FieldMetrics that receives Counter and Gauge
+// fields.
+func WithFieldMetrics(m FieldMetrics) Option {
+	return func(o *Options) { o.FieldMetrics = m }
+}
+
+// WithMetrics sets the hook that receives the Logger's health metrics.
+func WithMetrics(m MetricsHook) Option {
+	return func(o *Options) { o.Metrics = m }
+}
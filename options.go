@@ -22,9 +22,11 @@ package velo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -36,8 +38,46 @@ const (
 	TextFormatter Formatter = iota
 	// JSONFormatter serializes log entries as structured JSON.
 	JSONFormatter
+	// BinaryFormatter serializes log entries as compact, length-prefixed
+	// binary records, which a BinaryDecoder converts back to text or JSON.
+	BinaryFormatter
 )
 
+// String returns the lowercase ASCII representation of the formatter.
+func (f Formatter) String() string {
+	switch f {
+	case TextFormatter:
+		return "text"
+	case JSONFormatter:
+		return "json"
+	case BinaryFormatter:
+		return "binary"
+	default:
+		return fmt.Sprintf("Formatter(%d)", int(f))
+	}
+}
+
+// MarshalText serializes the Formatter to its lowercase name.
+func (f Formatter) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText deserializes "text", "json", or "binary", in any case, into a Formatter.
+// An empty string selects TextFormatter.
+func (f *Formatter) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "text", "":
+		*f = TextFormatter
+	case "json":
+		*f = JSONFormatter
+	case "binary":
+		*f = BinaryFormatter
+	default:
+		return fmt.Errorf("unrecognized formatter: %q", text)
+	}
+	return nil
+}
+
 // OverflowStrategy dictates how an asynchronous Logger behaves when its internal ring buffer fills up.
 type OverflowStrategy int
 
@@ -55,9 +95,107 @@ const (
 	OverflowBlock
 )
 
+// String returns the lowercase ASCII representation of the strategy.
+func (s OverflowStrategy) String() string {
+	switch s {
+	case OverflowSync:
+		return "sync"
+	case OverflowDrop:
+		return "drop"
+	case OverflowBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("OverflowStrategy(%d)", int(s))
+	}
+}
+
+// MarshalText serializes the OverflowStrategy to its lowercase name.
+func (s OverflowStrategy) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText deserializes "sync", "drop", or "block", in any case, into an
+// OverflowStrategy. An empty string selects OverflowSync.
+func (s *OverflowStrategy) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "sync", "":
+		*s = OverflowSync
+	case "drop":
+		*s = OverflowDrop
+	case "block":
+		*s = OverflowBlock
+	default:
+		return fmt.Errorf("unrecognized overflow strategy: %q", text)
+	}
+	return nil
+}
+
+// FatalBehavior dictates what a Logger does after writing a FatalLevel entry
+// and running Options.OnFatal.
+type FatalBehavior int
+
+const (
+	// FatalExit flushes every background worker and exits the process with
+	// Options.FatalExitCode, through Options.ExitFunc if it is set.
+	FatalExit FatalBehavior = iota
+	// FatalPanic flushes the Logger and panics with the message, as
+	// PanicLevel does. Deferred functions run, and recovering middleware can
+	// stop the panic.
+	FatalPanic
+	// FatalCallback flushes the Logger and returns, leaving Options.OnFatal
+	// as the only reaction. Use it when a service shuts down by canceling a
+	// root context rather than exiting on the spot.
+	FatalCallback
+)
+
+// String returns the lowercase ASCII representation of the behavior.
+func (b FatalBehavior) String() string {
+	switch b {
+	case FatalExit:
+		return "exit"
+	case FatalPanic:
+		return "panic"
+	case FatalCallback:
+		return "callback"
+	default:
+		return fmt.Sprintf("FatalBehavior(%d)", int(b))
+	}
+}
+
+// MarshalText serializes the FatalBehavior to its lowercase name.
+func (b FatalBehavior) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText deserializes "exit", "panic", or "callback", in any case, into
+// a FatalBehavior. An empty string selects FatalExit.
+func (b *FatalBehavior) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "exit", "":
+		*b = FatalExit
+	case "panic":
+		*b = FatalPanic
+	case "callback":
+		*b = FatalCallback
+	default:
+		return fmt.Errorf("unrecognized fatal behavior: %q", text)
+	}
+	return nil
+}
+
 // TimeFunction defines a custom hook for generating or modifying timestamps.
 type TimeFunction func(time.Time) time.Time
 
+// Clock supplies the current time to a Logger.
+//
+// Unlike TimeFunction, which adjusts a reading, a Clock replaces the time
+// source entirely. Readings from time.Now carry a monotonic component, so
+// implementations that wrap it preserve ordering across wall clock jumps. Use
+// a deterministic Clock in tests to make timestamps reproducible.
+type Clock interface {
+	Now() time.Time
+}
+
 // CallerFormatter defines a custom hook for formatting file and line number information.
 type CallerFormatter func(file string, line int, funcName string) string
 
@@ -71,6 +209,83 @@ func LongCallerFormatter(file string, line int, funcName string) string {
 	return fmt.Sprintf("%s:%d", file, line)
 }
 
+// MultilineMode controls how the TextFormatter renders messages and field
+// values that contain line breaks.
+type MultilineMode int
+
+const (
+	// MultilineRaw writes line breaks unchanged. This is the default.
+	MultilineRaw MultilineMode = iota
+	// MultilineEscape replaces line breaks with the two character sequences
+	// \n and \r, so every entry occupies exactly one line.
+	MultilineEscape
+	// MultilineIndent indents every continuation line, so only the first line
+	// of an entry starts in the first column. Stack traces are already
+	// indented and therefore stay visually attached to their entry.
+	MultilineIndent
+)
+
+// multilineIndent prefixes continuation lines under MultilineIndent.
+const multilineIndent = "    "
+
+// TextLayout configures column alignment and line handling for the TextFormatter.
+//
+// Widths are measured in terminal columns, ignoring ANSI escape sequences.
+// Padding is only inserted between elements, so lines never end in trailing
+// whitespace.
+type TextLayout struct {
+	// AlignLevels pads every level label to the width of the widest label, so
+	// the text after it starts in the same column regardless of level.
+	AlignLevels bool
+
+	// MessageWidth pads the message to at least this many columns before the
+	// first field begins. Longer messages are never cut.
+	MessageWidth int
+
+	// FieldWidth pads each key=value pair to at least this many columns, so
+	// keys repeated across lines start in the same column.
+	FieldWidth int
+
+	// Multiline selects how line breaks inside messages and values are
+	// rendered. It defaults to MultilineRaw.
+	Multiline MultilineMode
+
+	// MaxValueWidth cuts field values wider than this many columns, ending
+	// them with "…". It only affects the TextFormatter; JSON output always
+	// carries the full value. A value of zero disables the limit.
+	MaxValueWidth int
+
+	// MaxValueWidths overrides MaxValueWidth for specific keys. A width of
+	// zero exempts the key from the global limit.
+	MaxValueWidths map[string]int
+}
+
+// enabled reports whether t changes the output of the TextFormatter.
+func (t *TextLayout) enabled() bool {
+	return t.AlignLevels || t.MessageWidth > 0 || t.FieldWidth > 0 ||
+		t.Multiline != MultilineRaw || t.MaxValueWidth > 0 || len(t.MaxValueWidths) > 0
+}
+
+// FuncCallerFormatter returns the package qualified function name (e.g., "velo.(*Logger).Info").
+func FuncCallerFormatter(file string, line int, funcName string) string {
+	return shortFuncName(funcName)
+}
+
+// ShortFuncCallerFormatter returns the file name, line number, and function name
+// (e.g., "logger.go:42 velo.(*Logger).Info").
+func ShortFuncCallerFormatter(file string, line int, funcName string) string {
+	return fmt.Sprintf("%s:%d %s", filepath.Base(file), line, shortFuncName(funcName))
+}
+
+// shortFuncName strips the import path from a fully qualified function name,
+// keeping the package name.
+func shortFuncName(fn string) string {
+	if i := strings.LastIndexByte(fn, '/'); i >= 0 {
+		return fn[i+1:]
+	}
+	return fn
+}
+
 // ContextExtractor defines a custom hook for extracting strongly typed fields from a context.Context.
 type ContextExtractor func(context.Context) []Field
 
@@ -84,26 +299,90 @@ type Options struct {
 	Output io.Writer
 
 	// BufferSize defines the capacity of the internal ring buffer for asynchronous loggers.
-	// It must be a power of 2. It defaults to 8192.
+	// It is rounded up to a power of two, at most 1<<24, so that positions
+	// map to slots with a mask; NewWithOptionsE rejects larger values. It
+	// defaults to 8192.
 	BufferSize int
 
 	// OverflowStrategy dictates behavior when the asynchronous buffer fills up.
 	// It defaults to OverflowSync.
 	OverflowStrategy OverflowStrategy
 
+	// Spill, if set, keeps the entries an asynchronous Logger would otherwise
+	// lose: those OverflowDrop discards, and those the destination fails to
+	// accept. Write errors are still reported, but no longer stop the worker
+	// from writing later entries. It requires Async.
+	Spill *SpillFile
+
+	// WriteTimeout, when positive, bounds each write of an asynchronous
+	// Logger to a destination implementing DeadlineWriter, such as a
+	// net.Conn, so that a hung collector cannot stall the worker and, with
+	// OverflowBlock, the goroutines logging behind it. Entries a write fails
+	// to deliver in time go whole to Spill, if set, and are otherwise dropped;
+	// the timeout is reported like any write error. Other destinations ignore
+	// it.
+	// It requires Async.
+	WriteTimeout time.Duration
+
+	// TriggerBuffer, when positive, turns the Logger into a flight recorder:
+	// up to this many of the most recent entries below Level are formatted
+	// and kept in a ring instead of being discarded, and written ahead of the
+	// next entry at TriggerLevel or above. Failures then arrive with the
+	// debug output that led up to them, without shipping it all the time.
+	// The buffer is shared by the Logger and its children; NewTriggerContext
+	// gives a request its own. Enabled and Check still report levels below
+	// Level as disabled, so code they guard is not retained, and a Logger
+	// writing to a Core retains nothing.
+	// Performance Note: Retained entries cost as much to format as written ones.
+	TriggerBuffer int
+
+	// TriggerLevel, when set, is the level at which a TriggerBuffer is
+	// flushed, as in TriggerLevel: new(velo.WarnLevel). Entries below it, and
+	// below Level, are retained. It defaults to ErrorLevel.
+	TriggerLevel *Level
+
 	// ReportTimestamp includes a timestamp in every log entry.
 	ReportTimestamp bool
 
 	// TimeFormat specifies the layout string for timestamps.
-	// It defaults to DefaultTimeFormat.
+	// It defaults to DefaultTimeFormat. DefaultTimeFormatMilli and
+	// DefaultTimeFormatMicro add sub-second precision, and "unix",
+	// "unix_milli", "unix_micro", and "unix_nano" write the time since the
+	// Unix epoch as an integer, which JSON output leaves unquoted.
+	// Performance Note: These formats, time.RFC3339, and time.RFC3339Nano are
+	// rendered without allocating; other layouts use time.Time.AppendFormat.
 	TimeFormat string
 
+	// TimeLocation, when set, converts timestamps and the values of Time and
+	// Times fields to this location before they are written, such as
+	// time.UTC to keep every service's output in one zone. TimeFunction, if
+	// set, is applied after the conversion. By default timestamps are in the
+	// local zone and field values in the location they were constructed with.
+	TimeLocation *time.Location
+
+	// DurationEncoding selects how durations are written: as integer
+	// nanoseconds, fractional milliseconds or seconds, or strings such as
+	// "1.5s". It defaults to DurationDefault, which writes nanoseconds in
+	// JSON and strings in text.
+	DurationEncoding DurationEncoding
+
 	// TimeFunction provides a custom hook for generating timestamps.
 	// It defaults to time.Now.
 	TimeFunction TimeFunction
 
+	// Clock replaces time.Now as the source of timestamps. TimeFunction, if
+	// set, is applied to the Clock's reading.
+	Clock Clock
+
+	// ReportSequence stamps every entry with a monotonically increasing number
+	// under the SequenceKey field. The counter is shared by the Logger and all
+	// of its children, so ordering can be reconstructed after asynchronous
+	// batching or overflow writes.
+	ReportSequence bool
+
 	// ReportCaller includes the calling file and line number in every log entry.
-	// Performance Note: Enabling this incurs a significant performance penalty.
+	// Performance Note: Each call site is resolved once and cached, so every
+	// later entry costs one runtime.Callers.
 	ReportCaller bool
 
 	// CallerOffset adjusts the stack frame depth when identifying the caller.
@@ -111,29 +390,311 @@ type Options struct {
 	CallerOffset int
 
 	// CallerFormatter provides a custom hook for formatting caller information.
-	// It defaults to ShortCallerFormatter.
+	// It defaults to ShortCallerFormatter. Use FuncCallerFormatter or
+	// ShortFuncCallerFormatter to include the function name.
 	CallerFormatter CallerFormatter
 
+	// CallerObject makes the JSONFormatter emit the caller as a structured
+	// object, {"file":…,"line":…,"func":…}, instead of the string produced by
+	// CallerFormatter. The file is the absolute path.
+	CallerObject bool
+
 	// ReportStacktrace includes a full stack trace for entries at ErrorLevel or higher.
 	// Performance Note: Enabling this incurs a significant performance penalty on errors.
 	ReportStacktrace bool
 
+	// DumpGoroutines appends the stacks of all goroutines to PanicLevel and
+	// FatalLevel entries, which helps diagnose the deadlocks that often lead
+	// to them. The TextFormatter writes the dump after the entry; the
+	// JSONFormatter stores it under GoroutinesKey.
+	DumpGoroutines bool
+
+	// GoroutineDumpLimit caps the size of the goroutine dump in bytes. It
+	// defaults to DefaultGoroutineDumpLimit.
+	GoroutineDumpLimit int
+
+	// Development enables behavior meant for development builds: entries at
+	// DPanicLevel panic after being written, as PanicLevel entries do, and
+	// entries at WarnLevel or above always include the caller.
+	Development bool
+
+	// OnFatal runs after a FatalLevel entry has been written and before the
+	// process exits, so applications can flush traces and metrics or release
+	// resources. The Entry is pooled and must not be retained.
+	OnFatal func(e *Entry)
+
+	// ExitFunc replaces os.Exit as the function called with FatalExitCode
+	// after a FatalLevel entry. If it returns, as an interceptor in a test
+	// would, the Fatal call returns too. TinyGo and WebAssembly builds panic by
+	// default instead of exiting, so that a host embedding the module keeps
+	// running.
+	ExitFunc func(code int)
+
+	// FatalBehavior dictates what happens after a FatalLevel entry is
+	// written: exit, panic, or return after OnFatal. It defaults to FatalExit
+	// and applies to every logging method, including Fatal, Fatalf,
+	// FatalFields, and Log or LogContext at FatalLevel.
+	FatalBehavior FatalBehavior
+
+	// FatalExitCode is the process exit status used by FatalExit. It
+	// defaults to 1. Supervisors that restart on some statuses and not others
+	// can tell a fatal log entry apart from other failures this way.
+	FatalExitCode int
+
+	// StacktraceLevel, when set, captures stack traces for every entry at or
+	// above this level, regardless of its fields, when ReportStacktrace is
+	// enabled, as in StacktraceLevel: new(velo.WarnLevel). When nil, stack
+	// traces are captured at ErrorLevel or higher and for entries carrying an
+	// error field.
+	StacktraceLevel *Level
+
+	// StacktraceDepth limits the number of frames rendered in a stack trace.
+	// It defaults to DefaultStacktraceDepth.
+	StacktraceDepth int
+
+	// StackFrameFilter decides which frames appear in a stack trace. Frames it
+	// rejects do not count toward StacktraceDepth. It defaults to
+	// DefaultFrameFilter.
+	StackFrameFilter FrameFilter
+
 	// Prefix prepends a static string to every log message.
 	Prefix string
 
+	// Name is the dot-separated name of the component the Logger belongs to,
+	// such as "db.pool", written under NameKey ahead of the other fields.
+	// Unlike Prefix, which is a label for people, the name is meant for
+	// machines: filters, Cores, and the Reloader can route on it. Named
+	// extends it for child Loggers.
+	Name string
+
+	// MaxMessageBytes caps the length of the log message in raw bytes,
+	// before escaping and excluding TruncationMarker. Longer messages are cut
+	// at a UTF-8 boundary, suffixed with TruncationMarker, and the original
+	// length is attached under the TruncatedMessageKey field. A value of zero
+	// disables the limit.
+	MaxMessageBytes int
+
+	// MaxEntryBytes caps the encoded size of an entry. Fields that would take
+	// the entry past it are dropped as they are formatted, before the entry is
+	// written, and the number dropped is attached under the
+	// TruncatedFieldsKey field. Fields attached with With and WithFields
+	// count like any other, and an ObjectMarshaler or ArrayMarshaler stops
+	// being encoded as soon as it passes the limit. The time, level, and
+	// message are always kept; bound the message with MaxMessageBytes. Stack
+	// traces and goroutine dumps are not counted. A value of zero disables
+	// the limit.
+	MaxEntryBytes int
+
 	// Fields attaches default, loosely typed key-value pairs to every log entry.
 	Fields []any
 
+	// IncludeHostInfo attaches the hostname, process ID, and service name and
+	// version to every log entry, under HostnameKey, PIDKey, ServiceKey, and
+	// ServiceVersionKey, ahead of Fields. The fields are computed once and,
+	// like Fields, pre-encoded for the JSONFormatter.
+	IncludeHostInfo bool
+
+	// ServiceName and ServiceVersion override the service name and version
+	// attached by IncludeHostInfo. They default to the main module's name and
+	// version from the binary's build info.
+	ServiceName    string
+	ServiceVersion string
+
+	// SortFields emits the fields of every entry in ascending key order,
+	// making output byte-for-byte reproducible regardless of how fields were
+	// attached. The header (time, level, caller, prefix, msg, and seq) keeps its
+	// fixed position. Without SortFields, fields appear in a stable order:
+	// Logger fields first, then context fields, then the fields of the call.
+	// Performance Note: Sorting routes every call through the Entry path.
+	SortFields bool
+
+	// DuplicateKeys dictates how the JSONFormatter writes fields that share a
+	// key. It defaults to DuplicateKeysAllow, which writes them all.
+	// Performance Note: Any other policy routes every JSON call through the
+	// Entry path. Entries with up to 16 fields are deduplicated without
+	// allocating.
+	DuplicateKeys DuplicateKeys
+
+	// Styles overrides the visual appearance of the TextFormatter for this
+	// Logger. It defaults to the global styles set by SetDefaultStyles.
+	Styles *Styles
+
+	// LevelLabels overrides the level names rendered by the TextFormatter,
+	// for example {WarnLevel: "WRN", ErrorLevel: "ERR"}. The labels are applied
+	// on top of Styles, or on top of the current global styles when Styles is
+	// unset, so later calls to SetDefaultStyles no longer affect this Logger.
+	LevelLabels map[Level]string
+
+	// TextLayout aligns the columns of the TextFormatter so interleaved output
+	// from several components stays scannable. The zero value disables all
+	// padding.
+	TextLayout TextLayout
+
+	// Color controls ANSI styling when Styles is unset. With the default
+	// ColorAuto, the Logger falls back to PlainStyles whenever the writer is
+	// not a terminal, such as a file, a pipe, or container log capture.
+	Color ColorMode
+
 	// Formatter dictates how the Logger serializes entries (e.g., TextFormatter or JSONFormatter).
 	// It defaults to TextFormatter.
 	Formatter Formatter
 
+	// Encoder, when set, serializes entries in place of Formatter. See
+	// EntryEncoder.
+	Encoder EntryEncoder
+
 	// ContextExtractor provides a custom hook to pull fields from a context.Context.
 	ContextExtractor ContextExtractor
 
+	// FieldProviders compute fields for every entry at the moment it is
+	// logged, after the level and sampling checks pass. See FieldProvider.
+	FieldProviders []FieldProvider
+
+	// Hooks inspect, enrich, or veto every entry before it is formatted. See
+	// Hook for details. Setting any hook routes all calls through the Entry path.
+	Hooks []Hook
+
+	// Processors form a pipeline that every entry passes through after its
+	// Hooks and before it is observed and formatted. See Processor for
+	// details. Setting any processor routes all calls through the Entry path.
+	Processors []Processor
+
+	// Observer receives every Entry the Logger writes, before it is formatted.
+	// Setting an Observer routes all calls through the Entry path.
+	Observer EntryObserver
+
+	// Metrics receives counters and timings describing the Logger's own health,
+	// such as entries per level, dropped entries, and write errors.
+	Metrics MetricsHook
+
+	// FieldMetrics receives the values of the Counter and Gauge fields passed
+	// to the typed logging methods, such as LogFields and InfoFields, for
+	// every entry at or above Level, including entries that are then
+	// sampled out. Fields attached with WithFields are not forwarded.
+	FieldMetrics FieldMetrics
+
+	// OnWrite runs after every write to the destination, synchronously on the
+	// logging goroutine or, with Async, on the background worker after each
+	// batch is flushed. Use it for byte accounting, quota enforcement, or
+	// tracing how much output each component generates. Like Metrics, it must
+	// be safe for concurrent use and return quickly. A background worker keeps
+	// the OnWrite of the Logger that created it. It is not called for entries
+	// written by a Core.
+	OnWrite func(WriteStats)
+
+	// Schema, when set, checks the keys and field types of every entry and
+	// reports mismatches to ErrorHandler. See Schema.
+	Schema *Schema
+
+	// ErrorHandler receives the problems the Logger finds with its own
+	// entries, such as Schema violations, and the errors of the Async
+	// worker's writes, none of which can be returned to the caller. A write
+	// error repeating the previous one is not reported again. It must be safe
+	// for concurrent use. It defaults to writing the error to os.Stderr.
+	ErrorHandler func(error)
+
+	// PublishExpvar publishes entry, drop, and worker counters under a "velo"
+	// map on the expvar package (served at /debug/vars). It composes with Metrics.
+	PublishExpvar bool
+
+	// Core, when set, receives every entry instead of the Logger's own
+	// formatter and writer, which are ignored along with Async. See Core.
+	Core Core
+
 	// Async enables the background worker, routing logs through a lock free ring buffer.
 	Async bool
 }
 
+// SequenceKey is the field key used by Options.ReportSequence.
+const SequenceKey = "seq"
+
+// NameKey is the field key holding the name of a Logger. See Logger.Named.
+const NameKey = "logger"
+
+// TruncationMarker is appended to messages shortened by Options.MaxMessageBytes.
+const TruncationMarker = "...[truncated]"
+
+// TruncatedMessageKey is the field key holding the original byte length of a truncated message.
+const TruncatedMessageKey = "msg_len"
+
+// TruncatedFieldsKey is the field key holding the number of fields dropped
+// by Options.MaxEntryBytes.
+const TruncatedFieldsKey = "fields_dropped"
+
 // DefaultTimeFormat specifies the standard timestamp layout used when no custom format is provided.
 const DefaultTimeFormat = "2006/01/02 15:04:05"
+
+// DefaultTimeFormatMilli is DefaultTimeFormat with millisecond precision.
+const DefaultTimeFormatMilli = "2006/01/02 15:04:05.000"
+
+// DefaultTimeFormatMicro is DefaultTimeFormat with microsecond precision.
+const DefaultTimeFormatMicro = "2006/01/02 15:04:05.000000"
+
+// normalize validates o and fills in defaults, rounding BufferSize up to the
+// next power of two. It returns the first problem found.
+func (o *Options) normalize() error {
+	switch {
+	case o.Level < DebugLevel || o.Level > FatalLevel:
+		return fmt.Errorf("velo: invalid Level %v", o.Level)
+	case o.Formatter < TextFormatter || o.Formatter > BinaryFormatter:
+		return fmt.Errorf("velo: invalid Formatter %v", o.Formatter)
+	case o.OverflowStrategy < OverflowSync || o.OverflowStrategy > OverflowBlock:
+		return fmt.Errorf("velo: invalid OverflowStrategy %v", o.OverflowStrategy)
+	case o.DuplicateKeys < DuplicateKeysAllow || o.DuplicateKeys > DuplicateKeysSuffix:
+		return fmt.Errorf("velo: invalid DuplicateKeys %v", o.DuplicateKeys)
+	case o.DurationEncoding < DurationDefault || o.DurationEncoding > DurationString:
+		return fmt.Errorf("velo: invalid DurationEncoding %v", o.DurationEncoding)
+	case o.FatalBehavior < FatalExit || o.FatalBehavior > FatalCallback:
+		return fmt.Errorf("velo: invalid FatalBehavior %v", o.FatalBehavior)
+	case o.FatalExitCode < 0 || o.FatalExitCode > 255:
+		return fmt.Errorf("velo: FatalExitCode %d is outside 0 to 255", o.FatalExitCode)
+	case o.Color < ColorAuto || o.Color > ColorAlways:
+		return fmt.Errorf("velo: invalid Color %v", o.Color)
+	case o.TextLayout.Multiline < MultilineRaw || o.TextLayout.Multiline > MultilineIndent:
+		return fmt.Errorf("velo: invalid TextLayout.Multiline %d", o.TextLayout.Multiline)
+	case o.StacktraceLevel != nil && (*o.StacktraceLevel < DebugLevel || *o.StacktraceLevel > FatalLevel):
+		return fmt.Errorf("velo: invalid StacktraceLevel %v", *o.StacktraceLevel)
+	case o.TriggerLevel != nil && (*o.TriggerLevel < DebugLevel || *o.TriggerLevel > FatalLevel):
+		return fmt.Errorf("velo: invalid TriggerLevel %v", *o.TriggerLevel)
+	case o.TriggerBuffer < 0:
+		return errors.New("velo: TriggerBuffer must not be negative")
+	case o.BufferSize < 0 || o.BufferSize > _maxBufferSize:
+		return fmt.Errorf("velo: BufferSize %d is outside 0 to %d", o.BufferSize, _maxBufferSize)
+	case o.CallerOffset < 0:
+		return errors.New("velo: CallerOffset must not be negative")
+	case o.MaxMessageBytes < 0:
+		return errors.New("velo: MaxMessageBytes must not be negative")
+	case o.Spill != nil && !o.Async:
+		return errors.New("velo: Spill requires Async")
+	case o.WriteTimeout < 0:
+		return errors.New("velo: WriteTimeout must not be negative")
+	case o.WriteTimeout > 0 && !o.Async:
+		return errors.New("velo: WriteTimeout requires Async")
+	case o.MaxEntryBytes < 0:
+		return errors.New("velo: MaxEntryBytes must not be negative")
+	case o.StacktraceDepth < 0:
+		return errors.New("velo: StacktraceDepth must not be negative")
+	case o.GoroutineDumpLimit < 0:
+		return errors.New("velo: GoroutineDumpLimit must not be negative")
+	case o.TextLayout.MessageWidth < 0 || o.TextLayout.FieldWidth < 0 || o.TextLayout.MaxValueWidth < 0:
+		return errors.New("velo: TextLayout widths must not be negative")
+	case len(o.Fields)%2 != 0:
+		return fmt.Errorf("velo: Fields has an odd number of elements; %v is missing a value", o.Fields[len(o.Fields)-1])
+	}
+	for i := 0; i < len(o.Fields); i += 2 {
+		if _, ok := o.Fields[i].(string); !ok {
+			return fmt.Errorf("velo: Fields key %v at index %d is not a string", o.Fields[i], i)
+		}
+	}
+	for key, width := range o.TextLayout.MaxValueWidths {
+		if width < 0 {
+			return fmt.Errorf("velo: TextLayout.MaxValueWidths[%q] must not be negative", key)
+		}
+	}
+
+	if o.FatalExitCode == 0 {
+		o.FatalExitCode = 1
+	}
+	o.BufferSize = ringSize(o.BufferSize)
+	return nil
+}
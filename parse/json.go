@@ -0,0 +1,183 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package parse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"velo"
+)
+
+// JSON parses a line written by the JSONFormatter into an Entry.
+//
+// The time, level, caller, prefix, msg, seq, and goroutines keys fill the
+// matching Entry fields; every other key becomes a field, in order. The
+// first occurrence of a reserved key wins, and later ones are kept as fields.
+// Objects and arrays are decoded into map[string]any and []any values.
+func JSON(line []byte, o Options) (*velo.Entry, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	if tok, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	} else if tok != json.Delim('{') {
+		return nil, fmt.Errorf("parse: line is not a JSON object")
+	}
+
+	e := &velo.Entry{Formatter: velo.JSONFormatter, TimeFormat: o.timeFormat()}
+	var seen struct{ time, level, caller, prefix, msg, seq, goroutines bool }
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("parse: %w", err)
+		}
+		key := tok.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("parse: value of %q: %w", key, err)
+		}
+
+		switch {
+		case key == "time" && !seen.time:
+			seen.time = true
+			t, err := o.jsonTime(raw)
+			if err != nil {
+				return nil, fmt.Errorf("parse: time: %w", err)
+			}
+			e.Time = t
+		case key == "level" && !seen.level:
+			seen.level = true
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return nil, fmt.Errorf("parse: level: %w", err)
+			}
+			l, err := velo.ParseLevel(s)
+			if err != nil {
+				return nil, fmt.Errorf("parse: %w", err)
+			}
+			e.Level = l
+		case key == "caller" && !seen.caller:
+			seen.caller = true
+			if err := jsonCaller(e, raw); err != nil {
+				return nil, fmt.Errorf("parse: caller: %w", err)
+			}
+		case key == "prefix" && !seen.prefix:
+			seen.prefix = true
+			if err := json.Unmarshal(raw, &e.Prefix); err != nil {
+				return nil, fmt.Errorf("parse: prefix: %w", err)
+			}
+		case key == "msg" && !seen.msg:
+			seen.msg = true
+			if err := json.Unmarshal(raw, &e.Message); err != nil {
+				return nil, fmt.Errorf("parse: msg: %w", err)
+			}
+		case key == velo.SequenceKey && !seen.seq:
+			seen.seq = true
+			if err := json.Unmarshal(raw, &e.Sequence); err != nil {
+				return nil, fmt.Errorf("parse: %s: %w", velo.SequenceKey, err)
+			}
+		case key == velo.GoroutinesKey && !seen.goroutines:
+			seen.goroutines = true
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return nil, fmt.Errorf("parse: %s: %w", velo.GoroutinesKey, err)
+			}
+			e.Goroutines = []byte(s)
+		default:
+			f, err := jsonField(key, raw)
+			if err != nil {
+				return nil, fmt.Errorf("parse: value of %q: %w", key, err)
+			}
+			e.TypedFields = append(e.TypedFields, f)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	return e, nil
+}
+
+// jsonTime decodes a timestamp written in the TimeFormat of o.
+func (o *Options) jsonTime(raw json.RawMessage) (time.Time, error) {
+	if len(raw) > 0 && raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return time.Time{}, err
+		}
+		return o.parseTime(s)
+	}
+	return o.parseTime(string(raw))
+}
+
+// jsonCaller fills the caller of e from either a "file:line" string or the
+// object written under Options.CallerObject.
+func jsonCaller(e *velo.Entry, raw json.RawMessage) error {
+	if len(raw) > 0 && raw[0] == '{' {
+		var c struct {
+			File string `json:"file"`
+			Line int    `json:"line"`
+			Func string `json:"func"`
+		}
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return err
+		}
+		e.CallerFile, e.CallerLine, e.CallerFunc = c.File, c.Line, c.Func
+		e.Caller = c.File + ":" + strconv.Itoa(c.Line)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return err
+	}
+	parseCaller(e, s)
+	return nil
+}
+
+// jsonField converts a JSON value into a Field.
+func jsonField(key string, raw json.RawMessage) (velo.Field, error) {
+	switch raw[0] {
+	case '"':
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return velo.String(key, s), err
+	case 't', 'f':
+		var b bool
+		err := json.Unmarshal(raw, &b)
+		return velo.Bool(key, b), err
+	case 'n':
+		return velo.Any(key, nil), nil
+	case '{', '[':
+		var v any
+		err := json.Unmarshal(raw, &v)
+		return velo.Any(key, v), err
+	default:
+		if n, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+			return velo.Int64(key, n), nil
+		}
+		if u, err := strconv.ParseUint(string(raw), 10, 64); err == nil {
+			return velo.Any(key, u), nil
+		}
+		f, err := strconv.ParseFloat(string(raw), 64)
+		return velo.Any(key, f), err
+	}
+}
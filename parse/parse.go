@@ -0,0 +1,122 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package parse reads velo output back into Entry values.
+//
+// JSON parses lines written by the JSONFormatter and Text parses lines written
+// by the TextFormatter, or any logfmt style line that follows the same
+// layout. Line picks between the two. Options describes the Logger that wrote
+// the output, such as its TimeFormat and Styles, so that custom layouts parse
+// as reliably as the defaults.
+//
+// Parsed entries are not pooled; callers own them. Fields are strongly typed
+// where the value allows it: integers become IntType fields, booleans
+// BoolType fields, and everything else keeps its textual or JSON form.
+package parse
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"velo"
+)
+
+// Options describes how the Logger that wrote the output was configured.
+type Options struct {
+	// TimeFormat is the layout of timestamps: a time package layout, "unix",
+	// "unix_milli", "unix_micro", or "unix_nano". It defaults to
+	// velo.DefaultTimeFormat. Timestamps without a zone are read in the local
+	// time zone.
+	TimeFormat string
+
+	// Prefix is the Options.Prefix of the Logger. Text output only carries
+	// the prefix as the start of the message, so it is recognized only when
+	// set here.
+	Prefix string
+
+	// Styles are the Styles of the Logger, consulted by Text for level
+	// labels and field delimiters. They default to velo.PlainStyles. Colors
+	// are ignored; ANSI escape sequences are stripped before parsing.
+	Styles *velo.Styles
+}
+
+// Line parses a line written by either formatter, treating lines that begin
+// with '{' as JSON.
+func Line(line []byte, o Options) (*velo.Entry, error) {
+	if trimmed := bytes.TrimLeft(line, " \t"); len(trimmed) > 0 && trimmed[0] == '{' {
+		return JSON(line, o)
+	}
+	return Text(line, o)
+}
+
+func (o *Options) timeFormat() string {
+	if o.TimeFormat == "" {
+		return velo.DefaultTimeFormat
+	}
+	return o.TimeFormat
+}
+
+// parseTime reads a timestamp rendered in the layout of o.
+func (o *Options) parseTime(s string) (time.Time, error) {
+	switch layout := o.timeFormat(); layout {
+	case "unix":
+		n, err := strconv.ParseInt(s, 10, 64)
+		return time.Unix(n, 0), err
+	case "unix_milli":
+		n, err := strconv.ParseInt(s, 10, 64)
+		return time.UnixMilli(n), err
+	case "unix_micro":
+		n, err := strconv.ParseInt(s, 10, 64)
+		return time.UnixMicro(n), err
+	case "unix_nano":
+		n, err := strconv.ParseInt(s, 10, 64)
+		return time.Unix(0, n), err
+	default:
+		return time.ParseInLocation(layout, s, time.Local)
+	}
+}
+
+// textField converts a value rendered as text into the most specific Field
+// that renders back to the same text.
+func textField(key, val string) velo.Field {
+	switch val {
+	case "true":
+		return velo.Bool(key, true)
+	case "false":
+		return velo.Bool(key, false)
+	}
+	if n, err := strconv.ParseInt(val, 10, 64); err == nil && strconv.FormatInt(n, 10) == val {
+		return velo.Int64(key, n)
+	}
+	return velo.String(key, val)
+}
+
+// parseCaller splits a "file:line" caller into its parts. It leaves line at
+// zero for callers in other shapes, such as function names.
+func parseCaller(e *velo.Entry, caller string) {
+	e.Caller = caller
+	if i := strings.LastIndexByte(caller, ':'); i >= 0 {
+		if n, err := strconv.Atoi(caller[i+1:]); err == nil {
+			e.CallerFile, e.CallerLine = caller[:i], n
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package parse
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"velo"
+)
+
+// logSamples logs the same entries to l wherever it is called from, so that
+// the output can be compared with a replay through another Logger.
+func logSamples(l *velo.Logger) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	l.LogAt(at, velo.InfoLevel, "started", velo.String("user", "alice"), velo.Int("attempt", -3), velo.Bool("ok", true))
+	l.LogAt(at, velo.WarnLevel, "slow request", velo.String("path", "/a b"), velo.Duration("elapsed", 1500*time.Millisecond))
+	l.LogAt(at, velo.ErrorLevel, "failed", velo.Any("tags", []string{"a", "b"}), velo.Any("meta", map[string]any{"n": 1}))
+	l.LogAt(at, velo.DebugLevel, "no fields")
+}
+
+// roundTrip logs the samples with opts, parses every line with parse, writes
+// the parsed entries through a Logger with the same opts, and compares the
+// two outputs.
+func roundTrip(t *testing.T, parse func([]byte, Options) (*velo.Entry, error), o Options, opts ...velo.Option) {
+	t.Helper()
+	opts = append([]velo.Option{velo.WithLevel(velo.DebugLevel), velo.WithCaller(), velo.WithMessagePrefix("svc")}, opts...)
+	var want bytes.Buffer
+	logSamples(velo.New(&want, opts...))
+
+	var got bytes.Buffer
+	core := velo.NewCore(&got, opts...)
+	for line := range bytes.Lines(want.Bytes()) {
+		e, err := parse(line, o)
+		if err != nil {
+			t.Fatalf("parsing %q: %v", line, err)
+		}
+		if err := core.Write(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got.String() != want.String() {
+		t.Errorf("parsed and rewritten\n%s\nwant\n%s", got.String(), want.String())
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	roundTrip(t, JSON, Options{TimeFormat: time.RFC3339, Prefix: "svc"},
+		velo.WithFormatter(velo.JSONFormatter), velo.WithTimestamp(time.RFC3339), velo.WithTimeLocation(time.UTC))
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	roundTrip(t, Text, Options{TimeFormat: time.DateTime, Prefix: "svc"},
+		velo.WithFormatter(velo.TextFormatter), velo.WithTimestamp(time.DateTime), velo.WithTimeLocation(time.Local))
+}
+
+func TestLine(t *testing.T) {
+	for _, line := range []string{
+		`{"level":"warn","msg":"hello","n":1}`,
+		`WARN hello n=1`,
+	} {
+		e, err := Line([]byte(line), Options{})
+		if err != nil {
+			t.Fatalf("Line(%q): %v", line, err)
+		}
+		if e.Level != velo.WarnLevel || e.Message != "hello" || len(e.TypedFields) != 1 || e.TypedFields[0].Int != 1 {
+			t.Errorf("Line(%q) = level %v, message %q, fields %v", line, e.Level, e.Message, e.TypedFields)
+		}
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	for _, line := range []string{
+		``,
+		`[1]`,
+		`{"level":"loud"}`,
+		`{"time":"yesterday"}`,
+		`{"msg":"unterminated"`,
+	} {
+		if _, err := JSON([]byte(line), Options{}); err == nil {
+			t.Errorf("JSON(%q) succeeded", line)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package parse
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"velo"
+)
+
+// Text parses a line written by the TextFormatter into an Entry.
+//
+// The line is read as an optional timestamp, level label, <caller>, and
+// prefix, followed by the message and its key=value fields. Text output does
+// not escape messages, so a message that itself ends in key=value pairs is
+// indistinguishable from one with fields; Text takes the longest run of
+// well formed pairs at the end of the line as the fields. Lines without a
+// recognized level label parse as InfoLevel, and values spanning several
+// lines under TextLayout.Multiline are not supported.
+func Text(line []byte, o Options) (*velo.Entry, error) {
+	st := o.Styles
+	if st == nil {
+		st = velo.PlainStyles()
+	}
+	rest := stripANSI(string(bytes.TrimRight(line, "\r\n")))
+	if strings.ContainsAny(rest, "\r\n") {
+		return nil, fmt.Errorf("parse: multiline text entries are not supported")
+	}
+
+	e := &velo.Entry{Formatter: velo.TextFormatter, TimeFormat: o.timeFormat(), Level: velo.InfoLevel}
+
+	// The layout determines how many words the timestamp spans.
+	if n := strings.Count(o.timeFormat(), " ") + 1; strings.Count(rest, " ") >= n-1 {
+		words := strings.SplitN(rest, " ", n+1)
+		if t, err := o.parseTime(strings.Join(words[:n], " ")); err == nil {
+			e.Time = t
+			rest = strings.Join(words[n:], " ")
+		}
+	}
+
+	for _, l := range levelLabels(st) {
+		if after, ok := strings.CutPrefix(rest, l.label); ok && (after == "" || after[0] == ' ') {
+			e.Level = l.level
+			rest = strings.TrimLeft(after, " ")
+			break
+		}
+	}
+
+	if strings.HasPrefix(rest, "<") {
+		if i := strings.Index(rest, "> "); i > 0 {
+			parseCaller(e, rest[1:i])
+			rest = rest[i+2:]
+		} else if strings.HasSuffix(rest, ">") {
+			parseCaller(e, rest[1:len(rest)-1])
+			rest = ""
+		}
+	}
+
+	if o.Prefix != "" {
+		if after, ok := strings.CutPrefix(rest, o.Prefix+":"); ok && (after == "" || after[0] == ' ') {
+			e.Prefix = o.Prefix
+			rest = strings.TrimPrefix(after, " ")
+		}
+	}
+
+	e.Message, e.TypedFields = splitFields(rest, st)
+	for i, f := range e.TypedFields {
+		if f.Key == velo.SequenceKey && f.Type == velo.IntType && f.Int > 0 {
+			e.Sequence = uint64(f.Int)
+			e.TypedFields = slices.Delete(e.TypedFields, i, i+1)
+			break
+		}
+	}
+	return e, nil
+}
+
+// levelLabel pairs a level with the plain text of its label.
+type levelLabel struct {
+	label string
+	level velo.Level
+}
+
+// levelLabels returns the labels of st, longest first so that a label that
+// prefixes another does not shadow it.
+func levelLabels(st *velo.Styles) []levelLabel {
+	labels := make([]levelLabel, 0, len(st.Levels)+len(st.LevelLabels))
+	add := func(level velo.Level, label string) {
+		if label = stripANSI(label); label != "" {
+			labels = append(labels, levelLabel{label, level})
+		}
+	}
+	if st.CachedLevelStrings != nil {
+		for l, label := range st.CachedLevelStrings {
+			add(l, label)
+		}
+	} else {
+		for l, s := range st.Levels {
+			if label, ok := st.LevelLabels[l]; ok {
+				s = s.SetString(label)
+			}
+			add(l, s.String())
+		}
+	}
+	slices.SortFunc(labels, func(a, b levelLabel) int {
+		return cmp.Or(cmp.Compare(len(b.label), len(a.label)), cmp.Compare(a.label, b.label))
+	})
+	return labels
+}
+
+// splitFields separates the message from the fields that follow it, taking
+// the earliest split after which the rest of s parses as fields.
+func splitFields(s string, st *velo.Styles) (string, []velo.Field) {
+	open := st.FieldsOpen
+	for i := 0; i <= len(s); i++ {
+		// The first field is preceded by a space and FieldsOpen, or only by
+		// FieldsOpen when the message is empty.
+		var tail string
+		switch {
+		case i == 0 && strings.HasPrefix(s, open):
+			tail = s[len(open):]
+		case i > 0 && s[i-1] == ' ' && strings.HasPrefix(s[i:], open):
+			tail = s[i+len(open):]
+		default:
+			continue
+		}
+		if fields, ok := parseFields(tail, st); ok {
+			// TextLayout.MessageWidth pads the message with spaces.
+			return strings.TrimRight(s[:max(i-1, 0)], " "), fields
+		}
+	}
+	return s, nil
+}
+
+// parseFields parses s as a complete list of fields, closed by FieldsClose.
+func parseFields(s string, st *velo.Styles) ([]velo.Field, bool) {
+	if st.FieldsClose != "" {
+		var ok bool
+		if s, ok = strings.CutSuffix(s, st.FieldsClose); !ok {
+			return nil, false
+		}
+	}
+	sep := st.FieldSeparator
+	if sep == "" {
+		sep = " "
+	}
+	kvSep := st.KeyValueSeparator
+	if kvSep == "" {
+		kvSep = "="
+	}
+
+	var fields []velo.Field
+	for {
+		i := strings.Index(s, kvSep)
+		if i <= 0 || strings.ContainsAny(s[:i], " \"") || (strings.TrimSpace(sep) != "" && strings.Contains(s[:i], strings.TrimSpace(sep))) {
+			return nil, false
+		}
+		key := s[:i]
+		s = s[i+len(kvSep):]
+
+		var val string
+		if strings.HasPrefix(s, `"`) {
+			// Quoted values are not escaped, so the value ends at the first
+			// quote that is followed by a separator or the end of the line.
+			end := -1
+			for j := 1; j < len(s); j++ {
+				if s[j] == '"' && (j == len(s)-1 || strings.HasPrefix(s[j+1:], sep)) {
+					end = j
+					break
+				}
+			}
+			if end < 0 {
+				return nil, false
+			}
+			val, s = s[1:end], s[end+1:]
+		} else if j := strings.Index(s, sep); j >= 0 {
+			val, s = s[:j], s[j:]
+		} else {
+			val, s = s, ""
+		}
+
+		fields = append(fields, textField(key, val))
+		if s == "" {
+			return fields, true
+		}
+		if s, _ = strings.CutPrefix(s, sep); s == "" {
+			return nil, false
+		}
+	}
+}
+
+// stripANSI removes ANSI escape sequences from s.
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b[") {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[' {
+			// Skip parameters up to the final byte of the sequence.
+			j := i + 2
+			for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+				j++
+			}
+			i = j
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
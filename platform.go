@@ -0,0 +1,47 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build !(tinygo || wasm)
+
+package velo
+
+import (
+	"os"
+	"runtime/debug"
+
+	"github.com/mattn/go-isatty"
+	"golang.org/x/sys/cpu"
+)
+
+// cacheLinePad separates hot atomic fields so that goroutines on different
+// cores do not contend for the same cache line.
+type cacheLinePad = cpu.CacheLinePad
+
+// isTerminalFile reports whether f is attached to a terminal.
+func isTerminalFile(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// exit ends the process after a FatalLevel entry.
+func exit(code int) { os.Exit(code) }
+
+// setCrashOutput directs the runtime's crash reports to f as well as standard
+// error. A nil f stops the copy.
+func setCrashOutput(f *os.File) error { return debug.SetCrashOutput(f, debug.CrashOptions{}) }
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build tinygo || wasm
+
+package velo
+
+import (
+	"errors"
+	"os"
+	"strconv"
+)
+
+// cacheLinePad is empty on TinyGo and WebAssembly, which run goroutines on a
+// single thread and gain nothing from padding but its size.
+type cacheLinePad struct{}
+
+// isTerminalFile reports false: output from edge workers and embedded targets
+// goes to a host console that does not interpret ANSI escape sequences.
+func isTerminalFile(*os.File) bool { return false }
+
+// exit panics instead of ending the process after a FatalLevel entry. On these
+// targets the module usually runs inside a host, such as an edge worker, where
+// exiting would tear down the runtime for every request rather than the one
+// that failed. Set Options.ExitFunc to choose another behavior.
+func exit(code int) { panic("velo: fatal log entry, exit status " + strconv.Itoa(code)) }
+
+// setCrashOutput reports an error: these targets have no runtime crash output
+// to redirect.
+func setCrashOutput(*os.File) error {
+	return errors.New("velo: crash files are not supported on this platform")
+}
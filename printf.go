@@ -0,0 +1,144 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// appendf appends the message described by format and args to b, producing
+// the same text as fmt.Appendf.
+//
+// Performance Note: Formats using only the verbs %s, %v, %d, %q, %x, %t, and
+// %%, without flags, widths, or precisions, on strings, byte slices,
+// booleans, integers, and floats are written directly onto b without
+// reflection. Any other format is handed to fmt.Appendf, which also appends
+// without allocating beyond what the arguments' own methods do.
+func appendf(b []byte, format string, args []any) []byte {
+	start := len(b)
+	if out, ok := appendfFast(b, format, args); ok {
+		return out
+	}
+	return fmt.Appendf(b[:start], format, args...)
+}
+
+// appendfFast implements the fast path of appendf. It reports false, leaving
+// garbage after the original length of b, if the format needs fmt.
+func appendfFast(b []byte, format string, args []any) ([]byte, bool) {
+	n := 0
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' {
+			b = append(b, c)
+			continue
+		}
+		i++
+		if i == len(format) {
+			return b, false
+		}
+		verb := format[i]
+		if verb == '%' {
+			b = append(b, '%')
+			continue
+		}
+		if n == len(args) {
+			return b, false
+		}
+		var ok bool
+		if b, ok = appendVerb(b, verb, args[n]); !ok {
+			return b, false
+		}
+		n++
+	}
+	return b, n == len(args)
+}
+
+// appendVerb appends arg formatted with verb. It reports false for
+// combinations it does not handle exactly as fmt does.
+func appendVerb(b []byte, verb byte, arg any) ([]byte, bool) {
+	switch v := arg.(type) {
+	case string:
+		switch verb {
+		case 's', 'v':
+			return append(b, v...), true
+		case 'q':
+			return strconv.AppendQuote(b, v), true
+		}
+	case []byte:
+		if verb == 's' {
+			return append(b, v...), true
+		}
+	case bool:
+		if verb == 't' || verb == 'v' {
+			return strconv.AppendBool(b, v), true
+		}
+	case int:
+		return appendIntVerb(b, verb, int64(v))
+	case int64:
+		return appendIntVerb(b, verb, v)
+	case int32:
+		return appendIntVerb(b, verb, int64(v))
+	case int16:
+		return appendIntVerb(b, verb, int64(v))
+	case int8:
+		return appendIntVerb(b, verb, int64(v))
+	case uint:
+		return appendUintVerb(b, verb, uint64(v))
+	case uint64:
+		return appendUintVerb(b, verb, v)
+	case uint32:
+		return appendUintVerb(b, verb, uint64(v))
+	case uint16:
+		return appendUintVerb(b, verb, uint64(v))
+	case float64:
+		if verb == 'v' {
+			return strconv.AppendFloat(b, v, 'g', -1, 64), true
+		}
+	case float32:
+		if verb == 'v' {
+			return strconv.AppendFloat(b, float64(v), 'g', -1, 32), true
+		}
+	}
+	return b, false
+}
+
+// appendIntVerb appends v for the verbs %d, %v, and %x.
+func appendIntVerb(b []byte, verb byte, v int64) ([]byte, bool) {
+	switch verb {
+	case 'd', 'v':
+		return appendInt64(b, v), true
+	case 'x':
+		return strconv.AppendInt(b, v, 16), true
+	}
+	return b, false
+}
+
+// appendUintVerb appends v for the verbs %d, %v, and %x.
+func appendUintVerb(b []byte, verb byte, v uint64) ([]byte, bool) {
+	switch verb {
+	case 'd', 'v':
+		return strconv.AppendUint(b, v, 10), true
+	case 'x':
+		return strconv.AppendUint(b, v, 16), true
+	}
+	return b, false
+}
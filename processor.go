@@ -0,0 +1,75 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+// Processor is one stage of an entry processing pipeline.
+//
+// Processors run in order after the Logger has assembled an entry's fields
+// and run its Hooks, and before the entry is observed and formatted. Each
+// stage receives the entry and next, the rest of the pipeline. A stage may
+// modify the entry before calling next, call next more than once to emit
+// copies, or not call it at all to drop the entry. This makes cross-cutting
+// concerns such as redaction, enrichment, and routing easy to package and
+// reuse across services:
+//
+//	redact := func(e *velo.Entry, next func(*velo.Entry)) {
+//	  for i := range e.TypedFields {
+//	    if e.TypedFields[i].Key == "password" {
+//	      e.TypedFields[i] = velo.String("password", "[REDACTED]")
+//	    }
+//	  }
+//	  next(e)
+//	}
+//
+// A PanicLevel or FatalLevel entry still panics or exits after the pipeline
+// returns, even if a stage dropped it. Dropped entries are reported to
+// Metrics as DropHook. The Entry is pooled, so stages must not retain it, or
+// call next, after returning.
+//
+// Performance Note: Setting any processor routes all calls through the Entry
+// path. The pipeline itself is composed once, when the Logger is configured.
+type Processor func(e *Entry, next func(*Entry))
+
+// Chain composes processors into a single Processor that runs them in order.
+func Chain(processors ...Processor) Processor {
+	return func(e *Entry, next func(*Entry)) {
+		composeProcessors(processors, next)(e)
+	}
+}
+
+// composeProcessors builds the pipeline that runs processors in order and
+// ends in terminal.
+func composeProcessors(processors []Processor, terminal func(*Entry)) func(*Entry) {
+	h := terminal
+	for i := len(processors) - 1; i >= 0; i-- {
+		if p, next := processors[i], h; p != nil {
+			h = func(e *Entry) { p(e, next) }
+		}
+	}
+	return h
+}
+
+// writeProcessed is the final stage of every pipeline. It writes the entry
+// with the Logger and configuration that produced it.
+func writeProcessed(e *Entry) {
+	e.written = true
+	e.logger.writeEntry(e.cfg, e)
+}
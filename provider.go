@@ -0,0 +1,53 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "slices"
+
+// FieldProvider computes fields for each entry at the moment it is logged.
+//
+// Fields attached with With or Options.Fields are fixed when they are
+// attached. A FieldProvider suits values that change between entries, such as
+// the number of goroutines or in-flight requests:
+//
+//	velo.WithFieldProvider(func() []velo.Field {
+//		return []velo.Field{velo.Int("goroutines", runtime.NumGoroutine())}
+//	})
+//
+// Providers run only for entries that pass the level and sampling checks,
+// on the goroutine that logs, so they must be safe for concurrent use. Their
+// fields follow the Logger's own fields and precede context and call fields.
+//
+// Performance Note: combining provider fields with the entry's own costs an
+// allocation per entry, in addition to whatever the provider allocates.
+type FieldProvider func() []Field
+
+// provide returns the fields of cfg's providers followed by ctxFields.
+func (c *loggerConfig) provide(ctxFields []Field) []Field {
+	var fields []Field
+	for _, p := range c.providers {
+		fields = append(fields, p()...)
+	}
+	if len(fields) == 0 {
+		return ctxFields
+	}
+	return append(slices.Clip(fields), ctxFields...)
+}
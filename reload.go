@@ -0,0 +1,227 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReloadConfig is the subset of settings a Reloader applies at runtime.
+//
+// It is read from a JSON file such as:
+//
+//	{
+//	  "level": "info",
+//	  "loggers": {"db": "debug", "http": "warn"},
+//	  "sampling": {"initial": 100, "thereafter": 10}
+//	}
+type ReloadConfig struct {
+	// Level, when set, is applied to every registered Logger that has no
+	// entry in Loggers.
+	Level *Level `json:"level"`
+
+	// Loggers sets the level of registered Loggers by name. Names are
+	// dot-separated, as produced by Logger.Named, and an entry also applies
+	// to the Loggers below it that have no entry of their own: "db" covers
+	// "db.pool" unless "db.pool" is listed.
+	Loggers map[string]Level `json:"loggers"`
+
+	// Sampling, when set, retunes every registered Logger created by
+	// NewSamplerWithOptions. It does not add sampling to other Loggers.
+	Sampling *SamplingConfig `json:"sampling"`
+}
+
+// Reloader re-reads a ReloadConfig file and applies it to a set of named
+// Loggers, so operators can retune logging without restarting the process.
+//
+// Levels are changed with SetLevel and sampling rates are swapped atomically,
+// so Loggers keep running throughout. Children created with With share their
+// parent's level and sampler, so registering a root Logger also retunes its
+// children.
+//
+//	r := velo.NewReloader("/etc/myapp/logging.json")
+//	r.Register("", logger)
+//	r.Register("db", dbLogger)
+//	go r.Watch(ctx, 5*time.Second)
+type Reloader struct {
+	// OnError receives errors from reloads triggered by Watch. It defaults to
+	// logging them with the Default Logger.
+	OnError func(error)
+
+	path    string
+	mu      sync.Mutex
+	loggers map[string]*Logger
+	current *ReloadConfig
+	modTime time.Time
+	size    int64
+}
+
+// NewReloader creates a Reloader for the config file at path. The file is not
+// read until Reload or Watch is called.
+func NewReloader(path string) *Reloader {
+	return &Reloader{
+		path:    path,
+		loggers: make(map[string]*Logger),
+	}
+}
+
+// Register adds a Logger under name, replacing any Logger registered under
+// the same name. If a config has already been loaded, it is applied to l
+// immediately. Pass l.Name() to register a Logger created by Named under its
+// own name.
+func (r *Reloader) Register(name string, l *Logger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loggers[name] = l
+	if r.current != nil {
+		r.current.apply(name, l)
+	}
+}
+
+// Reload reads the config file and applies it to every registered Logger.
+//
+// If the file cannot be read or parsed, no Logger is changed.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fmt.Errorf("velo: reload %s: %w", r.path, err)
+	}
+	return r.reload(info)
+}
+
+// reload reads and applies the file described by info. r.mu must be held.
+func (r *Reloader) reload(info os.FileInfo) error {
+	// Remember the file even if it is invalid, so a broken edit is reported
+	// once rather than on every tick.
+	r.modTime, r.size = info.ModTime(), info.Size()
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("velo: reload %s: %w", r.path, err)
+	}
+	var cfg ReloadConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("velo: reload %s: %w", r.path, err)
+	}
+	if err := cfg.Sampling.validate(); err != nil {
+		return fmt.Errorf("velo: reload %s: %w", r.path, err)
+	}
+	r.current = &cfg
+	for name, l := range r.loggers {
+		cfg.apply(name, l)
+	}
+	return nil
+}
+
+// apply sets the level and sampling rates of the Logger registered as name.
+func (c *ReloadConfig) apply(name string, l *Logger) {
+	if level, ok := c.levelOf(name); ok {
+		l.SetLevel(level)
+	} else if c.Level != nil {
+		l.SetLevel(*c.Level)
+	}
+	if s := c.Sampling; s != nil && l.sampler != nil {
+		l.sampler.setRates(s.tick(), s.Initial, s.Thereafter)
+	}
+}
+
+// levelOf returns the level of the Loggers entry for name or, failing that,
+// for its nearest listed ancestor.
+func (c *ReloadConfig) levelOf(name string) (Level, bool) {
+	for {
+		if level, ok := c.Loggers[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// Watch loads the config file, then reloads it whenever its modification time
+// or size changes, checked every interval, and whenever the process receives
+// SIGHUP on platforms that support it. It blocks until ctx is done and
+// returns ctx.Err().
+//
+// Errors after the initial load are passed to OnError, and the previous
+// config stays in effect. Watch returns an error at once if interval is not
+// positive.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("velo: invalid Watch interval %v", interval)
+	}
+	if err := r.Reload(); err != nil {
+		r.report(err)
+	}
+
+	hup := make(chan os.Signal, 1)
+	notifyReload(hup)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-hup:
+			if err := r.Reload(); err != nil {
+				r.report(err)
+			}
+		case <-ticker.C:
+			if err := r.reloadIfChanged(); err != nil {
+				r.report(err)
+			}
+		}
+	}
+}
+
+// reloadIfChanged reloads the file if it differs from the last one loaded.
+func (r *Reloader) reloadIfChanged() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fmt.Errorf("velo: reload %s: %w", r.path, err)
+	}
+	if info.ModTime().Equal(r.modTime) && info.Size() == r.size {
+		return nil
+	}
+	return r.reload(info)
+}
+
+func (r *Reloader) report(err error) {
+	if r.OnError != nil {
+		r.OnError(err)
+		return
+	}
+	Default().Error("velo: config reload failed", "error", err)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+// _maxBufferSize bounds Options.BufferSize, so that rounding it up to a power
+// of two cannot overflow.
+const _maxBufferSize = 1 << 24
+
+// ringSize rounds n up to the next power of two within _maxBufferSize, using
+// the default of 8192 for zero or less. The ring needs at least two slots, or
+// a published slot would look free to the next lap.
+func ringSize(n int) int {
+	if n <= 0 {
+		return 8192
+	}
+	return 1 << bits.Len(uint(min(max(n, 2), _maxBufferSize)-1))
+}
+
+// ringQueue is the bounded queue between the goroutines that log and the
+// worker that writes: a multi-producer, single-consumer ring of formatted
+// buffers.
+//
+// Its capacity is a power of two, so positions map to slots with a mask.
+// Each slot carries a sequence number telling producers whether it is free
+// for their position and the consumer whether it holds a published buffer,
+// so neither side takes a lock.
+type ringQueue struct {
+	slots []ringSlot
+	mask  uint64
+
+	_    cacheLinePad
+	tail atomic.Uint64 // next position to fill, claimed by producers
+	_    cacheLinePad
+	head atomic.Uint64 // next position to take, owned by the consumer
+	_    cacheLinePad
+
+	// ready holds a token while published buffers may be waiting, and space
+	// while a slot may have been freed for a producer blocked on a full ring.
+	ready chan struct{}
+	space chan struct{}
+}
+
+type ringSlot struct {
+	seq atomic.Uint64
+	b   *buffer
+}
+
+// newRingQueue creates a ring holding ringSize(size) buffers.
+func newRingQueue(size int) *ringQueue {
+	n := ringSize(size)
+	q := &ringQueue{
+		slots: make([]ringSlot, n),
+		mask:  uint64(n - 1),
+		ready: make(chan struct{}, 1),
+		space: make(chan struct{}, 1),
+	}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// capacity returns the number of buffers the ring holds.
+func (q *ringQueue) capacity() int {
+	return len(q.slots)
+}
+
+// len returns the number of buffers waiting in the ring.
+func (q *ringQueue) len() int {
+	head, tail := q.head.Load(), q.tail.Load()
+	if tail <= head {
+		return 0
+	}
+	return int(min(tail-head, uint64(len(q.slots))))
+}
+
+// push adds b to the ring, reporting false if the ring is full.
+func (q *ringQueue) push(b *buffer) bool {
+	pos := q.tail.Load()
+	for {
+		s := &q.slots[pos&q.mask]
+		switch seq := s.seq.Load(); {
+		case seq == pos:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				s.b = b
+				s.seq.Store(pos + 1)
+				notify(q.ready)
+				return true
+			}
+			pos = q.tail.Load()
+		case seq < pos:
+			// The slot still holds the buffer from one lap ago.
+			return false
+		default:
+			pos = q.tail.Load()
+		}
+	}
+}
+
+// pushWait adds b to the ring, waiting for the consumer to free a slot while
+// the ring is full.
+func (q *ringQueue) pushWait(b *buffer) {
+	for !q.push(b) {
+		<-q.space
+	}
+	// Pass the wake-up on, in case other producers wait for the slots freed
+	// since it was sent.
+	notify(q.space)
+}
+
+// pop removes the oldest published buffer. Only the consumer may call it.
+func (q *ringQueue) pop() (*buffer, bool) {
+	head := q.head.Load()
+	s := &q.slots[head&q.mask]
+	if s.seq.Load() != head+1 {
+		return nil, false
+	}
+	b := s.b
+	s.b = nil
+	s.seq.Store(head + q.mask + 1)
+	q.head.Store(head + 1)
+	notify(q.space)
+	return b, true
+}
+
+// notify leaves a token in c unless one is already there.
+func notify(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
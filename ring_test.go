@@ -0,0 +1,164 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestRingSize(t *testing.T) {
+	for _, tt := range []struct{ n, want int }{
+		{-1, 8192},
+		{0, 8192},
+		{1, 2},
+		{2, 2},
+		{3, 4},
+		{64, 64},
+		{65, 128},
+		{_maxBufferSize, _maxBufferSize},
+		{_maxBufferSize + 1, _maxBufferSize},
+	} {
+		if got := ringSize(tt.n); got != tt.want {
+			t.Errorf("ringSize(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestRingQueue pushes from many goroutines at once, waiting on a full ring,
+// and checks that the consumer takes every buffer exactly once and each
+// producer's buffers in order.
+func TestRingQueue(t *testing.T) {
+	const producers, perProducer = 16, 500
+	for _, size := range []int{1, 2, 3, 8, 64} {
+		t.Run(strconv.Itoa(size), func(t *testing.T) {
+			q := newRingQueue(size)
+			var wg sync.WaitGroup
+			for p := range producers {
+				wg.Go(func() {
+					for i := range perProducer {
+						q.pushWait(&buffer{B: []byte{byte(p), byte(i >> 8), byte(i)}})
+					}
+				})
+			}
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+
+			next := make([]int, producers)
+			taken := 0
+			for taken < producers*perProducer {
+				b, ok := q.pop()
+				if !ok {
+					select {
+					case <-q.ready:
+					case <-done:
+					}
+					continue
+				}
+				p, i := int(b.B[0]), int(b.B[1])<<8|int(b.B[2])
+				if i != next[p] {
+					t.Fatalf("producer %d: took entry %d, want %d", p, i, next[p])
+				}
+				next[p]++
+				taken++
+			}
+			if _, ok := q.pop(); ok {
+				t.Error("ring holds more buffers than were pushed")
+			}
+			if n := q.len(); n != 0 {
+				t.Errorf("len() = %d after draining, want 0", n)
+			}
+		})
+	}
+}
+
+// lineCounter counts the lines written to it. OverflowSync writes from the
+// logging goroutines, so it locks.
+type lineCounter struct {
+	mu    sync.Mutex
+	lines int
+}
+
+func (c *lineCounter) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	c.lines += bytes.Count(p, []byte("\n"))
+	c.mu.Unlock()
+	return len(p), nil
+}
+
+func (c *lineCounter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lines
+}
+
+// TestWorkerOverflow submits from many goroutines through small queues and
+// checks that every entry is either written or, for OverflowDrop, counted as
+// dropped.
+func TestWorkerOverflow(t *testing.T) {
+	const producers, perProducer = 16, 500
+	for _, strategy := range []OverflowStrategy{OverflowBlock, OverflowSync, OverflowDrop} {
+		for _, size := range []int{1, 8, 64} {
+			t.Run(fmt.Sprintf("%v/%d", strategy, size), func(t *testing.T) {
+				var out lineCounter
+				w := newWorker(&out, size, strategy, nil, nil, nil, nil, 0)
+				var wg sync.WaitGroup
+				var mu sync.Mutex
+				rejected := 0
+				for range producers {
+					wg.Go(func() {
+						for range perProducer {
+							b := getBuffer()
+							b.WriteString("entry\n")
+							if !w.submit(b) {
+								mu.Lock()
+								rejected++
+								mu.Unlock()
+							}
+						}
+					})
+				}
+				wg.Wait()
+				if err := w.sync(); err != nil {
+					t.Fatal(err)
+				}
+				w.stop()
+
+				written, dropped := out.count(), int(w.dropped.Load())
+				if dropped != rejected {
+					t.Errorf("dropped = %d, but submit rejected %d", dropped, rejected)
+				}
+				if strategy != OverflowDrop && dropped != 0 {
+					t.Errorf("%v dropped %d entries", strategy, dropped)
+				}
+				if written+dropped != producers*perProducer {
+					t.Errorf("wrote %d and dropped %d of %d entries", written, dropped, producers*perProducer)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "errors"
+
+// NewRouter returns a Core that sends each entry to one of several cores,
+// chosen by the value of the field named key.
+//
+// The value is compared in its text form, so String("tenant", "acme") and the
+// loosely typed pair "tenant", "acme" both select routes["acme"]. If an entry
+// carries the field more than once, the last occurrence wins. Entries without
+// the field, or with a value that has no route, go to fallback; if fallback
+// is nil they are discarded. This replaces keeping one Logger per
+// destination and choosing among them at every call site:
+//
+//	router := velo.NewRouter("channel", map[string]velo.Core{
+//	  "audit":   auditCore,
+//	  "billing": billingCore,
+//	}, appCore)
+//	logger := velo.NewWithCore(router)
+//	logger.Info("invoice sent", "channel", "billing")
+//
+// Use it with a child Logger, such as logger.With("channel", "audit"), to pin
+// a whole component to one route.
+func NewRouter(key string, routes map[string]Core, fallback Core) Core {
+	r := &routerCore{
+		key:      key,
+		routes:   make(map[string]Core, len(routes)),
+		fallback: fallback,
+	}
+	for v, c := range routes {
+		if c != nil {
+			r.routes[v] = c
+		}
+	}
+	return r
+}
+
+// routerCore dispatches entries to a core selected by a field value.
+type routerCore struct {
+	key      string
+	routes   map[string]Core
+	fallback Core
+}
+
+// route returns the core for the field value v, which may be nil.
+func (r *routerCore) route(v string, ok bool) Core {
+	if ok {
+		if c, found := r.routes[v]; found {
+			return c
+		}
+	}
+	return r.fallback
+}
+
+// Enabled reports whether any route or the fallback writes entries at level.
+func (r *routerCore) Enabled(level Level) bool {
+	for _, c := range r.routes {
+		if c.Enabled(level) {
+			return true
+		}
+	}
+	return r.fallback != nil && r.fallback.Enabled(level)
+}
+
+// With adds fields to every route. If fields select a route, the result is
+// bound to that route alone.
+func (r *routerCore) With(fields []Field) Core {
+	if v, ok := lookupFields(r.key, nil, fields); ok {
+		if c := r.route(v, true); c != nil {
+			return c.With(fields)
+		}
+		return NewTee()
+	}
+	nr := &routerCore{
+		key:    r.key,
+		routes: make(map[string]Core, len(r.routes)),
+	}
+	for v, c := range r.routes {
+		nr.routes[v] = c.With(fields)
+	}
+	if r.fallback != nil {
+		nr.fallback = r.fallback.With(fields)
+	}
+	return nr
+}
+
+func (r *routerCore) Write(e *Entry) error {
+	c := r.route(lookupFields(r.key, e.Fields, e.TypedFields))
+	if c == nil || !c.Enabled(e.Level) {
+		return nil
+	}
+	return c.Write(e)
+}
+
+// Sync flushes every route and the fallback, returning all errors joined.
+func (r *routerCore) Sync() error {
+	var errs []error
+	for _, c := range r.cores() {
+		if err := c.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *routerCore) close() {
+	for _, c := range r.cores() {
+		closeCore(c)
+	}
+}
+
+// cores returns the routes and the fallback.
+func (r *routerCore) cores() []Core {
+	cores := make([]Core, 0, len(r.routes)+1)
+	for _, c := range r.routes {
+		cores = append(cores, c)
+	}
+	if r.fallback != nil {
+		cores = append(cores, r.fallback)
+	}
+	return cores
+}
+
+// lookupFields returns the text of the last field named key among keyvals
+// and fields, which follow keyvals.
+func lookupFields(key string, keyvals []any, fields []Field) (string, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if fields[i].Key == key {
+			return textFieldValue(&fields[i], fieldFormat{layout: DefaultTimeFormat}), true
+		}
+	}
+	for i := len(keyvals)&^1 - 2; i >= 0; i -= 2 {
+		if k, ok := keyvals[i].(string); ok && k == key {
+			return formatAny(keyvals[i+1]), true
+		}
+	}
+	return "", false
+}
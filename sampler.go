@@ -152,25 +152,23 @@ func SamplerHook(hook func(lvl Level, msg string, dec SamplingDecision)) Sampler
 // precision. Under heavy load, each tick may slightly over sample or under sample.
 func NewSamplerWithOptions(logger *Logger, tick time.Duration, first, thereafter int, opts ...SamplerOption) *Logger {
 	s := &sampler{
-		tick:       tick,
-		counts:     newCounters(),
-		first:      uint64(first),
-		thereafter: uint64(thereafter),
-		hook:       nopSamplingHook,
+		counts: newCounters(),
+		hook:   nopSamplingHook,
 	}
+	s.setRates(tick, first, thereafter)
 	for _, opt := range opts {
 		opt.apply(s)
 	}
 
 	nl := &Logger{
-		fields:      logger.fields,
-		typedFields: logger.typedFields,
-		worker:      logger.worker,
-		level:       logger.level,
-		sampler:     s,
+		worker:  logger.worker,
+		out:     logger.out,
+		level:   logger.level,
+		sampler: s,
 	}
+	nl.base.Store(logger.base.Load())
 	nl.config.Store(logger.config.Load())
-	logger.worker.refCount.Add(1)
+	logger.retain()
 	return nl
 }
 
@@ -191,17 +189,33 @@ func NewSampler(logger *Logger, tick time.Duration, first, thereafter int) *Logg
 }
 
 type sampler struct {
-	counts            *counters
+	counts *counters
+	rates  atomic.Pointer[samplerRates]
+	hook   func(Level, string, SamplingDecision)
+}
+
+// samplerRates holds the parameters of a sampler, swapped as a unit so they
+// can be retuned while the sampler is in use.
+type samplerRates struct {
 	tick              time.Duration
 	first, thereafter uint64
-	hook              func(Level, string, SamplingDecision)
+}
+
+// setRates replaces the sampler's parameters.
+func (s *sampler) setRates(tick time.Duration, first, thereafter int) {
+	s.rates.Store(&samplerRates{
+		tick:       tick,
+		first:      uint64(first),
+		thereafter: uint64(thereafter),
+	})
 }
 
 func (s *sampler) check(lvl Level, msg string, t time.Time) bool {
 	if lvl >= _minLevel && lvl <= _maxLevel {
+		r := s.rates.Load()
 		counter := s.counts.get(lvl, msg)
-		n := counter.IncCheckReset(t, s.tick)
-		if n > s.first && (s.thereafter == 0 || (n-s.first)%s.thereafter != 0) {
+		n := counter.IncCheckReset(t, r.tick)
+		if n > r.first && (r.thereafter == 0 || (n-r.first)%r.thereafter != 0) {
 			s.hook(lvl, msg, LogDropped)
 			return false
 		}
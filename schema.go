@@ -0,0 +1,169 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Schema describes the fields a Logger's entries are expected to carry, so
+// that drifting field types and forgotten keys surface during development
+// instead of in the index downstream.
+//
+// Every entry written by a Logger with a Schema is checked after its hooks
+// and processors ran, and each violation is passed to Options.ErrorHandler as
+// a *SchemaError. The entry itself is written unchanged.
+//
+// Performance Note: Validation routes every call through the Entry path.
+// Enable it in development and test builds, for example by setting
+// Options.Schema only when Options.Development is set.
+type Schema struct {
+	// Types maps field keys to the type their values must have. Keys absent
+	// from Types may hold any value. Loosely typed values and Any fields are
+	// checked by their dynamic type: strings are StringType, integers of any
+	// size are IntType, and so on. Values with no more specific type, such as
+	// floats and maps, are AnyType.
+	Types map[string]FieldType
+
+	// Required maps messages to the keys every entry with that message must
+	// carry.
+	Required map[string][]string
+
+	// PrefixRequired maps prefixes to the keys every entry written by a
+	// Logger with that Prefix must carry.
+	PrefixRequired map[string][]string
+}
+
+// SchemaError describes an entry that does not match its Logger's Schema.
+type SchemaError struct {
+	// Level and Message identify the offending entry.
+	Level   Level
+	Message string
+
+	// Key is the field at fault.
+	Key string
+
+	// Missing reports that the entry lacks the required Key. Otherwise the
+	// value of Key has type Got instead of Want.
+	Missing   bool
+	Want, Got FieldType
+}
+
+func (e *SchemaError) Error() string {
+	if e.Missing {
+		return fmt.Sprintf("velo: %s entry %q is missing required field %q", e.Level, e.Message, e.Key)
+	}
+	return fmt.Sprintf("velo: field %q of %s entry %q is %v, want %v", e.Key, e.Level, e.Message, e.Got, e.Want)
+}
+
+// check reports every violation of s in e to handle.
+func (s *Schema) check(e *Entry, handle func(error)) {
+	if len(s.Types) > 0 {
+		for i := 0; i+1 < len(e.Fields); i += 2 {
+			if key, ok := e.Fields[i].(string); ok {
+				s.checkType(e, key, valueType(e.Fields[i+1]), handle)
+			}
+		}
+		for i := range e.TypedFields {
+			f := &e.TypedFields[i]
+			typ := f.Type
+			if typ == AnyType {
+				typ = valueType(f.Any)
+			}
+			s.checkType(e, f.Key, typ, handle)
+		}
+	}
+	s.checkRequired(e, s.Required[e.Message], handle)
+	if e.Prefix != "" {
+		s.checkRequired(e, s.PrefixRequired[e.Prefix], handle)
+	}
+}
+
+func (s *Schema) checkType(e *Entry, key string, got FieldType, handle func(error)) {
+	if want, ok := s.Types[key]; ok && want != got {
+		handle(&SchemaError{Level: e.Level, Message: e.Message, Key: key, Want: want, Got: got})
+	}
+}
+
+func (s *Schema) checkRequired(e *Entry, keys []string, handle func(error)) {
+	for _, key := range keys {
+		if !e.hasField(key) {
+			handle(&SchemaError{Level: e.Level, Message: e.Message, Key: key, Missing: true})
+		}
+	}
+}
+
+// hasField reports whether e carries a field named key.
+func (e *Entry) hasField(key string) bool {
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		if k, ok := e.Fields[i].(string); ok && k == key {
+			return true
+		}
+	}
+	for i := range e.TypedFields {
+		if e.TypedFields[i].Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// valueType returns the FieldType matching the dynamic type of a loosely
+// typed value.
+func valueType(v any) FieldType {
+	switch v.(type) {
+	case string:
+		return StringType
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return IntType
+	case bool:
+		return BoolType
+	case time.Time:
+		return TimeType
+	case time.Duration:
+		return DurationType
+	case []int:
+		return IntsType
+	case []string:
+		return StringsType
+	case []time.Time:
+		return TimesType
+	case error:
+		return ErrorType
+	case ObjectMarshaler:
+		return ObjectType
+	case ArrayMarshaler:
+		return ArrayType
+	}
+	return AnyType
+}
+
+// reportError passes err to the Logger's ErrorHandler, or writes it to
+// standard error when there is none.
+func (c *loggerConfig) reportError(err error) {
+	if c.errorHandler != nil {
+		c.errorHandler(err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, err)
+}
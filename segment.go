@@ -0,0 +1,93 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "sync/atomic"
+
+// _maxSegmentDepth bounds the number of segments walked for every entry.
+// Extending a longer chain flattens it into a single segment.
+const _maxSegmentDepth = 16
+
+// segment is one link of an immutable chain of encoded Logger fields.
+//
+// A child Logger created by With or WithFields adds a segment holding only
+// its own fields, so creating it costs the same however many fields its
+// ancestors carry. Log calls walk the chain, which every descendant shares,
+// instead of copying it.
+type segment struct {
+	prev *segment
+	b    []byte
+
+	// fields counts the fields encoded in the whole chain, size its bytes,
+	// and depth its segments.
+	fields int
+	size   int
+	depth  int
+
+	// flat caches the chain as a single slice for Entry.PreEncodedJSON.
+	flat atomic.Pointer[[]byte]
+}
+
+// extend returns the chain s followed by b, which encodes n fields. It
+// returns s if b is empty.
+func (s *segment) extend(b []byte, n int) *segment {
+	if len(b) == 0 {
+		return s
+	}
+	ns := &segment{prev: s, b: b, fields: n, size: len(b), depth: 1}
+	if s != nil {
+		ns.fields += s.fields
+		ns.size += s.size
+		ns.depth += s.depth
+		if ns.depth > _maxSegmentDepth {
+			ns.b = append(s.appendTo(nil, 0), b...)
+			ns.prev, ns.depth = nil, 1
+		}
+	}
+	return ns
+}
+
+// appendTo appends the chain to dst, oldest segment first, leaving out its
+// first skip bytes.
+func (s *segment) appendTo(dst []byte, skip int) []byte {
+	if s.prev != nil {
+		dst = s.prev.appendTo(dst, skip)
+		skip = 0
+	}
+	return append(dst, s.b[skip:]...)
+}
+
+// bytes returns the chain as a single slice, which must not be modified. A
+// nil chain returns nil.
+func (s *segment) bytes() []byte {
+	switch {
+	case s == nil:
+		return nil
+	case s.prev == nil:
+		return s.b
+	}
+	if p := s.flat.Load(); p != nil {
+		return *p
+	}
+	b := s.appendTo(nil, 0)
+	s.flat.Store(&b)
+	return b
+}
@@ -42,11 +42,13 @@ func NewSlogHandler(logger *Logger) *SlogHandler {
 
 // Enabled determines if the handler should process records at the specified slog.Level.
 func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
-	return h.logger.level.val.Load() <= int64(slogLevelToVelo(level))
+	return h.logger.Enabled(slogLevelToVelo(level))
 }
 
-// Handle processes a slog.Record, converting it into a Velo log entry.
-func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
+// Handle processes a slog.Record, converting it into a Velo log entry. Like
+// LogContextFields, it attaches the fields of the Logger's ContextExtractor
+// and the correlation ID of ctx.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
 	level := slogLevelToVelo(r.Level)
 
 	// Use a pooled buffer for fields to reduce allocations if we were doing complex formatting,
@@ -61,7 +63,7 @@ func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
 		return true
 	})
 
-	h.logger.LogFields(level, r.Message, fields...)
+	h.logger.LogContextFields(ctx, level, r.Message, fields...)
 	return nil
 }
 
@@ -97,6 +99,25 @@ func (h *SlogHandler) WithGroup(name string) slog.Handler {
 	}
 }
 
+// LogAttrs writes a message at the specified level with slog attributes,
+// converted to Fields as SlogHandler converts them, so code written in slog's
+// LogAttrs style can log through velo without building a slog.Record. Like
+// LogContextFields, it attaches the fields derived from ctx.
+//
+// Performance Note: Up to eight attributes are converted on the stack, so
+// calls with few attributes allocate no more than LogContextFields does.
+func (l *Logger) LogAttrs(ctx context.Context, level Level, msg string, attrs ...slog.Attr) {
+	if l == nil || (l.level.val.Load() > int64(level) && l.trigger == nil) {
+		return
+	}
+	var scratch [8]Field
+	fields := scratch[:0]
+	for _, a := range attrs {
+		fields = append(fields, slogAttrToField(a, ""))
+	}
+	l.logContextFields(0, ctx, level, msg, fields)
+}
+
 func slogLevelToVelo(l slog.Level) Level {
 	switch {
 	case l >= slog.LevelError:
@@ -0,0 +1,198 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// SpillFile is a bounded, append only file that keeps the formatted entries
+// an asynchronous Logger could not deliver: those discarded by OverflowDrop
+// and those its destination failed to accept. Set it as Options.Spill, and
+// once the destination recovers, call Reingest to send them on.
+//
+// Entries are kept whole and in the order they were lost. An entry the
+// destination accepted only part of is spilled whole, so after Reingest the
+// destination may hold its beginning twice. Once the file reaches its size
+// limit, further entries are discarded and counted by Lost, so the start of
+// an outage is preserved over its end.
+type SpillFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	f       *os.File
+	size    int64
+	lost    atomic.Uint64
+}
+
+// OpenSpillFile opens or creates the spill file at path, keeping any entries
+// spilled by an earlier run, and limits it to maxSize bytes.
+func OpenSpillFile(path string, maxSize int64) (*SpillFile, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("velo: spill file size must be positive")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &SpillFile{path: path, maxSize: maxSize, f: f, size: info.Size()}, nil
+}
+
+// Write appends p to the file, or discards it when the file is full. It
+// never fails because the file is full; p counts as lost instead.
+func (s *SpillFile) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size+int64(len(p)) > s.maxSize {
+		s.lost.Add(uint64(len(p)))
+		return len(p), nil
+	}
+	n, err := s.f.Write(p)
+	s.size += int64(n)
+	if err != nil {
+		s.lost.Add(uint64(len(p) - n))
+	}
+	return n, err
+}
+
+// Size returns the number of bytes waiting in the file.
+func (s *SpillFile) Size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// Lost returns the number of bytes discarded because the file was full or
+// could not be written.
+func (s *SpillFile) Lost() uint64 {
+	return s.lost.Load()
+}
+
+// Reingest copies the spilled entries to w, oldest first, and empties the
+// file once they are all written. Pass the recovered destination, or a
+// Logger's writer, as w.
+//
+// If w fails, the file is left as is and the error is returned; calling
+// Reingest again resends every entry, so entries written before the failure
+// are delivered twice.
+func (s *SpillFile) Reingest(w io.Writer) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size == 0 {
+		return 0, nil
+	}
+	n, err := io.Copy(w, io.NewSectionReader(s.f, 0, s.size))
+	if err != nil {
+		return n, err
+	}
+	if err := s.f.Truncate(0); err != nil {
+		return n, err
+	}
+	s.size = 0
+	return n, nil
+}
+
+// Sync commits the file's contents to stable storage.
+func (s *SpillFile) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Sync()
+}
+
+// Close closes the file. Close the Logger that spills into it first.
+func (s *SpillFile) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
+
+// spillWriter writes to out, appending to spill every entry out fails to
+// accept in full, so that a failing destination does not lose entries.
+//
+// It reports every write as successful so that the worker's bufio.Writer
+// keeps working through the outage; take returns the error it hid.
+type spillWriter struct {
+	out   io.Writer
+	spill *SpillFile
+	err   atomic.Pointer[error]
+
+	// lens holds the lengths of the entries the worker buffered and Write
+	// has not yet seen, oldest first, from next on. Only the worker
+	// goroutine touches them.
+	lens []int
+	next int
+}
+
+// Write writes a run of buffered entries, whose lengths the worker recorded
+// in lens.
+func (s *spillWriter) Write(p []byte) (int, error) {
+	n, err := s.out.Write(p)
+	if err != nil {
+		s.err.Store(&err)
+	}
+	off := 0
+	for off < len(p) && s.next < len(s.lens) {
+		l := s.lens[s.next]
+		s.next++
+		if err != nil && off+l > n {
+			s.spill.Write(p[off : off+l])
+		}
+		off += l
+	}
+	if s.next == len(s.lens) {
+		s.lens, s.next = s.lens[:0], 0
+	}
+	if err != nil && off < len(p) {
+		// Bytes no recorded entry accounts for; spill what out missed.
+		s.spill.Write(p[max(off, n):])
+	}
+	return len(p), nil
+}
+
+// writeEntry writes the single entry p, spilling it whole if out fails to
+// accept all of it. Unlike Write, it may be called from any goroutine.
+func (s *spillWriter) writeEntry(p []byte) (int, error) {
+	if _, err := s.out.Write(p); err != nil {
+		s.spill.Write(p)
+		s.err.Store(&err)
+	}
+	return len(p), nil
+}
+
+// take returns and clears the last error from the destination.
+func (s *spillWriter) take() error {
+	if err := s.err.Swap(nil); err != nil {
+		return *err
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSpillFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spill")
+	s, err := OpenSpillFile(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Write([]byte("12345\n"))
+	s.Write([]byte("67890\n"))
+	if s.Size() != 6 || s.Lost() != 6 {
+		t.Errorf("Size() = %d and Lost() = %d, want 6 and 6", s.Size(), s.Lost())
+	}
+	s.Close()
+
+	// Reopening keeps the entries of the earlier run.
+	s, err = OpenSpillFile(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	var out bytes.Buffer
+	if n, err := s.Reingest(&out); err != nil || n != 6 || out.String() != "12345\n" {
+		t.Errorf("Reingest() = %d, %v and wrote %q", n, err, out.String())
+	}
+	if s.Size() != 0 {
+		t.Errorf("Size() = %d after Reingest, want 0", s.Size())
+	}
+	if n, err := s.Reingest(&out); n != 0 || err != nil {
+		t.Errorf("Reingest of an empty file = %d, %v", n, err)
+	}
+}
+
+func TestSpillFileSize(t *testing.T) {
+	if _, err := OpenSpillFile(filepath.Join(t.TempDir(), "spill"), 0); err == nil {
+		t.Error("OpenSpillFile accepted a size of 0")
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("unavailable") }
+
+func TestSpillOnWriteFailure(t *testing.T) {
+	s, err := OpenSpillFile(filepath.Join(t.TempDir(), "spill"), 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	l := New(failingWriter{}, WithAsync(16, OverflowBlock), WithSpill(s), WithErrorHandler(func(error) {}))
+	l.Info("one")
+	l.Info("two")
+	l.Close()
+
+	var out bytes.Buffer
+	if _, err := s.Reingest(&out); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "INFO one\nINFO two\n"; got != want {
+		t.Errorf("spilled %q, want %q", got, want)
+	}
+}
+
+// brokenWriter accepts the first n bytes written to it, then fails.
+type brokenWriter struct {
+	bytes.Buffer
+	n int
+}
+
+func (w *brokenWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n, _ := w.Buffer.Write(p[:w.n])
+		w.n = 0
+		return n, errors.New("unavailable")
+	}
+	w.n -= len(p)
+	return w.Buffer.Write(p)
+}
+
+func TestSpillKeepsEntriesWhole(t *testing.T) {
+	s, err := OpenSpillFile(filepath.Join(t.TempDir(), "spill"), 200<<10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	// The destination fails partway through an entry, after several flushes
+	// of the worker's buffer, and the spill file fills up before the end.
+	dst := &brokenWriter{n: 100_001}
+	l := New(dst, WithAsync(64, OverflowBlock), WithSpill(s), WithErrorHandler(func(error) {}))
+	pad := strings.Repeat("x", 300)
+	const entries = 2000
+	for i := range entries {
+		l.Info("entry", "i", i, "pad", pad)
+	}
+	l.Close()
+	if s.Lost() == 0 {
+		t.Fatal("the spill file did not fill up")
+	}
+
+	// The destination ends with the beginning of the entry it cut short,
+	// which is the first spilled entry.
+	written := dst.String()
+	cut := written[strings.LastIndexByte(written, '\n')+1:]
+	var out bytes.Buffer
+	if _, err := s.Reingest(&out); err != nil {
+		t.Fatal(err)
+	}
+	if cut == "" || !strings.HasPrefix(out.String(), cut) {
+		t.Errorf("destination ends with %q, which does not start the spill file", cut)
+	}
+	sc := bufio.NewScanner(&out)
+	next := -1
+	for sc.Scan() {
+		var i int
+		if _, err := fmt.Sscanf(sc.Text(), "INFO entry i=%d pad="+pad, &i); err != nil || !strings.HasSuffix(sc.Text(), pad) {
+			t.Fatalf("spilled a broken entry %q", sc.Text())
+		}
+		if next >= 0 && i != next {
+			t.Fatalf("spilled entry %d, want %d", i, next)
+		}
+		next = i + 1
+	}
+	if next < 0 {
+		t.Fatal("nothing was spilled")
+	}
+}
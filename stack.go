@@ -21,56 +21,156 @@
 package velo
 
 import (
+	"bytes"
+	"iter"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"unsafe"
 )
 
-const maxTraceDepth = 5
+// DefaultGoroutineDumpLimit is the size cap of a goroutine dump when
+// Options.GoroutineDumpLimit is unset.
+const DefaultGoroutineDumpLimit = 1 << 20
 
-// writeStacktrace processes program counters into a human readable, styled stack trace.
-//
-// It avoids string splitting and regular expressions, relying entirely on
-// runtime.CallersFrames. This approach ensures high performance, comparable to
-// Zap's stack trace generation.
-//
-//go:noinline
-func writeStacktrace(b *buffer, pcs []uintptr, st *Styles) {
-	if len(pcs) == 0 {
-		return
-	}
+// StacktraceKey is the JSON key holding the stack trace of an entry.
+const StacktraceKey = "stacktrace"
 
-	frames := runtime.CallersFrames(pcs)
-	rendered := 0
+// GoroutinesKey is the JSON key holding the goroutine dump of Options.DumpGoroutines.
+const GoroutinesKey = "goroutines"
 
-	// cache static byte slices to eliminate loop allocations.
-	prefix := []byte(st.Separator.Render("   at "))
-	space := byte(' ')
-	newline := byte('\n')
+// DefaultStacktraceDepth is the number of frames rendered when Options.StacktraceDepth is unset.
+const DefaultStacktraceDepth = 5
 
-	for {
-		frame, more := frames.Next()
+// stackLevel holds Options.StacktraceLevel, or _defaultStackLevel when it
+// is unset.
+type stackLevel Level
 
-		// ignore standard library internals and test runners.
-		if strings.Contains(frame.File, "runtime/") || strings.Contains(frame.File, "testing/") {
-			if !more {
-				break
+// _defaultStackLevel lies outside the range of levels and selects the
+// default rule.
+const _defaultStackLevel = stackLevel(noLevel)
+
+// newStackLevel returns the stackLevel for Options.StacktraceLevel.
+func newStackLevel(level *Level) stackLevel {
+	if level == nil {
+		return _defaultStackLevel
+	}
+	return stackLevel(*level)
+}
+
+// option returns s as Options.StacktraceLevel.
+func (s stackLevel) option() *Level {
+	if s == _defaultStackLevel {
+		return nil
+	}
+	level := Level(s)
+	return &level
+}
+
+// captures reports whether an entry at level with the given fields receives
+// a stack trace.
+//
+// By default, entries at ErrorLevel or higher and entries carrying an error
+// field are captured. An explicit level is a plain threshold.
+func (s stackLevel) captures(level Level, keyvals []any, ctxFields, typedFields []Field) bool {
+	if level == noLevel {
+		return false
+	}
+	if s != _defaultStackLevel {
+		return level >= Level(s)
+	}
+	if level >= ErrorLevel {
+		return true
+	}
+	for i := 0; i < len(keyvals); i++ {
+		if _, ok := keyvals[i].(error); ok {
+			return true
+		}
+	}
+	for _, fields := range [...][]Field{ctxFields, typedFields} {
+		for i := range fields {
+			if fields[i].Type == ErrorType {
+				return true
 			}
-			continue
 		}
+	}
+	return false
+}
+
+// FrameFilter reports whether a stack frame should appear in a rendered stack trace.
+type FrameFilter func(frame runtime.Frame) bool
 
-		// ignore our own library frames unless we are running tests.
-		if strings.Contains(frame.Function, "velo") && !strings.HasSuffix(frame.File, "_test.go") {
+// _veloPackage is the import path of this package, used to recognize its own frames.
+var _veloPackage = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	fn := runtime.FuncForPC(pc).Name()
+	// The name has the form "path/to/velo.func1"; the package ends at the
+	// first dot after the last slash.
+	slash := strings.LastIndexByte(fn, '/')
+	return fn[:slash+1+strings.IndexByte(fn[slash+1:], '.')]
+}()
+
+// DefaultFrameFilter hides frames from the Go runtime, the testing package,
+// and the logger itself, so stack traces start at the code that logged.
+//
+// Frames are matched by their package path rather than by substrings of the
+// file path, so application packages whose path merely contains "velo" or
+// "runtime" are kept. The logger's own frames are kept in its tests.
+func DefaultFrameFilter(frame runtime.Frame) bool {
+	fn := frame.Function
+	switch {
+	case strings.HasPrefix(fn, "runtime.") || strings.HasPrefix(fn, "runtime/"),
+		strings.HasPrefix(fn, "testing."):
+		return false
+	case strings.HasPrefix(fn, _veloPackage+"."):
+		return strings.HasSuffix(frame.File, "_test.go")
+	}
+	return true
+}
+
+// stackFrames yields at most depth frames of pcs accepted by filter. A zero
+// depth or nil filter selects the defaults.
+func stackFrames(pcs []uintptr, depth int, filter FrameFilter) iter.Seq[runtime.Frame] {
+	if depth <= 0 {
+		depth = DefaultStacktraceDepth
+	}
+	if filter == nil {
+		filter = DefaultFrameFilter
+	}
+	return func(yield func(runtime.Frame) bool) {
+		if len(pcs) == 0 {
+			return
+		}
+		frames := runtime.CallersFrames(pcs)
+		rendered := 0
+		for rendered < depth {
+			frame, more := frames.Next()
+			if filter(frame) {
+				if !yield(frame) {
+					return
+				}
+				rendered++
+			}
 			if !more {
-				break
+				return
 			}
-			continue
 		}
+	}
+}
 
-		if rendered >= maxTraceDepth {
-			break
-		}
+// writeStacktrace processes program counters into a human readable, styled stack trace.
+//
+// It avoids string splitting and regular expressions, relying entirely on
+// runtime.CallersFrames. This approach ensures high performance, comparable to
+// Zap's stack trace generation.
+//
+//go:noinline
+func writeStacktrace(b *buffer, pcs []uintptr, st *Styles, depth int, filter FrameFilter) {
+	// cache static byte slices to eliminate loop allocations.
+	prefix := []byte(st.Separator.Render("   at "))
 
+	for frame := range stackFrames(pcs, depth, filter) {
 		// isolate the function name from its package path.
 		fn := frame.Function
 		if idx := strings.LastIndexByte(fn, '/'); idx >= 0 {
@@ -88,17 +188,116 @@ func writeStacktrace(b *buffer, pcs []uintptr, st *Styles) {
 
 		// stream the styled output directly to the buffer.
 		b.Write(prefix)
-		b.WriteString(st.StackFunc.Render(fn))
-		b.WriteByte(space)
+		b.B = st.StackFunc.appendRender(b.B, fn, "")
+		b.WriteByte(' ')
 
 		// concatenate file and line efficiently.
 		loc := file + ":" + strconv.Itoa(frame.Line)
-		b.WriteString(st.StackFile.Render(loc))
-		b.WriteByte(newline)
+		b.B = st.StackFile.appendRender(b.B, loc, "")
+		b.WriteByte('\n')
+	}
+}
 
-		rendered++
-		if !more {
-			break
+// appendJSONStacktrace encodes a stack trace as a JSON string.
+//
+// Each frame contributes its fully qualified function name followed by a tab
+// indented absolute file:line, one per line, which is the shape most log
+// pipelines and error trackers already parse.
+func appendJSONStacktrace(b *buffer, pcs []uintptr, depth int, filter FrameFilter) {
+	trace := getBufferSize(len(pcs) * stackFrameSizeHint)
+	appendStackLines(trace, pcs, depth, filter)
+	// The scratch buffer outlives the string only until it returns to the pool.
+	appendJSONString(b, unsafe.String(unsafe.SliceData(trace.B), len(trace.B)))
+	putBuffer(trace)
+}
+
+// appendStackLines writes the unstyled frames of a stack trace into the empty
+// buffer b, in the shape appendJSONStacktrace encodes.
+func appendStackLines(b *buffer, pcs []uintptr, depth int, filter FrameFilter) {
+	for frame := range stackFrames(pcs, depth, filter) {
+		if len(b.B) > 0 {
+			b.WriteByte('\n')
 		}
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteByte(':')
+		b.B = strconv.AppendInt(b.B, int64(frame.Line), 10)
 	}
 }
+
+// dumpGoroutines returns the stacks of all goroutines, cut to at most limit
+// bytes and marked with TruncationMarker when cut.
+//
+// It stops the world while collecting, which is acceptable on the way to a
+// panic or exit but not on any regular logging path.
+func dumpGoroutines(limit int) []byte {
+	if limit <= 0 {
+		limit = DefaultGoroutineDumpLimit
+	}
+	buf := make([]byte, limit)
+	n := runtime.Stack(buf, true)
+	if n == len(buf) {
+		// runtime.Stack silently stops at the end of buf.
+		if i := bytes.LastIndexByte(buf, '\n'); i > 0 {
+			n = i + 1
+		}
+		return append(buf[:n], TruncationMarker+"\n"...)
+	}
+	return buf[:n]
+}
+
+// pendingStack is a captured stack trace whose symbolization is deferred from
+// the logging goroutine to the asynchronous worker.
+//
+// runtime.Callers is cheap, but resolving frames and rendering them is not.
+// The formatter records the offset at which the trace belongs, and the worker
+// renders it into place just before writing the buffer.
+type pendingStack struct {
+	pcs    []uintptr
+	at     int
+	depth  int
+	filter FrameFilter
+	styles *Styles
+	json   bool
+}
+
+// deferStack arranges for pcs to be rendered at the current end of b once the
+// worker calls resolveStack.
+func (b *buffer) deferStack(pcs []uintptr, st *Styles, depth int, filter FrameFilter, json bool) {
+	b.stack = pendingStack{
+		pcs:    append(b.stack.pcs[:0], pcs...),
+		at:     len(b.B),
+		depth:  depth,
+		filter: filter,
+		styles: st,
+		json:   json,
+	}
+}
+
+// stackFrameSizeHint estimates the bytes a rendered stack frame takes.
+const stackFrameSizeHint = 160
+
+// resolveStack renders a deferred stack trace into b. It is a no-op for
+// buffers without one.
+func (b *buffer) resolveStack() {
+	ps := &b.stack
+	if len(ps.pcs) == 0 {
+		return
+	}
+	trace := getBufferSize(len(ps.pcs) * stackFrameSizeHint)
+	if ps.json {
+		appendJSONStacktrace(trace, ps.pcs, ps.depth, ps.filter)
+	} else {
+		writeStacktrace(trace, ps.pcs, ps.styles, ps.depth, ps.filter)
+		trace.B = bytes.TrimSuffix(trace.B, []byte{'\n'})
+	}
+	b.B = slices.Insert(b.B, ps.at, trace.B...)
+	putBuffer(trace)
+	ps.reset()
+}
+
+// reset clears the pending stack while keeping its PC capacity for reuse.
+func (ps *pendingStack) reset() {
+	*ps = pendingStack{pcs: ps.pcs[:0]}
+}
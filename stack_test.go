@@ -0,0 +1,59 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStacktraceLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		level *Level
+		log   func(l *Logger)
+		want  bool
+	}{
+		{"default info", nil, func(l *Logger) { l.Info("msg") }, false},
+		{"default error", nil, func(l *Logger) { l.Error("msg") }, true},
+		{"default error field", nil, func(l *Logger) { l.Info("msg", "err", bytes.ErrTooLarge) }, true},
+		{"info", new(InfoLevel), func(l *Logger) { l.Info("msg") }, true},
+		{"info debug", new(InfoLevel), func(l *Logger) { l.Debug("msg") }, false},
+		{"warn error field", new(WarnLevel), func(l *Logger) { l.Info("msg", "err", bytes.ErrTooLarge) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewWithOptions(&buf, Options{
+				Formatter:        JSONFormatter,
+				Level:            DebugLevel,
+				ReportStacktrace: true,
+				StacktraceLevel:  tt.level,
+			})
+			tt.log(l)
+			l.Sync()
+			if got := strings.Contains(buf.String(), StacktraceKey); got != tt.want {
+				t.Errorf("stack trace written = %v, want %v:\n%s", got, tt.want, buf.String())
+			}
+		})
+	}
+}
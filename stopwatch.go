@@ -0,0 +1,102 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "time"
+
+// ElapsedKey is the key of the duration fields written by Stopwatch and the
+// Timed methods.
+const ElapsedKey = "elapsed"
+
+// Watch measures the time elapsed since it was started. The zero value is not
+// started; create one with Stopwatch.
+//
+// A Watch is a plain value, cheap to copy and safe to read from any
+// goroutine.
+type Watch struct {
+	start time.Time
+}
+
+// Stopwatch starts a Watch:
+//
+//	sw := velo.Stopwatch()
+//	rows, err := db.Query(q)
+//	logger.InfoFields("query done", sw.Field(), velo.Int("rows", n))
+func Stopwatch() Watch {
+	return Watch{start: time.Now()}
+}
+
+// Elapsed returns the time since the Watch started, measured on the
+// monotonic clock.
+func (w Watch) Elapsed() time.Duration {
+	return time.Since(w.start)
+}
+
+// Field returns the elapsed time as a Duration field under ElapsedKey.
+func (w Watch) Field() Field {
+	return Duration(ElapsedKey, w.Elapsed())
+}
+
+// FieldAs returns the elapsed time as a Duration field under key.
+func (w Watch) FieldAs(key string) Field {
+	return Duration(key, w.Elapsed())
+}
+
+// Timed writes a message at the specified level that marks the start of an
+// operation, and returns a function that marks its end.
+//
+// The start entry carries fields and the message followed by " started". The
+// returned function writes the message followed by " finished", with fields,
+// then its own fields, then the elapsed time under ElapsedKey. Call it once,
+// typically deferred:
+//
+//	done := logger.TimedInfo("import", velo.String("file", name))
+//	defer done()
+//
+// Both entries are subject to the level at the time they are written, and the
+// second reports the caller of the returned function.
+func (l *Logger) Timed(level Level, msg string, fields ...Field) func(more ...Field) {
+	return l.timed(level, msg, fields)
+}
+
+// TimedDebug is Timed at DebugLevel.
+func (l *Logger) TimedDebug(msg string, fields ...Field) func(more ...Field) {
+	return l.timed(DebugLevel, msg, fields)
+}
+
+// TimedInfo is Timed at InfoLevel.
+func (l *Logger) TimedInfo(msg string, fields ...Field) func(more ...Field) {
+	return l.timed(InfoLevel, msg, fields)
+}
+
+// timed writes the start entry and returns the function writing the end
+// entry. The fields are copied, since the caller's variadic slice may be
+// reused before the operation ends.
+func (l *Logger) timed(level Level, msg string, fields []Field) func(more ...Field) {
+	sw := Stopwatch()
+	l.logFields(1, level, msg+" started", fields)
+	fields = append([]Field(nil), fields...)
+	return func(more ...Field) {
+		end := make([]Field, 0, len(fields)+len(more)+1)
+		end = append(append(append(end, fields...), more...), sw.Field())
+		l.logFields(0, level, msg+" finished", end)
+	}
+}
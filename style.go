@@ -0,0 +1,385 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ColorProfile describes the range of colors a terminal can display.
+type ColorProfile int
+
+const (
+	// NoColor disables all ANSI escape sequences, including bold and faint.
+	NoColor ColorProfile = iota
+	// ANSIColor supports the 16 standard terminal colors.
+	ANSIColor
+	// ANSI256Color supports the 256 color xterm palette.
+	ANSI256Color
+	// TrueColor supports 24 bit RGB colors.
+	TrueColor
+)
+
+// Color is a terminal color, written either as an ANSI palette index from "0"
+// to "255" or as a hex RGB value such as "#ff5f87". Colors the active
+// ColorProfile cannot display are mapped to the nearest supported color.
+type Color string
+
+// Style is a minimal ANSI text style for the TextFormatter.
+//
+// Its builder methods mirror the subset of lipgloss that log output needs and
+// return modified copies, so a Style can be shared freely. The escape
+// sequence is computed when the Style is built rather than on every Render.
+// A Style uses the color profile detected for standard output unless Profile
+// selects another one. The zero value renders text unchanged.
+type Style struct {
+	seq      string
+	value    string
+	fg, bg   Color
+	attrs    []byte
+	maxWidth int
+	profile  ColorProfile
+}
+
+// _stdoutProfile is the color profile detected for standard output.
+var _stdoutProfile = sync.OnceValue(func() ColorProfile {
+	return detectProfile(os.Stdout)
+})
+
+// NewStyle returns an empty Style using the color profile of standard output.
+func NewStyle() Style {
+	return Style{profile: _stdoutProfile()}
+}
+
+// Profile returns a copy of the Style that renders for the color profile p.
+func (s Style) Profile(p ColorProfile) Style {
+	s.profile = p
+	return s.build()
+}
+
+// Bold returns a copy of the Style with bold text enabled or disabled.
+func (s Style) Bold(v bool) Style { return s.attr('1', v) }
+
+// Faint returns a copy of the Style with faint text enabled or disabled.
+func (s Style) Faint(v bool) Style { return s.attr('2', v) }
+
+// Italic returns a copy of the Style with italic text enabled or disabled.
+func (s Style) Italic(v bool) Style { return s.attr('3', v) }
+
+// Underline returns a copy of the Style with underlined text enabled or disabled.
+func (s Style) Underline(v bool) Style { return s.attr('4', v) }
+
+// Reverse returns a copy of the Style with swapped foreground and background colors.
+func (s Style) Reverse(v bool) Style { return s.attr('7', v) }
+
+// Foreground returns a copy of the Style with the text color set to c.
+func (s Style) Foreground(c Color) Style {
+	s.fg = c
+	return s.build()
+}
+
+// Background returns a copy of the Style with the background color set to c.
+func (s Style) Background(c Color) Style {
+	s.bg = c
+	return s.build()
+}
+
+// MaxWidth returns a copy of the Style that cuts each line of rendered text
+// to at most n terminal columns.
+func (s Style) MaxWidth(n int) Style {
+	s.maxWidth = n
+	return s
+}
+
+// UnsetMaxWidth returns a copy of the Style without a width limit.
+func (s Style) UnsetMaxWidth() Style {
+	s.maxWidth = 0
+	return s
+}
+
+// SetString returns a copy of the Style holding a preset text, which String
+// renders and Render prepends to its arguments.
+func (s Style) SetString(v string) Style {
+	s.value = v
+	return s
+}
+
+// Value returns the preset text set by SetString.
+func (s Style) Value() string { return s.value }
+
+// String renders the preset text set by SetString.
+func (s Style) String() string { return s.Render() }
+
+// Render applies the Style to the given strings, joined by spaces.
+func (s Style) Render(strs ...string) string {
+	text := s.value
+	if len(strs) > 0 {
+		if text != "" {
+			text += " "
+		}
+		text += strings.Join(strs, " ")
+	}
+	if s.maxWidth > 0 {
+		text = truncateLines(text, s.maxWidth)
+	}
+	if s.seq == "" || text == "" {
+		return text
+	}
+	return s.seq + text + _ansiReset
+}
+
+// appendRender appends text followed by suffix, rendered with the Style, to
+// b. It produces the same bytes as Render(text + suffix).
+//
+// Performance Note: The escape sequences opening and closing the Style are
+// computed when it is built, so for Styles without a preset text or width
+// limit this copies them around the raw text without building any strings.
+func (s *Style) appendRender(b []byte, text, suffix string) []byte {
+	if s.value != "" || s.maxWidth > 0 {
+		return append(b, s.Render(text+suffix)...)
+	}
+	if s.seq == "" || (text == "" && suffix == "") {
+		b = append(b, text...)
+		return append(b, suffix...)
+	}
+	b = append(b, s.seq...)
+	b = append(b, text...)
+	b = append(b, suffix...)
+	return append(b, _ansiReset...)
+}
+
+// _ansiReset is the escape sequence closing every styled text.
+const _ansiReset = "\x1b[0m"
+
+// attr toggles the SGR attribute code c.
+func (s Style) attr(c byte, v bool) Style {
+	i := slices.Index(s.attrs, c)
+	switch {
+	case v && i < 0:
+		s.attrs = append(s.attrs[:len(s.attrs):len(s.attrs)], c)
+	case !v && i >= 0:
+		s.attrs = append(s.attrs[:i:i], s.attrs[i+1:]...)
+	}
+	return s.build()
+}
+
+// build recomputes the escape sequence opening the Style.
+func (s Style) build() Style {
+	s.seq = ""
+	if s.profile == NoColor {
+		return s
+	}
+	var params []byte
+	for _, c := range s.attrs {
+		if len(params) > 0 {
+			params = append(params, ';')
+		}
+		params = append(params, c)
+	}
+	params = appendColorParams(params, s.fg, s.profile, false)
+	params = appendColorParams(params, s.bg, s.profile, true)
+	if len(params) > 0 {
+		s.seq = "\x1b[" + string(params) + "m"
+	}
+	return s
+}
+
+// appendColorParams appends the SGR parameters selecting c as a foreground or
+// background color, degraded to what profile p can display.
+func appendColorParams(params []byte, c Color, p ColorProfile, background bool) []byte {
+	if c == "" {
+		return params
+	}
+	var r, g, b uint8
+	index := -1
+	if c[0] == '#' {
+		v, err := strconv.ParseUint(string(c[1:]), 16, 32)
+		if err != nil || len(c) != 7 {
+			return params
+		}
+		r, g, b = uint8(v>>16), uint8(v>>8), uint8(v)
+	} else {
+		n, err := strconv.Atoi(string(c))
+		if err != nil || n < 0 || n > 255 {
+			return params
+		}
+		index = n
+		r, g, b = paletteRGB(n)
+	}
+
+	if len(params) > 0 {
+		params = append(params, ';')
+	}
+	base := 30
+	if background {
+		base = 40
+	}
+	switch {
+	case p == TrueColor && index < 0:
+		params = strconv.AppendInt(params, int64(base+8), 10)
+		params = append(params, ";2;"...)
+		params = strconv.AppendUint(params, uint64(r), 10)
+		params = append(params, ';')
+		params = strconv.AppendUint(params, uint64(g), 10)
+		params = append(params, ';')
+		params = strconv.AppendUint(params, uint64(b), 10)
+		return params
+	case p >= ANSI256Color && index >= 16, p >= ANSI256Color && index < 0:
+		if index < 0 {
+			index = nearestColor(r, g, b, 16, 256)
+		}
+		params = strconv.AppendInt(params, int64(base+8), 10)
+		params = append(params, ";5;"...)
+		return strconv.AppendInt(params, int64(index), 10)
+	}
+	if index < 0 || index >= 16 {
+		index = ansi16(r, g, b)
+	}
+	if index >= 8 {
+		// Bright colors use the 90 and 100 ranges.
+		return strconv.AppendInt(params, int64(base+60+index-8), 10)
+	}
+	return strconv.AppendInt(params, int64(base+index), 10)
+}
+
+// _ansiRGB holds the conventional xterm values of the 16 standard colors.
+var _ansiRGB = [16][3]uint8{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+	{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// paletteRGB returns the RGB value of entry n of the xterm 256 color palette.
+func paletteRGB(n int) (r, g, b uint8) {
+	switch {
+	case n < 16:
+		c := _ansiRGB[n]
+		return c[0], c[1], c[2]
+	case n < 232:
+		n -= 16
+		level := func(v int) uint8 {
+			if v == 0 {
+				return 0
+			}
+			return uint8(55 + v*40)
+		}
+		return level(n / 36), level(n / 6 % 6), level(n % 6)
+	default:
+		v := uint8(8 + (n-232)*10)
+		return v, v, v
+	}
+}
+
+// ansi16 maps an RGB value to one of the 16 standard colors.
+//
+// Distance matching would turn most pastel colors gray, so it instead keeps
+// the hue: each channel at or above half the strongest one is switched on,
+// and bright variants are used for light colors. Near-grays map to the four
+// gray levels.
+func ansi16(r, g, b uint8) int {
+	hi, lo := max(r, g, b), min(r, g, b)
+	if hi-lo < 40 {
+		switch l := (int(hi) + int(lo)) / 2; {
+		case l < 48:
+			return 0
+		case l < 128:
+			return 8
+		case l < 208:
+			return 7
+		default:
+			return 15
+		}
+	}
+	index := 0
+	for bit, v := range [3]uint8{r, g, b} {
+		if int(v)*2 >= int(hi) {
+			index |= 1 << bit
+		}
+	}
+	if hi > 191 {
+		index += 8
+	}
+	return index
+}
+
+// nearestColor returns the palette index in [lo, hi) closest to the RGB value.
+func nearestColor(r, g, b uint8, lo, hi int) int {
+	best, bestDist := lo, -1
+	for i := lo; i < hi; i++ {
+		pr, pg, pb := paletteRGB(i)
+		dr, dg, db := int(r)-int(pr), int(g)-int(pg), int(b)-int(pb)
+		// Weights approximate the eye's sensitivity to each channel.
+		if d := 3*dr*dr + 4*dg*dg + 2*db*db; bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// truncateLines cuts every line of s to at most n terminal columns.
+func truncateLines(s string, n int) string {
+	if !strings.Contains(s, "\n") {
+		return runewidth.Truncate(s, n, "")
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = runewidth.Truncate(line, n, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// textWidth returns the number of terminal columns s occupies, ignoring ANSI
+// escape sequences. For multi-line strings it returns the widest line.
+func textWidth(s string) int {
+	if strings.IndexByte(s, '\x1b') < 0 && !strings.Contains(s, "\n") {
+		return runewidth.StringWidth(s)
+	}
+	widest, width := 0, 0
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '\x1b' && i+1 < len(s) && s[i+1] == '[':
+			// Skip a CSI sequence up to its final byte.
+			i += 2
+			for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+				i++
+			}
+			i++
+		case c == '\n':
+			widest, width = max(widest, width), 0
+			i++
+		default:
+			j := i + 1
+			for j < len(s) && s[j] != '\x1b' && s[j] != '\n' {
+				j++
+			}
+			width += runewidth.StringWidth(s[i:j])
+			i = j
+		}
+	}
+	return max(widest, width)
+}
@@ -20,86 +20,144 @@
 
 package velo
 
-import (
-	"strings"
-
-	"github.com/charmbracelet/lipgloss"
-)
+import "maps"
 
 // Styles defines the visual appearance of log entries when using the TextFormatter.
 //
-// It leverages the lipgloss library to provide rich, customizable terminal styling
-// for timestamps, levels, messages, keys, values, and stack traces.
+// It uses the built-in Style type to provide customizable terminal styling for
+// timestamps, levels, messages, keys, values, and stack traces. The velolipgloss
+// module converts lipgloss styles for applications that already use them.
 type Styles struct {
-	Timestamp lipgloss.Style
-	Caller    lipgloss.Style
-	Prefix    lipgloss.Style
-	Message   lipgloss.Style
-	Key       lipgloss.Style
-	Value     lipgloss.Style
-	Separator lipgloss.Style
-	StackFunc lipgloss.Style
-	StackFile lipgloss.Style
-	Levels    map[Level]lipgloss.Style
-	Keys      map[string]lipgloss.Style
-	Values    map[string]lipgloss.Style
+	Timestamp Style
+	Caller    Style
+	Prefix    Style
+	Message   Style
+	Key       Style
+	Value     Style
+	Separator Style
+	StackFunc Style
+	StackFile Style
+	Levels    map[Level]Style
+	Keys      map[string]Style
+	Values    map[string]Style
+
+	// ErrorMessage replaces Message for entries at ErrorLevel and above.
+	ErrorMessage Style
+
+	// ErrorValue styles the values of error fields, unless Values has an
+	// override for the key.
+	ErrorValue Style
+
+	// KeyValueSeparator is written between a key and its value, styled with
+	// Separator. It defaults to "=", and ": " is a common alternative.
+	KeyValueSeparator string
+
+	// FieldSeparator is written between consecutive fields. It defaults to a
+	// single space.
+	FieldSeparator string
+
+	// FieldsOpen and FieldsClose, when set, enclose the fields of an entry,
+	// as in "msg [k=v k2=v2]". Entries without fields are written without them.
+	FieldsOpen  string
+	FieldsClose string
+
+	// LevelLabels overrides the text rendered for a level, such as "WRN" or a
+	// localized word, while keeping the level's style from Levels. Labels are
+	// never cut unless the level's style sets MaxWidth.
+	LevelLabels map[Level]string
+
+	// LevelIcons maps levels to an icon, such as "✖" for ErrorLevel, that is
+	// rendered in the level's color before its label.
+	LevelIcons map[Level]string
 
 	// CachedLevelStrings stores the rendered level strings to avoid rendering again on every log.
 	// This optimization significantly improves text formatting performance.
+	// It is derived from Levels, LevelLabels, and LevelIcons the first time
+	// the Styles are used; reset it to nil after changing any of them.
 	CachedLevelStrings map[Level]string
+
+	// levelWidth is the visible width of the widest level label, used by TextLayout.AlignLevels.
+	levelWidth int
 }
 
 // DefaultStyles initializes and returns the standard styling configuration.
 //
-// It provides a clean, readable, and color coded default appearance for text logs.
+// It provides a clean, readable, and color coded default appearance for text
+// logs. It honors the NO_COLOR, CLICOLOR, CLICOLOR_FORCE, and FORCE_COLOR
+// environment conventions, and otherwise enables colors only when standard
+// output is a terminal.
 func DefaultStyles() *Styles {
+	return newStyles(_stdoutProfile())
+}
+
+// PlainStyles returns the default styling configuration with all ANSI escape
+// sequences disabled.
+//
+// Labels keep their shape (for example, the four character level names), so
+// plain output lines up with colored output. Use this for files, pipes, and
+// golden tests.
+func PlainStyles() *Styles {
+	return newStyles(NoColor)
+}
+
+// newStyles builds the default styling configuration for the color profile p.
+func newStyles(p ColorProfile) *Styles {
+	base := Style{profile: p}
 	s := &Styles{
-		Timestamp: lipgloss.NewStyle(),
-		Caller:    lipgloss.NewStyle().Faint(true),
-		Prefix:    lipgloss.NewStyle().Bold(true).Faint(true),
-		Message:   lipgloss.NewStyle(),
-		Key:       lipgloss.NewStyle().Faint(true),
-		Value:     lipgloss.NewStyle(),
-		Separator: lipgloss.NewStyle().Faint(true),
-		StackFunc: lipgloss.NewStyle().Foreground(lipgloss.Color("252")),
-		StackFile: lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
-		Levels: map[Level]lipgloss.Style{
-			DebugLevel: lipgloss.NewStyle().
-				SetString(strings.ToUpper(DebugLevel.String())).
+		Timestamp:    base,
+		Caller:       base.Faint(true),
+		Prefix:       base.Bold(true).Faint(true),
+		Message:      base,
+		ErrorMessage: base.Bold(true),
+		Key:          base.Faint(true),
+		Value:        base,
+		ErrorValue:   base.Foreground(Color("204")),
+		Separator:    base.Faint(true),
+		StackFunc:    base.Foreground(Color("252")),
+		StackFile:    base.Foreground(Color("240")),
+		Levels: map[Level]Style{
+			DebugLevel: base.
+				SetString("DEBU").
+				Bold(true).
+				Foreground(Color("63")),
+			InfoLevel: base.
+				SetString("INFO").
 				Bold(true).
-				MaxWidth(4).
-				Foreground(lipgloss.Color("63")),
-			InfoLevel: lipgloss.NewStyle().
-				SetString(strings.ToUpper(InfoLevel.String())).
+				Foreground(Color("86")),
+			WarnLevel: base.
+				SetString("WARN").
 				Bold(true).
-				MaxWidth(4).
-				Foreground(lipgloss.Color("86")),
-			WarnLevel: lipgloss.NewStyle().
-				SetString(strings.ToUpper(WarnLevel.String())).
+				Foreground(Color("192")),
+			ErrorLevel: base.
+				SetString("ERRO").
 				Bold(true).
-				MaxWidth(4).
-				Foreground(lipgloss.Color("192")),
-			ErrorLevel: lipgloss.NewStyle().
-				SetString(strings.ToUpper(ErrorLevel.String())).
+				Foreground(Color("204")),
+			DPanicLevel: base.
+				SetString("DPAN").
 				Bold(true).
-				MaxWidth(4).
-				Foreground(lipgloss.Color("204")),
-			FatalLevel: lipgloss.NewStyle().
-				SetString(strings.ToUpper(FatalLevel.String())).
+				Foreground(Color("204")),
+			PanicLevel: base.
+				SetString("PANI").
 				Bold(true).
-				MaxWidth(4).
-				Foreground(lipgloss.Color("134")),
+				Foreground(Color("134")),
+			FatalLevel: base.
+				SetString("FATA").
+				Bold(true).
+				Foreground(Color("134")),
 		},
-		Keys:   map[string]lipgloss.Style{},
-		Values: map[string]lipgloss.Style{},
+		Keys:   map[string]Style{},
+		Values: map[string]Style{},
 	}
 
-	s.CachedLevelStrings = make(map[Level]string, len(s.Levels))
-	for l, style := range s.Levels {
-		s.CachedLevelStrings[l] = style.String()
-	}
+	return prepareStyles(s)
+}
 
-	return s
+// messageStyle returns the style for the message of an entry at level.
+func (s *Styles) messageStyle(level Level) *Style {
+	if level >= ErrorLevel && level != noLevel {
+		return &s.ErrorMessage
+	}
+	return &s.Message
 }
 
 // SetDefaultStyles overrides the global default styles for the TextFormatter.
@@ -109,12 +167,53 @@ func SetDefaultStyles(s *Styles) {
 	if s == nil {
 		return
 	}
-	// Ensure CachedLevelStrings is populated
+	_defaultStyles = prepareStyles(s)
+}
+
+// prepareStyles ensures CachedLevelStrings and the level label width are
+// populated before s is used for formatting.
+func prepareStyles(s *Styles) *Styles {
 	if s.CachedLevelStrings == nil {
-		s.CachedLevelStrings = make(map[Level]string, len(s.Levels))
+		s.levelWidth = 0
+		s.CachedLevelStrings = make(map[Level]string, len(s.Levels)+len(s.LevelLabels)+len(s.LevelIcons))
 		for l, style := range s.Levels {
+			if label, ok := s.LevelLabels[l]; ok {
+				style = style.SetString(label)
+			}
 			s.CachedLevelStrings[l] = style.String()
 		}
+		for l, label := range s.LevelLabels {
+			if _, ok := s.Levels[l]; !ok {
+				s.CachedLevelStrings[l] = label
+			}
+		}
+		for l, icon := range s.LevelIcons {
+			if style, ok := s.Levels[l]; ok {
+				icon = style.UnsetMaxWidth().SetString(icon).String()
+			}
+			if label, ok := s.CachedLevelStrings[l]; ok {
+				icon += " " + label
+			}
+			s.CachedLevelStrings[l] = icon
+		}
+	}
+	if s.levelWidth == 0 {
+		for _, label := range s.CachedLevelStrings {
+			s.levelWidth = max(s.levelWidth, textWidth(label))
+		}
+	}
+	return s
+}
+
+// withLevelLabels returns a copy of s whose LevelLabels are extended by labels.
+func withLevelLabels(s *Styles, labels map[Level]string) *Styles {
+	c := *s
+	c.LevelLabels = maps.Clone(s.LevelLabels)
+	if c.LevelLabels == nil {
+		c.LevelLabels = make(map[Level]string, len(labels))
 	}
-	_defaultStyles = s
+	maps.Copy(c.LevelLabels, labels)
+	c.CachedLevelStrings = nil
+	c.levelWidth = 0
+	return prepareStyles(&c)
 }
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import "strings"
+
+// Logt writes a message at the specified level from a template whose
+// placeholders name the keys of fields, such as
+// "user {user_id} purchased {sku}".
+//
+// The TextFormatter writes the message with every placeholder replaced by the
+// value of the last field of the entry with that key, so that a field given
+// here takes precedence over one added with With, rendered as it would be
+// among the fields, so that lines read naturally. Everything else, from the
+// sampler, hooks, processors, and observers to the JSON and binary formats
+// and any Core, sees the template itself as the message, so that entries
+// from the same call site share one message for sampling, grouping, and
+// aggregation. In every format the fields are also written as usual.
+//
+// A placeholder without a matching field is left as is. Write "{{" for a
+// literal brace where it would otherwise start a placeholder.
+//
+// Performance Note: With the TextFormatter, a template takes the Entry path,
+// like a Logger with hooks, and is expanded into a pooled buffer as the line
+// is written. Other formats log the template directly, at the cost of the
+// Fields methods.
+func (l *Logger) Logt(level Level, template string, fields ...Field) {
+	l.logt(0, level, template, fields)
+}
+
+// logt logs fields with template as the message, marking the entry for
+// expansion when the TextFormatter writes it. It stands in for logFields.
+func (l *Logger) logt(skip int, level Level, template string, fields []Field) {
+	if l == nil {
+		return
+	}
+	cfg := l.config.Load()
+	if cfg.formatter != TextFormatter || cfg.core != nil || strings.IndexByte(template, '{') < 0 {
+		l.logFields(skip+1, level, template, fields)
+		return
+	}
+	if l.level.val.Load() > int64(level) {
+		if l.trigger != nil {
+			l.hold(skip, nil, level, template, nil, fields, true)
+		}
+		return
+	}
+	if cfg.fieldMetrics != nil {
+		recordMetrics(cfg.fieldMetrics, fields)
+	}
+
+	t, fields := cfg.stamp(fields)
+	if !l.sample(cfg, level, template, t) {
+		return
+	}
+	l.logWithEntry(skip, level, template, nil, fields, nil, cfg, t, true)
+}
+
+// appendTemplate appends the template message of e to b with each {key}
+// placeholder replaced by the text value of the last field of e with that
+// key.
+func appendTemplate(b []byte, e *Entry) []byte {
+	template := e.Message
+	for {
+		i := strings.IndexByte(template, '{')
+		if i < 0 {
+			return append(b, template...)
+		}
+		b = append(b, template[:i]...)
+		template = template[i:]
+		if strings.HasPrefix(template, "{{") {
+			b = append(b, '{')
+			template = template[2:]
+			continue
+		}
+		end := strings.IndexByte(template, '}')
+		if end < 0 {
+			return append(b, template...)
+		}
+		key := template[1:end]
+		if f := templateField(e.TypedFields, key); f != nil {
+			b = append(b, textFieldValue(f, e.fieldFormat())...)
+		} else if v, ok := templateKeyVal(e.Fields, key); ok {
+			b = append(b, textValue(v, e.fieldFormat())...)
+		} else {
+			b = append(b, template[:end+1]...)
+		}
+		template = template[end+1:]
+	}
+}
+
+// templateField returns the last field with key, or nil.
+func templateField(fields []Field, key string) *Field {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if fields[i].Key == key {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// templateKeyVal returns the value of the last loosely typed pair with key.
+func templateKeyVal(keyvals []any, key string) (any, bool) {
+	for i := len(keyvals)&^1 - 2; i >= 0; i -= 2 {
+		if k, ok := keyvals[i].(string); ok && k == key {
+			return keyvals[i+1], true
+		}
+	}
+	return nil, false
+}
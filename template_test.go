@@ -0,0 +1,77 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogt(t *testing.T) {
+	var buf bytes.Buffer
+	var messages []string
+	l := New(&buf, WithCaller(), WithHooks(HookFunc(func(e *Entry) error {
+		messages = append(messages, e.Message)
+		return nil
+	}))).With("user_id", 7, "region", "eu")
+
+	l.Infot("user {user_id} bought {sku} in {region}, {{literal}, {missing}", String("sku", "A-1"), Int("user_id", 42))
+	want := "INFO <template_test.go:38> user 42 bought A-1 in eu, {literal}, {missing} user_id=7 region=eu sku=A-1 user_id=42\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if len(messages) != 1 || messages[0] != "user {user_id} bought {sku} in {region}, {{literal}, {missing}" {
+		t.Errorf("hook saw messages %q, want the template", messages)
+	}
+}
+
+func TestLogtJSONKeepsTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, WithFormatter(JSONFormatter)).Infot("user {user_id}", Int("user_id", 42))
+	if want := `"msg":"user {user_id}","user_id":42}`; !strings.Contains(buf.String(), want) {
+		t.Errorf("got %s, want %s", buf.String(), want)
+	}
+}
+
+// TestLogtSamplesByTemplate checks that the sampler counts every expansion of
+// a template as the same message.
+func TestLogtSamplesByTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSamplerWithOptions(New(&buf), time.Minute, 2, 0)
+	for i := range 10 {
+		l.Infot("user {user_id}", Int("user_id", i))
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 2 {
+		t.Errorf("wrote %d entries, want the 2 the sampler lets through:\n%s", n, buf.String())
+	}
+}
+
+func TestLogtHeldByTrigger(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithTriggerBuffer(8, ErrorLevel))
+	l.Debugt("user {user_id}", Int("user_id", 42))
+	l.Error("failed")
+	if want := "DEBU user 42 user_id=42\nERRO failed\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TenantOptions configures NewTenantRouter.
+type TenantOptions struct {
+	// NewWriter opens the destination of a tenant, such as a file named
+	// after it. It is called the first time the tenant logs, and again after
+	// its destination was closed to honor MaxTenants. A destination that is
+	// an io.Closer is closed when it is evicted and when the Logger using the
+	// router is closed.
+	NewWriter func(tenant string) (io.Writer, error)
+
+	// Options returns the options of the Logger formatting a tenant's
+	// entries, for example to attach the tenant as a label for Loki with
+	// WithFields. It may be nil.
+	Options func(tenant string) []Option
+
+	// Level is the minimum level written for any tenant. Options may raise
+	// it for some tenants with WithLevel.
+	Level Level
+
+	// Fallback receives entries without the tenant field. If it is nil they
+	// are discarded.
+	Fallback Core
+
+	// Quota limits what each tenant may write, unless Quotas lists the
+	// tenant. The zero value imposes no limit.
+	Quota TenantQuota
+
+	// Quotas overrides Quota for individual tenants.
+	Quotas map[string]TenantQuota
+
+	// MaxTenants caps the number of open destinations. Opening another one
+	// closes the destination of the tenant that logged least recently. Zero
+	// leaves every destination open until the router is closed.
+	MaxTenants int
+}
+
+// TenantQuota limits the entries and bytes a tenant may write per window.
+// Entries beyond either limit are dropped until the window ends. Bytes are
+// counted as they are written, so the entry that crosses the limit is still
+// written in full.
+type TenantQuota struct {
+	// Entries is the number of entries allowed per Window. Zero means no limit.
+	Entries int
+	// Bytes is the number of formatted bytes allowed per Window. Zero means
+	// no limit.
+	Bytes int64
+	// Window is the period over which the limits apply. It defaults to one
+	// second.
+	Window time.Duration
+}
+
+// TenantStats counts what a tenant has logged through a TenantRouter.
+type TenantStats struct {
+	// Written is the number of entries written.
+	Written uint64
+	// Dropped is the number of entries dropped by the tenant's quota.
+	Dropped uint64
+	// Bytes is the number of formatted bytes written.
+	Bytes uint64
+}
+
+// TenantRouter is a Core that sends each entry to the destination of its
+// tenant, named by a designated field, and enforces per-tenant quotas. It
+// lets one Logger serve thousands of tenants, where keeping a Logger per
+// tenant does not scale:
+//
+//	router := velo.NewTenantRouter("tenant", velo.TenantOptions{
+//	  NewWriter: func(tenant string) (io.Writer, error) {
+//	    return os.OpenFile(filepath.Join(dir, tenant+".log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+//	  },
+//	  Options:    func(string) []velo.Option { return []velo.Option{velo.WithFormatter(velo.JSONFormatter)} },
+//	  Quota:      velo.TenantQuota{Entries: 1000, Bytes: 1 << 20},
+//	  MaxTenants: 512,
+//	})
+//	logger := velo.NewWithCore(router)
+//	logger.Info("order placed", "tenant", "acme")
+//
+// Like NewRouter, the tenant is the text of the last field named by the key.
+// Use a child Logger, such as logger.With("tenant", id), to bind a request
+// to its tenant.
+type TenantRouter struct {
+	key  string
+	opts TenantOptions
+
+	mu      sync.RWMutex
+	tenants map[string]*tenant
+	open    int
+	closed  bool
+}
+
+// tenant is the destination, quota window, and counters of one tenant. The
+// counters outlive the destination when it is evicted.
+type tenant struct {
+	name  string
+	quota TenantQuota
+
+	mu      sync.RWMutex
+	core    Core
+	closer  io.Closer
+	lastUse atomic.Int64
+
+	windowEnd atomic.Int64
+	entries   atomic.Int64
+	bytes     atomic.Int64
+
+	written atomic.Uint64
+	dropped atomic.Uint64
+	total   atomic.Uint64
+}
+
+// NewTenantRouter returns a TenantRouter selecting tenants by the field named
+// key.
+func NewTenantRouter(key string, o TenantOptions) *TenantRouter {
+	return &TenantRouter{key: key, opts: o, tenants: make(map[string]*tenant)}
+}
+
+// Enabled reports whether entries at level are written for any tenant or by
+// the fallback.
+func (r *TenantRouter) Enabled(level Level) bool {
+	return level >= r.opts.Level || (r.opts.Fallback != nil && r.opts.Fallback.Enabled(level))
+}
+
+// With returns a Core that adds fields to every entry before routing it.
+func (r *TenantRouter) With(fields []Field) Core {
+	return &tenantView{r: r, fields: slices.Clone(fields)}
+}
+
+// Write sends e to its tenant's destination, opening it if needed, unless
+// the tenant has exhausted its quota.
+func (r *TenantRouter) Write(e *Entry) error {
+	name, ok := lookupFields(r.key, e.Fields, e.TypedFields)
+	if !ok {
+		if f := r.opts.Fallback; f != nil && f.Enabled(e.Level) {
+			return f.Write(e)
+		}
+		return nil
+	}
+	if e.Level < r.opts.Level {
+		return nil
+	}
+
+	t := r.tenant(name)
+	now := time.Now().UnixNano()
+	if !t.allow(now) {
+		t.dropped.Add(1)
+		return nil
+	}
+	t.lastUse.Store(now)
+
+	for {
+		t.mu.RLock()
+		if c := t.core; c != nil {
+			var err error
+			if c.Enabled(e.Level) {
+				if err = c.Write(e); err == nil {
+					t.written.Add(1)
+				}
+			}
+			t.mu.RUnlock()
+			return err
+		}
+		t.mu.RUnlock()
+		// The destination may be evicted again before it is used, in which
+		// case it is reopened.
+		if err := r.openTenant(t); err != nil {
+			return err
+		}
+	}
+}
+
+// Sync flushes the fallback and every open destination, returning all
+// errors joined.
+func (r *TenantRouter) Sync() error {
+	var errs []error
+	if f := r.opts.Fallback; f != nil {
+		errs = append(errs, f.Sync())
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, t := range r.tenants {
+		t.mu.RLock()
+		if t.core != nil {
+			errs = append(errs, t.core.Sync())
+		}
+		t.mu.RUnlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Stats returns the counters of every tenant that has logged.
+func (r *TenantRouter) Stats() map[string]TenantStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stats := make(map[string]TenantStats, len(r.tenants))
+	for name, t := range r.tenants {
+		stats[name] = TenantStats{Written: t.written.Load(), Dropped: t.dropped.Load(), Bytes: t.total.Load()}
+	}
+	return stats
+}
+
+func (r *TenantRouter) close() {
+	if f := r.opts.Fallback; f != nil {
+		closeCore(f)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	for _, t := range r.tenants {
+		t.mu.Lock()
+		t.shut()
+		t.mu.Unlock()
+	}
+	r.open = 0
+}
+
+// tenant returns the state of the tenant called name, creating it if needed.
+func (r *TenantRouter) tenant(name string) *tenant {
+	r.mu.RLock()
+	t, ok := r.tenants[name]
+	r.mu.RUnlock()
+	if ok {
+		return t
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.tenants[name]; ok {
+		return t
+	}
+	t = &tenant{name: name, quota: r.opts.Quota}
+	if q, ok := r.opts.Quotas[name]; ok {
+		t.quota = q
+	}
+	if t.quota.Window <= 0 {
+		t.quota.Window = time.Second
+	}
+	r.tenants[name] = t
+	return t
+}
+
+// openTenant opens the destination of t, first closing the least recently
+// used one if MaxTenants destinations are open.
+func (r *TenantRouter) openTenant(t *tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return errors.New("velo: TenantRouter is closed")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.core != nil {
+		return nil
+	}
+	if r.opts.MaxTenants > 0 && r.open >= r.opts.MaxTenants {
+		r.evict(t)
+	}
+
+	w, err := r.opts.NewWriter(t.name)
+	if err != nil {
+		return err
+	}
+	opts := []Option{WithLevel(r.opts.Level)}
+	if r.opts.Options != nil {
+		opts = append(opts, r.opts.Options(t.name)...)
+	}
+	t.core = New(&tenantWriter{w: w, t: t}, opts...).Core()
+	t.closer, _ = w.(io.Closer)
+	r.open++
+	return nil
+}
+
+// evict closes the open destination, other than that of keep, whose tenant
+// logged least recently. r.mu must be held.
+func (r *TenantRouter) evict(keep *tenant) {
+	var oldest *tenant
+	for _, t := range r.tenants {
+		if t == keep {
+			continue
+		}
+		if oldest == nil || t.lastUse.Load() < oldest.lastUse.Load() {
+			t.mu.RLock()
+			open := t.core != nil
+			t.mu.RUnlock()
+			if open {
+				oldest = t
+			}
+		}
+	}
+	if oldest != nil {
+		oldest.mu.Lock()
+		oldest.shut()
+		oldest.mu.Unlock()
+		r.open--
+	}
+}
+
+// shut closes the destination of t. t.mu must be held for writing, so that
+// no Write is using the destination.
+func (t *tenant) shut() {
+	if t.core == nil {
+		return
+	}
+	t.core.Sync()
+	closeCore(t.core)
+	if t.closer != nil {
+		t.closer.Close()
+	}
+	t.core, t.closer = nil, nil
+}
+
+// allow reports whether t may write another entry at now, starting a new
+// quota window if the current one has ended.
+func (t *tenant) allow(now int64) bool {
+	q := &t.quota
+	if q.Entries == 0 && q.Bytes == 0 {
+		return true
+	}
+	if end := t.windowEnd.Load(); now >= end && t.windowEnd.CompareAndSwap(end, now+int64(q.Window)) {
+		t.entries.Store(0)
+		t.bytes.Store(0)
+	}
+	if q.Bytes > 0 && t.bytes.Load() >= q.Bytes {
+		return false
+	}
+	return q.Entries == 0 || t.entries.Add(1) <= int64(q.Entries)
+}
+
+// tenantWriter counts the bytes written to a tenant's destination.
+type tenantWriter struct {
+	w io.Writer
+	t *tenant
+}
+
+func (w *tenantWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.t.bytes.Add(int64(n))
+	w.t.total.Add(uint64(n))
+	return n, err
+}
+
+func (w *tenantWriter) Sync() error {
+	if s, ok := w.w.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// tenantView is a TenantRouter that adds fields to every entry.
+type tenantView struct {
+	r      *TenantRouter
+	fields []Field
+}
+
+func (v *tenantView) Enabled(level Level) bool { return v.r.Enabled(level) }
+
+func (v *tenantView) With(fields []Field) Core {
+	return &tenantView{r: v.r, fields: append(slices.Clip(v.fields), fields...)}
+}
+
+// Write routes e with the view's fields ahead of its own, restoring e
+// afterwards.
+func (v *tenantView) Write(e *Entry) error {
+	own := e.TypedFields
+	e.TypedFields = append(slices.Clip(v.fields), own...)
+	err := v.r.Write(e)
+	e.TypedFields = own
+	return err
+}
+
+func (v *tenantView) Sync() error { return v.r.Sync() }
+
+func (v *tenantView) close() { v.r.close() }
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTenantRouterLevel(t *testing.T) {
+	var buf bytes.Buffer
+	router := NewTenantRouter("tenant", TenantOptions{
+		NewWriter: func(string) (io.Writer, error) { return &buf, nil },
+		Level:     DebugLevel,
+	})
+	l := NewWithCore(router, WithLevel(DebugLevel))
+	l.Debug("dbg", "tenant", "acme")
+	l.Info("info", "tenant", "acme")
+	l.Sync()
+
+	if n := strings.Count(buf.String(), "\n"); n != 2 {
+		t.Errorf("wrote %q, want both entries", buf.String())
+	}
+	if got := router.Stats()["acme"].Written; got != 2 {
+		t.Errorf("Written = %d, want 2", got)
+	}
+}
+
+func TestTenantRouterCountsWrittenEntries(t *testing.T) {
+	var buf bytes.Buffer
+	router := NewTenantRouter("tenant", TenantOptions{
+		NewWriter: func(string) (io.Writer, error) { return &buf, nil },
+		Options:   func(string) []Option { return []Option{WithLevel(WarnLevel)} },
+	})
+	l := NewWithCore(router)
+	l.Info("info", "tenant", "acme")
+	l.Warn("warn", "tenant", "acme")
+	l.Sync()
+
+	if n := strings.Count(buf.String(), "\n"); n != 1 {
+		t.Errorf("wrote %q, want only the WarnLevel entry", buf.String())
+	}
+	if got := router.Stats()["acme"].Written; got != 1 {
+		t.Errorf("Written = %d, want 1", got)
+	}
+}
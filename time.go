@@ -20,7 +20,10 @@
 
 package velo
 
-import "time"
+import (
+	"sync/atomic"
+	"time"
+)
 
 var _smallsString = "00010203040506070809" +
 	"10111213141516171819" +
@@ -244,7 +247,137 @@ func appendTime(b []byte, t time.Time, format string) []byte {
 			b = append(b, _smallsString[i], _smallsString[i+1])
 		}
 		return b
+	case DefaultTimeFormatMilli:
+		b = appendTime(b, t, DefaultTimeFormat)
+		b = append(b, '.')
+		return appendInt(b, t.Nanosecond()/1e6, 3)
+	case DefaultTimeFormatMicro:
+		b = appendTime(b, t, DefaultTimeFormat)
+		b = append(b, '.')
+		return appendInt(b, t.Nanosecond()/1e3, 6)
+	case "unix":
+		return appendInt64(b, t.Unix())
+	case "unix_milli":
+		return appendInt64(b, t.UnixMilli())
+	case "unix_micro":
+		return appendInt64(b, t.UnixMicro())
+	case "unix_nano":
+		return appendInt64(b, t.UnixNano())
 	default:
 		return t.AppendFormat(b, format)
 	}
 }
+
+// unixFormat reports whether format renders a bare integer, which JSON output
+// writes unquoted.
+func unixFormat(format string) bool {
+	switch format {
+	case "unix", "unix_milli", "unix_micro", "unix_nano":
+		return true
+	}
+	return false
+}
+
+// timeCache holds the most recently rendered timestamp for formats with
+// second resolution, so entries logged within the same second reuse it
+// instead of formatting and styling the time again.
+//
+// Performance Note: A hit costs an atomic load and a few comparisons. The
+// cache is replaced, not mutated, so concurrent loggers never block.
+type timeCache struct {
+	last atomic.Pointer[cachedTime]
+}
+
+// cachedTime is one rendered timestamp.
+type cachedTime struct {
+	sec    int64
+	loc    *time.Location
+	format string
+	styles *Styles
+	raw    []byte
+	styled string
+}
+
+// secondResolution reports whether format renders nothing finer than a second.
+func secondResolution(format string) bool {
+	switch format {
+	case DefaultTimeFormat, time.RFC3339, time.DateTime:
+		return true
+	}
+	return false
+}
+
+// lookup returns the rendering of t in format, styled with st unless st is
+// nil. It returns nil if format is not cacheable or c is nil.
+func (c *timeCache) lookup(t time.Time, format string, st *Styles) *cachedTime {
+	if c == nil || !secondResolution(format) {
+		return nil
+	}
+	sec, loc := t.Unix(), t.Location()
+	if ct := c.last.Load(); ct != nil && ct.sec == sec && ct.loc == loc && ct.format == format && ct.styles == st {
+		return ct
+	}
+	ct := &cachedTime{sec: sec, loc: loc, format: format, styles: st}
+	ct.raw = appendTime(nil, t, format)
+	if st != nil {
+		ct.styled = st.Timestamp.Render(string(ct.raw))
+	}
+	c.last.Store(ct)
+	return ct
+}
+
+// appendTime appends t in format to b, using the cache when possible.
+func (c *timeCache) appendTime(b []byte, t time.Time, format string) []byte {
+	if ct := c.lookup(t, format, nil); ct != nil {
+		return append(b, ct.raw...)
+	}
+	return appendTime(b, t, format)
+}
+
+// render returns t in format styled with st, using the cache when possible.
+func (c *timeCache) render(t time.Time, format string, st *Styles) string {
+	if ct := c.lookup(t, format, st); ct != nil {
+		return ct.styled
+	}
+	var buf [64]byte
+	return st.Timestamp.Render(string(appendTime(buf[:0], t, format)))
+}
+
+// fieldFormat is how a Logger writes field values: Time and Times values in
+// layout and, unless loc is nil, converted to loc, and durations in
+// durations.
+type fieldFormat struct {
+	layout    string
+	loc       *time.Location
+	durations DurationEncoding
+}
+
+// in converts t to the location of ff, if it has one.
+func (ff fieldFormat) in(t time.Time) time.Time {
+	if ff.loc != nil {
+		return t.In(ff.loc)
+	}
+	return t
+}
+
+// timeZone keeps the location of the value of a Time field in Field.Any.
+// Holding a single pointer, it is stored in the interface without allocating.
+type timeZone struct {
+	loc *time.Location
+}
+
+// fieldTime returns the value of the Time field f as ff writes it: in the
+// location of ff or, failing that, the location it was constructed with.
+func fieldTime(f *Field, ff fieldFormat) time.Time {
+	t := time.Unix(0, f.Int)
+	if ff.loc != nil {
+		return t.In(ff.loc)
+	}
+	switch z := f.Any.(type) {
+	case timeZone:
+		return t.In(z.loc)
+	case eventTimeZone:
+		return t.In(z.loc)
+	}
+	return t
+}
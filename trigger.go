@@ -0,0 +1,151 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// triggerRing is a trigger buffer: a bounded ring of formatted entries that
+// were logged below the Logger's level, kept until an entry at the trigger
+// level is written or evicted by newer ones.
+type triggerRing struct {
+	mu   sync.Mutex
+	bufs []*buffer
+	head int
+	n    int
+
+	// held caches the configuration that diverts writes into this ring,
+	// derived from the Logger configuration it was last requested for.
+	held atomic.Pointer[heldConfig]
+}
+
+type heldConfig struct {
+	src, cfg *loggerConfig
+}
+
+func newTriggerRing(size int) *triggerRing {
+	return &triggerRing{bufs: make([]*buffer, size)}
+}
+
+// config returns a copy of cfg whose writes land in r.
+func (r *triggerRing) config(cfg *loggerConfig) *loggerConfig {
+	if h := r.held.Load(); h != nil && h.src == cfg {
+		return h.cfg
+	}
+	held := *cfg
+	held.held = r
+	r.held.Store(&heldConfig{src: cfg, cfg: &held})
+	return &held
+}
+
+// push adds b to the ring, evicting the oldest entry when it is full.
+func (r *triggerRing) push(b *buffer) {
+	r.mu.Lock()
+	i := (r.head + r.n) % len(r.bufs)
+	if r.n == len(r.bufs) {
+		putBuffer(r.bufs[i])
+		r.head = (r.head + 1) % len(r.bufs)
+	} else {
+		r.n++
+	}
+	r.bufs[i] = b
+	r.mu.Unlock()
+}
+
+// drain empties the ring, returning its entries oldest first.
+func (r *triggerRing) drain() []*buffer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.n == 0 {
+		return nil
+	}
+	out := make([]*buffer, r.n)
+	for i := range out {
+		j := (r.head + i) % len(r.bufs)
+		out[i], r.bufs[j] = r.bufs[j], nil
+	}
+	r.head, r.n = 0, 0
+	return out
+}
+
+type triggerContextKey struct{}
+
+// NewTriggerContext returns a copy of ctx carrying its own trigger buffer of
+// size entries, so that a failing request flushes its own debug entries
+// rather than those of every request in flight.
+//
+// It only affects LogContext and LogContextFields on Loggers with
+// Options.TriggerBuffer enabled; entries logged through them with the
+// returned context are retained in, and flushed from, the context's buffer
+// instead of the Logger's. A size of zero
+// or less returns ctx unchanged.
+func NewTriggerContext(ctx context.Context, size int) context.Context {
+	if size <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, triggerContextKey{}, newTriggerRing(size))
+}
+
+// triggerRingFrom returns the trigger buffer of ctx, or r when it has none.
+func triggerRingFrom(ctx context.Context, r *triggerRing) *triggerRing {
+	if ctx != nil {
+		if cr, ok := ctx.Value(triggerContextKey{}).(*triggerRing); ok {
+			return cr
+		}
+	}
+	return r
+}
+
+// hold formats an entry logged below the Logger's level into its trigger
+// buffer, or that of ctx. Hooks, processors, and observers see the entry as
+// it is retained; only the write is deferred. template marks msg as a Logt
+// template.
+func (l *Logger) hold(skip int, ctx context.Context, level Level, msg string, keyvals []any, fields []Field, template bool) {
+	cfg := l.config.Load()
+	if level >= cfg.triggerLevel || level >= DPanicLevel || cfg.core != nil {
+		return
+	}
+	hc := triggerRingFrom(ctx, l.trigger).config(cfg)
+	t, fields := hc.stamp(fields)
+
+	var scratch [1]Field
+	ctxFields := l.contextFields(hc, ctx, &scratch)
+
+	if template || hc.needsEntry(level) {
+		l.logWithEntry(skip+1, level, msg, keyvals, fields, ctxFields, hc, t, template)
+		return
+	}
+	l.output(skip+1, hc, level, msg, keyvals, fields, ctxFields, t)
+}
+
+// trip writes the entries retained in r ahead of an entry at level, when
+// level reaches the trigger level of cfg.
+func (l *Logger) trip(cfg *loggerConfig, r *triggerRing, level Level) {
+	if r == nil || cfg.held != nil || level < cfg.triggerLevel || level == noLevel {
+		return
+	}
+	for _, b := range r.drain() {
+		l.submit(cfg, b)
+	}
+}
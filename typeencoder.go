@@ -0,0 +1,101 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velo
+
+import (
+	"maps"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// typeEncoder encodes a value of a registered type as a single JSON value.
+type typeEncoder func(enc *JSONEncoder, v any)
+
+// _typeEncoders maps each registered type to its encoder. It is copied on
+// write under _typeEncodersMu, so the formatters read it without locking and,
+// until something is registered, skip it with a single load.
+var (
+	_typeEncoders   atomic.Pointer[map[reflect.Type]typeEncoder]
+	_typeEncodersMu sync.Mutex
+)
+
+// RegisterEncoder makes every formatter encode values of type T with fn,
+// wherever they are logged as Any fields or loosely typed key-value pairs,
+// and, in JSON, inside []any and map[string]any values. Register domain
+// types such as decimals, UUIDs, or protobuf messages this way when they
+// neither implement ObjectMarshaler nor print well through fmt, or when their
+// own String or MarshalJSON method allocates:
+//
+//	velo.RegisterEncoder(func(enc *velo.JSONEncoder, d decimal.Decimal) {
+//	  enc.AppendString(d.StringFixed(2))
+//	})
+//
+// fn must append exactly one value with one of the encoder's Append methods,
+// such as AppendString or AppendObject. The TextFormatter writes a string
+// value unquoted, and any other value as JSON. A registered encoder takes
+// precedence over velo's own encoding and the interfaces T implements, and
+// applies to T exactly: register *T separately to cover pointers. Register
+// encoders during initialization; a later registration for the same type
+// replaces the earlier one.
+func RegisterEncoder[T any](fn func(enc *JSONEncoder, v T)) {
+	_typeEncodersMu.Lock()
+	defer _typeEncodersMu.Unlock()
+	m := make(map[reflect.Type]typeEncoder)
+	if old := _typeEncoders.Load(); old != nil {
+		m = maps.Clone(*old)
+	}
+	m[reflect.TypeFor[T]()] = func(enc *JSONEncoder, v any) { fn(enc, v.(T)) }
+	_typeEncoders.Store(&m)
+}
+
+// lookupEncoder returns the encoder registered for the type of v, if any.
+func lookupEncoder(v any) (typeEncoder, bool) {
+	m := _typeEncoders.Load()
+	if m == nil || v == nil {
+		return nil, false
+	}
+	enc, ok := (*m)[reflect.TypeOf(v)]
+	return enc, ok
+}
+
+// appendEncoded appends v to b as JSON with the registered encoder enc,
+// writing durations and times as ff does.
+func appendEncoded(b *buffer, enc typeEncoder, v any, ff fieldFormat) {
+	e := getJSONEncoder(b, ff.durations, ff.loc)
+	enc(e, v)
+	putJSONEncoder(e)
+}
+
+// formatEncoded renders v with the registered encoder enc for the
+// TextFormatter: strings without their quotes, anything else as JSON.
+func formatEncoded(enc typeEncoder, v any) string {
+	var buf buffer
+	appendEncoded(&buf, enc, v, fieldFormat{})
+	s := string(buf.B)
+	if len(s) > 1 && s[0] == '"' {
+		if u, err := strconv.Unquote(s); err == nil {
+			return u
+		}
+	}
+	return s
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"unicode/utf8"
 )
 
 var (
@@ -43,15 +44,18 @@ func init() {
 // It bypasses the reflection heavy fmt.Sprintf for common types, significantly
 // improving performance during log formatting.
 func formatAny(v any) string {
+	if enc, ok := lookupEncoder(v); ok {
+		return formatEncoded(enc, v)
+	}
 	switch val := v.(type) {
 	case string:
 		return val
 	case int:
-		return strconv.Itoa(val)
+		return formatInt(int64(val))
 	case int64:
-		return strconv.FormatInt(val, 10)
+		return formatInt(val)
 	case int32:
-		return strconv.FormatInt(int64(val), 10)
+		return formatInt(int64(val))
 	case uint:
 		return strconv.FormatUint(uint64(val), 10)
 	case uint64:
@@ -77,3 +81,19 @@ func formatAny(v any) string {
 		return fmt.Sprintf("%+v", val)
 	}
 }
+
+// truncateMessage shortens msg to at most max bytes.
+//
+// It backs off to the nearest rune boundary so the result never ends with a
+// partial UTF-8 sequence. It reports false when max is disabled (zero or less)
+// or the message already fits.
+func truncateMessage(msg string, max int) (string, bool) {
+	if max <= 0 || len(msg) <= max {
+		return msg, false
+	}
+	n := max
+	for n > 0 && !utf8.RuneStart(msg[n]) {
+		n--
+	}
+	return msg[:n], true
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package veloaudit writes audit trails with write guarantees.
+//
+// Compliance events must not ride the same best-effort path as debug logs:
+// an ordinary velo Logger may buffer entries in memory, drop them when its
+// queue overflows, sample them, or swallow write errors. A veloaudit Logger
+// does none of that. Every entry is written synchronously on the calling
+// goroutine, committed to stable storage before the call returns, and any
+// failure along the way is returned to the caller:
+//
+//	f, err := veloaudit.OpenFile("/var/log/myapp/audit.log")
+//	if err != nil {
+//		return err
+//	}
+//	audit, err := veloaudit.New(f, velo.Options{Formatter: velo.JSONFormatter, ReportTimestamp: true})
+//	if err != nil {
+//		return err
+//	}
+//	defer audit.Close()
+//
+//	if err := audit.Record("role granted", velo.String("user", user), velo.String("role", role)); err != nil {
+//		return err // the grant must not proceed unrecorded
+//	}
+//
+// To amortize the cost of fsync, group entries in a Batch and commit them
+// together.
+package veloaudit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"velo"
+)
+
+// ErrNotWritten reports that an entry was discarded before it reached the
+// sink, for example by a Hook or Processor. The error returned by Log wraps
+// it.
+var ErrNotWritten = errors.New("veloaudit: entry was not written")
+
+// Logger writes audit entries synchronously and reports every failure to
+// the caller. It is safe for concurrent use; entries are written one at a
+// time.
+//
+// A Logger cannot be wrapped by velo.NewSampler or velo.NewFilter, so its
+// entries are never sampled.
+type Logger struct {
+	mu     sync.Mutex
+	logger *velo.Logger
+	sink   io.Writer
+	err    error
+}
+
+// New creates a Logger writing to w, which is usually a *File.
+//
+// The Options configure presentation as they do for velo.NewWithOptions;
+// JSONFormatter with ReportTimestamp suits most audit trails. Level is
+// ignored: a Logger writes every entry it is given. Options that would make
+// delivery best-effort are refused with an error: Async, OverflowDrop, a
+// TriggerBuffer, and a Core, which may write asynchronously.
+//
+// After each entry, w is synced if it has a Sync method, as *File and
+// *os.File do.
+func New(w io.Writer, o velo.Options) (*Logger, error) {
+	switch {
+	case o.Async:
+		return nil, errors.New("veloaudit: Async is not allowed")
+	case o.OverflowStrategy == velo.OverflowDrop:
+		return nil, errors.New("veloaudit: OverflowDrop is not allowed")
+	case o.TriggerBuffer > 0:
+		return nil, errors.New("veloaudit: TriggerBuffer is not allowed")
+	case o.Core != nil:
+		return nil, errors.New("veloaudit: Core is not allowed")
+	case w == nil:
+		return nil, errors.New("veloaudit: nil writer")
+	}
+
+	a := &Logger{sink: w}
+	o.Level = velo.DebugLevel
+	o.Output = nil
+	o.Metrics = &failures{next: o.Metrics, a: a}
+	if o.OnFatal == nil {
+		// The Logger syncs its writer before running OnFatal, so a FatalLevel
+		// entry is committed before the process exits.
+		o.OnFatal = func(*velo.Entry) {}
+	}
+	logger, err := velo.NewWithOptionsE(w, o)
+	if err != nil {
+		return nil, err
+	}
+	a.logger = logger
+	return a, nil
+}
+
+// Record writes an InfoLevel entry and commits it. See Log.
+func (a *Logger) Record(msg string, fields ...velo.Field) error {
+	return a.log(1, velo.InfoLevel, msg, fields, true)
+}
+
+// Log writes an entry at level and commits it to stable storage before
+// returning. It returns the first error from formatting, writing, or
+// syncing the entry, or an error wrapping ErrNotWritten if the entry was
+// discarded.
+//
+// PanicLevel and FatalLevel entries panic and exit as they do with a velo
+// Logger, after being committed.
+func (a *Logger) Log(level velo.Level, msg string, fields ...velo.Field) error {
+	return a.log(1, level, msg, fields, true)
+}
+
+func (a *Logger) log(skip int, level velo.Level, msg string, fields []velo.Field, commit bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.err = nil
+	a.logger.LogFieldsWithSkip(skip+1, level, msg, fields...)
+	err := a.err
+	if commit && err == nil {
+		err = a.sync()
+	}
+	return err
+}
+
+// sync commits the sink. a.mu must be held.
+func (a *Logger) sync() error {
+	if s, ok := a.sink.(interface{ Sync() error }); ok {
+		if err := s.Sync(); err != nil {
+			return fmt.Errorf("veloaudit: sync: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying velo Logger and, if the sink is an io.Closer,
+// closes it too, which for a *File commits it first.
+func (a *Logger) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.logger.Close()
+	if c, ok := a.sink.(io.Closer); ok {
+		return c.Close()
+	}
+	return a.sync()
+}
+
+// Batch groups entries that are written as they are logged but committed
+// together, trading the latency of one fsync per entry for an explicit
+// acknowledgement. Entries logged to a Batch are durable only once Commit
+// returns nil.
+//
+// A Batch is not safe for concurrent use. Entries written by the Logger
+// meanwhile are committed along with it.
+type Batch struct {
+	a   *Logger
+	n   int
+	err error
+}
+
+// Batch starts a new Batch of entries.
+func (a *Logger) Batch() *Batch {
+	return &Batch{a: a}
+}
+
+// Record adds an InfoLevel entry to the batch. See Log.
+func (b *Batch) Record(msg string, fields ...velo.Field) {
+	b.log(velo.InfoLevel, msg, fields)
+}
+
+// Log writes an entry at level without committing it. A failure is kept and
+// returned by Commit, and entries logged after it are not written.
+func (b *Batch) Log(level velo.Level, msg string, fields ...velo.Field) {
+	b.log(level, msg, fields)
+}
+
+func (b *Batch) log(level velo.Level, msg string, fields []velo.Field) {
+	if b.err != nil {
+		return
+	}
+	if err := b.a.log(2, level, msg, fields, false); err != nil {
+		b.err = fmt.Errorf("veloaudit: batch entry %d: %w", b.n, err)
+	}
+	b.n++
+}
+
+// Commit syncs the sink and returns the first error of the batch. When it
+// returns nil, every entry of the batch is on stable storage. The Batch is
+// empty afterwards and may be reused.
+func (b *Batch) Commit() error {
+	err := b.err
+	if err == nil {
+		b.a.mu.Lock()
+		err = b.a.sync()
+		b.a.mu.Unlock()
+	}
+	b.n, b.err = 0, nil
+	return err
+}
+
+// failures turns the MetricsHook callbacks that signal a lost entry into the
+// error of the call in progress, and forwards every callback to next.
+type failures struct {
+	next velo.MetricsHook
+	a    *Logger
+}
+
+func (f *failures) EntryLogged(level velo.Level, latency time.Duration) {
+	if f.next != nil {
+		f.next.EntryLogged(level, latency)
+	}
+}
+
+func (f *failures) EntryDropped(level velo.Level, reason velo.DropReason) {
+	if f.a.err == nil {
+		f.a.err = fmt.Errorf("%w: %v", ErrNotWritten, reason)
+	}
+	if f.next != nil {
+		f.next.EntryDropped(level, reason)
+	}
+}
+
+func (f *failures) BytesWritten(n int) {
+	if f.next != nil {
+		f.next.BytesWritten(n)
+	}
+}
+
+func (f *failures) WriteError(err error) {
+	if f.a.err == nil {
+		f.a.err = fmt.Errorf("veloaudit: write: %w", err)
+	}
+	if f.next != nil {
+		f.next.WriteError(err)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package veloaudit
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"velo"
+)
+
+// sink is an audit destination that counts syncs and fails writes with err.
+type sink struct {
+	bytes.Buffer
+	syncs int
+	err   error
+}
+
+func (s *sink) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	return s.Buffer.Write(p)
+}
+
+func (s *sink) Sync() error {
+	s.syncs++
+	return nil
+}
+
+func newLogger(t *testing.T, s *sink, o velo.Options) *Logger {
+	t.Helper()
+	a, err := New(s, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestRecord(t *testing.T) {
+	// Level is ignored: every entry is written.
+	s := &sink{}
+	a := newLogger(t, s, velo.Options{Level: velo.ErrorLevel})
+	if err := a.Record("role granted", velo.String("user", "ana")); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.String(); got != "INFO role granted user=ana\n" {
+		t.Errorf("wrote %q", got)
+	}
+	if s.syncs != 1 {
+		t.Errorf("synced %d times, want 1", s.syncs)
+	}
+}
+
+func TestRecordWriteError(t *testing.T) {
+	unavailable := errors.New("unavailable")
+	s := &sink{err: unavailable}
+	a := newLogger(t, s, velo.Options{})
+	if err := a.Record("role granted"); !errors.Is(err, unavailable) {
+		t.Errorf("Record() = %v, want the write error", err)
+	}
+	if s.syncs != 0 {
+		t.Errorf("synced %d times after a failed write", s.syncs)
+	}
+
+	s.err = nil
+	if err := a.Record("role revoked"); err != nil {
+		t.Errorf("Record() after the sink recovered = %v", err)
+	}
+}
+
+func TestRecordDroppedByHook(t *testing.T) {
+	s := &sink{}
+	drop := velo.HookFunc(func(*velo.Entry) error { return velo.ErrDropEntry })
+	a := newLogger(t, s, velo.Options{Hooks: []velo.Hook{drop}})
+	if err := a.Record("role granted"); !errors.Is(err, ErrNotWritten) {
+		t.Errorf("Record() = %v, want ErrNotWritten", err)
+	}
+	if s.Len() != 0 || s.syncs != 0 {
+		t.Errorf("wrote %q and synced %d times", s.String(), s.syncs)
+	}
+}
+
+func TestBatchCommit(t *testing.T) {
+	s := &sink{}
+	a := newLogger(t, s, velo.Options{})
+	b := a.Batch()
+	b.Record("one")
+	b.Record("two")
+	b.Log(velo.WarnLevel, "three")
+	if s.syncs != 0 {
+		t.Fatalf("synced %d times before Commit", s.syncs)
+	}
+	if err := b.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if s.syncs != 1 {
+		t.Errorf("Commit synced %d times, want 1", s.syncs)
+	}
+	if got, want := s.String(), "INFO one\nINFO two\nWARN three\n"; got != want {
+		t.Errorf("wrote %q, want %q", got, want)
+	}
+}
+
+func TestBatchCommitError(t *testing.T) {
+	unavailable := errors.New("unavailable")
+	s := &sink{}
+	a := newLogger(t, s, velo.Options{})
+	b := a.Batch()
+	b.Record("one")
+	s.err = unavailable
+	b.Record("two")
+	s.err = nil
+	b.Record("three")
+	err := b.Commit()
+	if !errors.Is(err, unavailable) || !strings.Contains(err.Error(), "batch entry 1") {
+		t.Errorf("Commit() = %v, want the write error of entry 1", err)
+	}
+	if s.syncs != 0 {
+		t.Errorf("a failed Commit synced %d times", s.syncs)
+	}
+	if got := s.String(); got != "INFO one\n" {
+		t.Errorf("wrote %q, want only the entry before the failure", got)
+	}
+
+	// The Batch is empty after Commit and may be reused.
+	b.Record("four")
+	if err := b.Commit(); err != nil {
+		t.Errorf("Commit() of a reused Batch = %v", err)
+	}
+}
+
+func TestNewRejectsBestEffortOptions(t *testing.T) {
+	for name, o := range map[string]velo.Options{
+		"Async":         {Async: true},
+		"OverflowDrop":  {OverflowStrategy: velo.OverflowDrop},
+		"TriggerBuffer": {TriggerBuffer: 16},
+		"Core":          {Core: velo.NewCore(&sink{})},
+	} {
+		if _, err := New(&sink{}, o); err == nil {
+			t.Errorf("New accepted %s", name)
+		}
+	}
+	if _, err := New(nil, velo.Options{}); err == nil {
+		t.Error("New accepted a nil writer")
+	}
+}
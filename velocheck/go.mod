@@ -0,0 +1,19 @@
+module velo/velocheck
+
+go 1.26.0
+
+require (
+	golang.org/x/tools v0.50.0
+	velo v0.0.0
+)
+
+require (
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	golang.org/x/mod v0.41.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+)
+
+replace velo => ../
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"velo"
+)
+
+func main() {
+	l := velo.New(nil)
+	err := errors.New("failed")
+	l.Fatal("fatal", "err", err)
+	l.Fatalt("fatal: {err}", velo.Err(err))
+	l.Panic("panic")
+	l.Log(velo.FatalLevel, "fatal")
+	velo.Fatal("fatal")
+	l.Fatal("odd", "err")                          // want `odd number of keyvals in call to Fatal`
+	l.Info("eager", "err", fmt.Sprintf("%v", err)) // want `fmt.Sprintf in a value passed to Info`
+}
@@ -0,0 +1,14 @@
+module testdata
+
+go 1.26.0
+
+require velo v0.0.0
+
+require (
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	golang.org/x/sys v0.41.0 // indirect
+)
+
+replace velo => ../../..
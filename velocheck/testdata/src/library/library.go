@@ -0,0 +1,44 @@
+package library
+
+import (
+	"fmt"
+
+	"velo"
+)
+
+func keyvals(l *velo.Logger, id int, key any) {
+	l.Info("ok", "id", id, "name", "x")
+	l.Info("odd", "id", id, "name")    // want `odd number of keyvals in call to Info: key "name" has no value`
+	l.With("id")                       // want `odd number of keyvals in call to With: key "id" has no value`
+	l.Log(velo.InfoLevel, "odd", "id") // want `odd number of keyvals in call to Log: key "id" has no value`
+	velo.Info("odd", "id")             // want `odd number of keyvals in call to Info: key "id" has no value`
+
+	l.Info("key", id, "x")                  // want `non-string key id \(int\) in call to Info`
+	l.Info("key", velo.String("name", "x")) // want `velo.Field passed as a key to Info; use InfoFields with typed fields`
+	l.With(velo.Int("id", id), "name", "x") // want `velo.Field passed as a key to With; use WithFields with typed fields`
+	l.Info("key", key, "x")                 // an interface may hold a string
+	l.Info("key", fmt.Sprint("name"), "x")
+
+	args := []any{"id", id, "name"}
+	l.Info("spread", args...) // the length is unknown here
+}
+
+func eager(l *velo.Logger, id int) {
+	l.Info("eager", "id", fmt.Sprintf("%04d", id))             // want `fmt.Sprintf in a value passed to Info formats even when the level is disabled`
+	l.Info("eager", "id", fmt.Sprint(id))                      // want `fmt.Sprint in a value passed to Info`
+	l.InfoFields("eager", velo.String("id", fmt.Sprintln(id))) // want `fmt.Sprintln in a value passed to String`
+	l.Info("lazy", "id", id)
+	l.Infof("lazy %d", id)
+}
+
+func terminal(l *velo.Logger, err error) {
+	l.Fatal("fatal", "err", err)                // want `Fatal in library code; return an error`
+	l.Fatalf("fatal: %v", err)                  // want `Fatalf in library code`
+	l.Fatalt("fatal: {err}", velo.Err(err))     // want `Fatalt in library code`
+	l.Panic("panic", "err", err)                // want `Panic in library code`
+	l.Panict("panic: {err}", velo.Err(err))     // want `Panict in library code`
+	l.Log(velo.FatalLevel, "fatal", "err", err) // want `Log at FatalLevel in library code`
+	velo.Fatal("fatal")                         // want `Fatal in library code`
+	l.Log(velo.ErrorLevel, "error", "err", err)
+	l.Error("error", "err", err)
+}
@@ -0,0 +1,16 @@
+package library
+
+import (
+	"testing"
+
+	"velo"
+)
+
+func TestTerminal(t *testing.T) {
+	l := velo.New(nil)
+	l.Fatal("fatal")
+	l.Fatalt("fatal")
+	l.Panic("panic")
+	l.Log(velo.PanicLevel, "panic")
+	l.Info("odd", "id") // want `odd number of keyvals in call to Info`
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package velocheck defines an analyzer that reports common mistakes in calls
+// to velo's logging API.
+//
+// The loosely typed methods such as Info and With accept alternating keys and
+// values, so a missing value or a misplaced argument compiles cleanly and only
+// shows up as a malformed entry at runtime. The analyzer reports:
+//
+//   - an odd number of keyvals, where the last key has no value
+//   - keys that are not strings
+//   - values built with fmt.Sprintf and friends, which format eagerly even
+//     when the level is disabled
+//   - Fatal and Panic calls outside package main, where exiting or panicking
+//     is a decision that belongs to the caller
+//
+// Run it through go vet with the velocheck command:
+//
+//	go install velo/velocheck/cmd/velocheck
+//	go vet -vettool=$(which velocheck) ./...
+package velocheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"velo"
+)
+
+// Analyzer reports misuse of velo's loosely typed logging API.
+var Analyzer = &analysis.Analyzer{
+	Name:     "velocheck",
+	Doc:      "report malformed keyvals, eager formatting, and Fatal or Panic calls in library code",
+	URL:      "https://pkg.go.dev/velo/velocheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// _veloPath is the import path of the velo package, taken from the package
+// itself so that the analyzer follows the module if it moves.
+var _veloPath = reflect.TypeFor[velo.Logger]().PkgPath()
+
+// _terminal lists the functions and methods that exit or panic after logging.
+var _terminal = map[string]bool{
+	"Fatal": true, "Fatalf": true, "Fatalt": true, "FatalFields": true,
+	"Panic": true, "Panicf": true, "Panict": true, "PanicFields": true,
+}
+
+// _terminalLevels lists the levels that make a Log call exit or panic.
+var _terminalLevels = map[string]bool{
+	"FatalLevel": true,
+	"PanicLevel": true,
+}
+
+// _eager lists the fmt functions that build a value before the level check.
+var _eager = map[string]bool{
+	"Sprintf": true, "Sprint": true, "Sprintln": true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if pass.Pkg.Path() == _veloPath {
+		return nil, nil
+	}
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	library := pass.Pkg.Name() != "main"
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn := callee(pass.TypesInfo, call)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != _veloPath {
+			return
+		}
+		sig := fn.Type().(*types.Signature)
+
+		if library && !isTest(pass, call) {
+			checkTerminal(pass, call, fn)
+		}
+		if isKeyvals(sig) {
+			checkKeyvals(pass, call, fn, sig)
+			return
+		}
+		if isField(sig.Results()) {
+			for _, arg := range call.Args {
+				checkEager(pass, arg, fn.Name())
+			}
+		}
+	})
+	return nil, nil
+}
+
+// callee returns the velo function or method called by call, or nil.
+func callee(info *types.Info, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[id].(*types.Func)
+	return fn
+}
+
+// isKeyvals reports whether sig ends with the variadic keyvals ...any
+// parameter used by the loosely typed API.
+func isKeyvals(sig *types.Signature) bool {
+	if !sig.Variadic() {
+		return false
+	}
+	last := sig.Params().At(sig.Params().Len() - 1)
+	if last.Name() != "keyvals" {
+		return false
+	}
+	slice, ok := last.Type().(*types.Slice)
+	return ok && types.Identical(slice.Elem(), types.Universe.Lookup("any").Type())
+}
+
+// isField reports whether results is a single velo.Field.
+func isField(results *types.Tuple) bool {
+	if results.Len() != 1 {
+		return false
+	}
+	named, ok := results.At(0).Type().(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == _veloPath &&
+		named.Obj().Name() == "Field"
+}
+
+// checkKeyvals reports odd-length keyvals, non-string keys, and eagerly
+// formatted values in a call to a loosely typed method.
+func checkKeyvals(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, sig *types.Signature) {
+	if call.Ellipsis.IsValid() {
+		// The keyvals come from a slice whose length is unknown here.
+		return
+	}
+	start := sig.Params().Len() - 1
+	if len(call.Args) <= start {
+		return
+	}
+	keyvals := call.Args[start:]
+
+	typed := false
+	for i := 0; i < len(keyvals); i += 2 {
+		if checkKey(pass, keyvals[i], fn.Name()) {
+			typed = true
+		}
+		if i+1 < len(keyvals) {
+			checkEager(pass, keyvals[i+1], fn.Name())
+		}
+	}
+	// A Field in keyvals already explains the odd count.
+	if len(keyvals)%2 != 0 && !typed {
+		last := keyvals[len(keyvals)-1]
+		pass.Reportf(last.Pos(), "odd number of keyvals in call to %s: key %s has no value",
+			fn.Name(), types.ExprString(last))
+	}
+}
+
+// checkKey reports a key whose static type is not a string. It returns true
+// if the key is a velo.Field.
+func checkKey(pass *analysis.Pass, key ast.Expr, name string) bool {
+	t := pass.TypesInfo.TypeOf(key)
+	if t == nil {
+		return false
+	}
+	if basic, ok := t.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+		return false
+	}
+	if types.IsInterface(t) {
+		// An interface may hold a string at runtime; only report it when
+		// the value is known to be something else.
+		return false
+	}
+	if isField(types.NewTuple(types.NewVar(0, nil, "", t))) {
+		pass.Reportf(key.Pos(), "velo.Field passed as a key to %s; use %s with typed fields",
+			name, fieldsVariant(name))
+		return true
+	}
+	pass.Reportf(key.Pos(), "non-string key %s (%s) in call to %s",
+		types.ExprString(key), t, name)
+	return false
+}
+
+// _fieldsVariant maps each loosely typed method to its typed counterpart.
+var _fieldsVariant = map[string]string{
+	"Debug": "DebugFields", "Info": "InfoFields", "Warn": "WarnFields",
+	"Error": "ErrorFields", "DPanic": "DPanicFields", "Panic": "PanicFields",
+	"Fatal": "FatalFields", "Log": "LogFields", "LogContext": "LogContextFields",
+	"LogWithSkip": "LogFieldsWithSkip", "With": "WithFields",
+}
+
+// fieldsVariant names the typed counterpart of a loosely typed method.
+func fieldsVariant(name string) string {
+	if v, ok := _fieldsVariant[name]; ok {
+		return v
+	}
+	return "LogFields"
+}
+
+// checkEager reports a value built by fmt.Sprintf, fmt.Sprint, or fmt.Sprintln.
+func checkEager(pass *analysis.Pass, value ast.Expr, name string) {
+	call, ok := ast.Unparen(value).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	fn := callee(pass.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" || !_eager[fn.Name()] {
+		return
+	}
+	pass.Reportf(value.Pos(), "fmt.%s in a value passed to %s formats even when the level is disabled; log the parts as separate fields",
+		fn.Name(), name)
+}
+
+// checkTerminal reports a call that exits or panics from library code.
+func checkTerminal(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) {
+	if _terminal[fn.Name()] {
+		pass.Reportf(call.Pos(), "%s in library code; return an error and let package main decide whether to exit",
+			fn.Name())
+		return
+	}
+	for _, arg := range call.Args {
+		var id *ast.Ident
+		switch a := ast.Unparen(arg).(type) {
+		case *ast.Ident:
+			id = a
+		case *ast.SelectorExpr:
+			id = a.Sel
+		default:
+			continue
+		}
+		c, ok := pass.TypesInfo.Uses[id].(*types.Const)
+		if ok && c.Pkg() != nil && c.Pkg().Path() == _veloPath && _terminalLevels[c.Name()] {
+			pass.Reportf(call.Pos(), "%s at %s in library code; return an error and let package main decide whether to exit",
+				fn.Name(), c.Name())
+			return
+		}
+	}
+}
+
+// isTest reports whether node lies in a _test.go file.
+func isTest(pass *analysis.Pass, node ast.Node) bool {
+	return strings.HasSuffix(pass.Fset.File(node.Pos()).Name(), "_test.go")
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package veloforward ships files written by velo to network sinks.
+//
+// Hosts that run batch jobs without reliable connectivity can log to local
+// files and let a Forwarder deliver the entries once the network returns. The
+// Forwarder tails each file, parses its lines with velo's parse package, and
+// hands them to a Sink in batches. It records how far every file has been
+// delivered in a state file, advancing only after the Sink accepts a batch, so
+// nothing is lost across restarts or outages; a batch may arrive twice.
+//
+//	fwd, err := veloforward.New(veloforward.Options{
+//		Paths:     []string{"/var/log/job/app.log"},
+//		StateFile: "/var/lib/job/forward.state",
+//		Sink:      veloforward.NewLokiSink("http://loki:3100", veloforward.LokiOptions{Labels: map[string]string{"job": "batch"}}),
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	err = fwd.Run(ctx)
+//
+// Files rotated by renaming, as velo's RotationConfig does, are followed to
+// the new file after the old one has been read to its end.
+package veloforward
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"velo"
+	"velo/parse"
+)
+
+// DefaultBatchSize is the number of entries per batch when Options.BatchSize
+// is unset.
+const DefaultBatchSize = 500
+
+// DefaultPollInterval is how often files at their end are checked for new
+// lines when Options.PollInterval is unset.
+const DefaultPollInterval = time.Second
+
+// DefaultMaxBackoff caps the delay between retries of a failed batch when
+// Options.MaxBackoff is unset.
+const DefaultMaxBackoff = time.Minute
+
+// Options configures a Forwarder.
+type Options struct {
+	// Paths lists the files to ship. A file that does not exist yet is
+	// picked up once it is created.
+	Paths []string
+
+	// StateFile records how far each file has been shipped. It is required.
+	StateFile string
+
+	// Sink receives the entries. It is required.
+	Sink Sink
+
+	// Parse describes the Logger that wrote the files, for text output with
+	// a custom TimeFormat, Prefix, or Styles.
+	Parse parse.Options
+
+	// BatchSize is the maximum number of entries per Send. It defaults to
+	// DefaultBatchSize.
+	BatchSize int
+
+	// PollInterval is how often files at their end are checked for new
+	// lines. It defaults to DefaultPollInterval.
+	PollInterval time.Duration
+
+	// MaxBackoff caps the delay between retries of a batch the Sink
+	// rejected. Retries start at PollInterval and double. It defaults to
+	// DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// OnError receives failures the Forwarder recovers from, such as a
+	// rejected batch or a line that does not parse. Such a line is still
+	// shipped, as the message of an InfoLevel entry.
+	OnError func(error)
+}
+
+// Forwarder tails files written by velo and ships their entries to a Sink.
+type Forwarder struct {
+	o       Options
+	tailers []*tailer
+	state   map[string]checkpoint
+}
+
+// New validates o, loads the state file, and opens the files to ship.
+func New(o Options) (*Forwarder, error) {
+	switch {
+	case o.Sink == nil:
+		return nil, errors.New("veloforward: Options.Sink is required")
+	case o.StateFile == "":
+		return nil, errors.New("veloforward: Options.StateFile is required")
+	case o.BatchSize < 0 || o.PollInterval < 0 || o.MaxBackoff < 0:
+		return nil, errors.New("veloforward: BatchSize, PollInterval, and MaxBackoff must not be negative")
+	}
+	if o.BatchSize == 0 {
+		o.BatchSize = DefaultBatchSize
+	}
+	if o.PollInterval == 0 {
+		o.PollInterval = DefaultPollInterval
+	}
+	if o.MaxBackoff == 0 {
+		o.MaxBackoff = DefaultMaxBackoff
+	}
+
+	state, err := loadState(o.StateFile)
+	if err != nil {
+		return nil, err
+	}
+	f := &Forwarder{o: o, state: state}
+	for _, path := range o.Paths {
+		t := &tailer{path: path}
+		if err := t.resume(state[path]); err != nil {
+			f.close()
+			return nil, err
+		}
+		f.tailers = append(f.tailers, t)
+	}
+	return f, nil
+}
+
+// Run ships entries until ctx is canceled, then closes the files and returns
+// ctx.Err(). It returns early only if the state file cannot be written.
+//
+// Run is not safe to call concurrently, and a Forwarder cannot be restarted
+// after Run returns; create a new one, which resumes from the state file.
+func (f *Forwarder) Run(ctx context.Context) error {
+	defer f.close()
+	for {
+		shipped, err := f.ship(ctx)
+		if err != nil {
+			return err
+		}
+		if shipped {
+			continue
+		}
+		if err := sleep(ctx, f.o.PollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// ship sends one batch from every file with complete lines. It reports
+// whether any file had lines, so that Run keeps going without pausing.
+func (f *Forwarder) ship(ctx context.Context) (bool, error) {
+	shipped := false
+	for _, t := range f.tailers {
+		lines, n, err := t.lines(f.o.BatchSize)
+		if err != nil {
+			f.report(err)
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+		shipped = true
+		if len(lines) > 0 {
+			if err := f.send(ctx, f.entries(lines)); err != nil {
+				return false, err
+			}
+		}
+		t.commit(n)
+		f.state[t.path] = t.checkpoint()
+		if err := saveState(f.o.StateFile, f.state); err != nil {
+			return false, err
+		}
+	}
+	return shipped, nil
+}
+
+// entries parses lines into entries. A line that does not parse becomes the
+// message of an InfoLevel entry, so that it is shipped rather than lost.
+func (f *Forwarder) entries(lines [][]byte) []*velo.Entry {
+	entries := make([]*velo.Entry, 0, len(lines))
+	for _, line := range lines {
+		e, err := parse.Line(line, f.o.Parse)
+		if err != nil {
+			f.report(err)
+			e = &velo.Entry{Level: velo.InfoLevel, Message: string(line)}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// send delivers entries, retrying with exponential backoff until the Sink
+// accepts them or ctx is canceled.
+func (f *Forwarder) send(ctx context.Context, entries []*velo.Entry) error {
+	backoff := f.o.PollInterval
+	for {
+		err := f.o.Sink.Send(ctx, entries)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		f.report(err)
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+		backoff = min(2*backoff, f.o.MaxBackoff)
+	}
+}
+
+// report passes err to Options.OnError, if set.
+func (f *Forwarder) report(err error) {
+	if f.o.OnError != nil {
+		f.o.OnError(err)
+	}
+}
+
+// close releases every open file.
+func (f *Forwarder) close() {
+	for _, t := range f.tailers {
+		t.close()
+	}
+}
+
+// sleep waits for d or until ctx is canceled, returning ctx.Err() in the
+// latter case.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package veloforward
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"velo"
+)
+
+// Sink delivers batches of entries to a remote destination.
+//
+// Send must not return until the batch is stored remotely, or return an
+// error, in which case the Forwarder sends the same batch again later. A
+// batch may therefore arrive more than once. The entries are owned by the
+// Forwarder and must not be retained after Send returns.
+//
+// Implement Sink to ship to a system without a built-in sink, such as Kafka
+// through the client library of your choice.
+type Sink interface {
+	Send(ctx context.Context, entries []*velo.Entry) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, entries []*velo.Entry) error
+
+// Send calls f(ctx, entries).
+func (f SinkFunc) Send(ctx context.Context, entries []*velo.Entry) error {
+	return f(ctx, entries)
+}
+
+// lineEncoder renders entries as JSON lines with velo's own JSONFormatter, so
+// that text files are shipped in the same form as JSON ones.
+type lineEncoder struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	core velo.Core
+}
+
+func newLineEncoder() *lineEncoder {
+	enc := &lineEncoder{}
+	enc.core = velo.NewCore(&enc.buf,
+		velo.WithLevel(velo.DebugLevel),
+		velo.WithFormatter(velo.JSONFormatter),
+		velo.WithTimestamp(time.RFC3339Nano),
+	)
+	return enc
+}
+
+// encode appends the JSON line of e, including its newline, to dst.
+func (enc *lineEncoder) encode(dst []byte, e *velo.Entry) []byte {
+	enc.mu.Lock()
+	defer enc.mu.Unlock()
+	enc.buf.Reset()
+	enc.core.Write(e)
+	return append(dst, enc.buf.Bytes()...)
+}
+
+// HTTPSink posts each batch as newline delimited JSON, one entry per line.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+	header http.Header
+	enc    *lineEncoder
+}
+
+// NewHTTPSink returns a Sink that posts batches to url with client, adding
+// header to every request. A nil client selects http.DefaultClient.
+//
+// Any response status outside 2xx is an error, so the batch is retried.
+func NewHTTPSink(url string, client *http.Client, header http.Header) *HTTPSink {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPSink{url: url, client: client, header: header, enc: newLineEncoder()}
+}
+
+// Send posts entries as a single request.
+func (s *HTTPSink) Send(ctx context.Context, entries []*velo.Entry) error {
+	var body []byte
+	for _, e := range entries {
+		body = s.enc.encode(body, e)
+	}
+	return post(ctx, s.client, s.url, "application/x-ndjson", s.header, body)
+}
+
+// LokiSink pushes batches to the Grafana Loki push API.
+//
+// Entries become log lines rendered by velo's JSONFormatter, grouped into one
+// stream per level. Each stream carries the configured labels plus a level
+// label.
+type LokiSink struct {
+	url    string
+	client *http.Client
+	header http.Header
+	labels map[string]string
+	enc    *lineEncoder
+}
+
+// LokiOptions configures a LokiSink.
+type LokiOptions struct {
+	// Client sends the requests. It defaults to http.DefaultClient.
+	Client *http.Client
+
+	// Header is added to every request, for example for authentication or
+	// the X-Scope-OrgID tenant header.
+	Header http.Header
+
+	// Labels are attached to every stream, such as {"job": "batch"}.
+	Labels map[string]string
+}
+
+// NewLokiSink returns a Sink that pushes to the Loki server at baseURL, such
+// as "http://loki:3100".
+func NewLokiSink(baseURL string, o LokiOptions) *LokiSink {
+	if o.Client == nil {
+		o.Client = http.DefaultClient
+	}
+	return &LokiSink{
+		url:    baseURL + "/loki/api/v1/push",
+		client: o.Client,
+		header: o.Header,
+		labels: o.Labels,
+		enc:    newLineEncoder(),
+	}
+}
+
+type lokiPush struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// Send pushes entries in a single request.
+func (s *LokiSink) Send(ctx context.Context, entries []*velo.Entry) error {
+	var push lokiPush
+	streams := make(map[velo.Level]*lokiStream)
+	var line []byte
+	for _, e := range entries {
+		st := streams[e.Level]
+		if st == nil {
+			labels := make(map[string]string, len(s.labels)+1)
+			for k, v := range s.labels {
+				labels[k] = v
+			}
+			labels["level"] = e.Level.String()
+			st = &lokiStream{Stream: labels}
+			streams[e.Level] = st
+			push.Streams = append(push.Streams, st)
+		}
+		t := e.Time
+		if t.IsZero() {
+			t = time.Now()
+		}
+		line = s.enc.encode(line[:0], e)
+		st.Values = append(st.Values, [2]string{
+			strconv.FormatInt(t.UnixNano(), 10),
+			string(bytes.TrimSuffix(line, []byte{'\n'})),
+		})
+	}
+	body, err := json.Marshal(&push)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, "application/json", s.header, body)
+}
+
+// post sends body to url and reports any status outside 2xx as an error.
+func post(ctx context.Context, client *http.Client, url, contentType string, header http.Header, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("veloforward: %s returned %s: %s", url, resp.Status, bytes.TrimSpace(msg))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package veloforward
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// _headSize is the number of leading bytes that identify a file across
+// restarts, when its inode can no longer be compared.
+const _headSize = 64
+
+// _readSize is the size of each read from a tailed file.
+const _readSize = 64 << 10
+
+// checkpoint records how far a file has been shipped.
+type checkpoint struct {
+	// Offset is the number of bytes shipped from the start of the file.
+	Offset int64 `json:"offset"`
+	// Head holds the first bytes of the file, hex encoded, to recognize it
+	// after a restart even if it has been rotated to path.1.
+	Head string `json:"head"`
+}
+
+// tailer follows one file written by velo.
+//
+// It hands out complete lines only; a partial line at the end of the file
+// waits until its newline is written. Rotation by rename, as Config's
+// RotationConfig does, is detected by comparing the open file with the one
+// now at path: the old file is read to its end before the new one is opened.
+// A file that shrinks in place was truncated and is read again from the
+// start.
+type tailer struct {
+	path    string
+	f       *os.File
+	info    os.FileInfo
+	head    []byte
+	offset  int64  // bytes shipped
+	pending []byte // bytes read after offset, not yet shipped
+	eof     bool   // the last read reached the end of f
+}
+
+// resume opens the file described by cp, which may since have been rotated
+// to path.1. A file that matches neither is read from the start.
+func (t *tailer) resume(cp checkpoint) error {
+	head, err := hex.DecodeString(cp.Head)
+	if err != nil || cp.Offset == 0 {
+		return t.open(t.path, 0)
+	}
+	for _, path := range [...]string{t.path, t.path + ".1"} {
+		ok, err := matches(path, head, cp.Offset)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return t.open(path, cp.Offset)
+		}
+	}
+	return t.open(t.path, 0)
+}
+
+// matches reports whether the file at path begins with head and holds at
+// least offset bytes.
+func matches(path string, head []byte, offset int64) (bool, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.Size() < offset {
+		return false, err
+	}
+	got := make([]byte, len(head))
+	if _, err := io.ReadFull(f, got); err != nil {
+		return false, nil
+	}
+	return bytes.Equal(got, head), nil
+}
+
+// open starts reading the file at path from offset. A missing file is not an
+// error; it is opened once it appears.
+func (t *tailer) open(path string, offset int64) error {
+	t.close()
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	t.f, t.info, t.offset = f, info, offset
+	return nil
+}
+
+// close releases the open file, if any, and forgets its position.
+func (t *tailer) close() {
+	if t.f != nil {
+		t.f.Close()
+	}
+	t.f, t.info, t.head = nil, nil, nil
+	t.offset, t.pending, t.eof = 0, nil, false
+}
+
+// lines returns up to max complete lines, without their newlines, and the
+// number of bytes they span. The lines alias an internal buffer and are valid
+// until the next call to commit.
+func (t *tailer) lines(max int) ([][]byte, int64, error) {
+	if t.f == nil {
+		if err := t.open(t.path, 0); err != nil || t.f == nil {
+			return nil, 0, err
+		}
+	}
+	if bytes.Count(t.pending, []byte{'\n'}) < max && !t.eof {
+		if err := t.fill(); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var lines [][]byte
+	var n int64
+	rest := t.pending
+	for len(lines) < max {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			break
+		}
+		if line := bytes.TrimSuffix(rest[:i], []byte{'\r'}); len(line) > 0 {
+			lines = append(lines, line)
+		}
+		n += int64(i + 1)
+		rest = rest[i+1:]
+	}
+	if n == 0 && t.eof {
+		return nil, 0, t.follow()
+	}
+	return lines, n, nil
+}
+
+// fill appends the next chunk of the file to pending.
+func (t *tailer) fill() error {
+	buf := make([]byte, _readSize)
+	k, err := t.f.ReadAt(buf, t.offset+int64(len(t.pending)))
+	t.pending = append(t.pending, buf[:k]...)
+	t.eof = err == io.EOF
+	if t.head == nil && t.offset == 0 && len(t.pending) > 0 {
+		t.head = bytes.Clone(t.pending[:min(len(t.pending), _headSize)])
+	}
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// follow runs once the open file is read to its end, switching to a new file
+// after rotation and starting over after truncation.
+func (t *tailer) follow() error {
+	t.eof = false
+	info, err := os.Stat(t.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case !os.SameFile(t.info, info):
+		// Lines written to the old file just before it was renamed have
+		// been read by now, since the last read found its end.
+		return t.open(t.path, 0)
+	case info.Size() < t.offset+int64(len(t.pending)):
+		t.offset, t.pending, t.head = 0, nil, nil
+	}
+	return nil
+}
+
+// commit marks n bytes after the offset as shipped.
+func (t *tailer) commit(n int64) {
+	t.offset += n
+	t.pending = t.pending[n:]
+	if len(t.pending) == 0 {
+		t.pending = nil
+	}
+}
+
+// checkpoint returns the position to record for t.
+func (t *tailer) checkpoint() checkpoint {
+	if t.head == nil && t.f != nil && t.offset > 0 {
+		head := make([]byte, min(t.offset, _headSize))
+		if k, _ := t.f.ReadAt(head, 0); k == len(head) {
+			t.head = head
+		}
+	}
+	return checkpoint{Offset: t.offset, Head: hex.EncodeToString(t.head)}
+}
+
+// loadState reads the checkpoints stored at path, keyed by tailed file.
+func loadState(path string) (map[string]checkpoint, error) {
+	state := make(map[string]checkpoint)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// saveState replaces the checkpoints stored at path. The new state is
+// written to a temporary file and renamed into place, so a crash leaves
+// either the old or the new state.
+func saveState(path string, state map[string]checkpoint) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package veloforward
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"velo"
+)
+
+// recordSink keeps the messages of the entries it is sent, or fails with err.
+type recordSink struct {
+	msgs []string
+	err  error
+}
+
+func (s *recordSink) Send(_ context.Context, entries []*velo.Entry) error {
+	if s.err != nil {
+		return s.err
+	}
+	for _, e := range entries {
+		s.msgs = append(s.msgs, e.Message)
+	}
+	return nil
+}
+
+// take returns and clears the messages received so far.
+func (s *recordSink) take() []string {
+	msgs := s.msgs
+	s.msgs = nil
+	return msgs
+}
+
+func newForwarder(t *testing.T, dir string, s Sink) *Forwarder {
+	t.Helper()
+	f, err := New(Options{
+		Paths:     []string{filepath.Join(dir, "app.log")},
+		StateFile: filepath.Join(dir, "state"),
+		Sink:      s,
+		BatchSize: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(f.close)
+	return f
+}
+
+// drain ships until the files have no complete lines left. A tailer at the
+// end of its file switches files or starts over in one round and reads in
+// the next, so drain stops after two idle rounds.
+func drain(t *testing.T, f *Forwarder) {
+	t.Helper()
+	for idle := 0; idle < 2; {
+		shipped, err := f.ship(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shipped {
+			idle = 0
+		} else {
+			idle++
+		}
+	}
+}
+
+func appendFile(t *testing.T, path, s string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(s); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expect(t *testing.T, s *recordSink, want ...string) {
+	t.Helper()
+	if got := s.take(); !slices.Equal(got, want) {
+		t.Errorf("shipped %q, want %q", got, want)
+	}
+}
+
+func TestForwarderWaitsForPartialLines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	s := &recordSink{}
+	f := newForwarder(t, dir, s)
+
+	appendFile(t, path, "INFO one\nINFO two\nINFO thr")
+	drain(t, f)
+	expect(t, s, "one", "two")
+
+	appendFile(t, path, "ee\n")
+	drain(t, f)
+	expect(t, s, "three")
+}
+
+func TestForwarderCheckpoints(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "INFO one\nINFO two\n")
+	s := &recordSink{}
+	drain(t, newForwarder(t, dir, s))
+	expect(t, s, "one", "two")
+
+	state, err := loadState(filepath.Join(dir, "state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp := state[path]; cp.Offset != int64(len("INFO one\nINFO two\n")) || cp.Head == "" {
+		t.Errorf("checkpoint = %+v, want the end of the file", cp)
+	}
+
+	// A batch the Sink rejects is not checkpointed.
+	appendFile(t, path, "INFO three\n")
+	f := newForwarder(t, dir, &recordSink{err: errors.New("unavailable")})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.ship(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ship() = %v, want context.Canceled", err)
+	}
+	f.close()
+
+	drain(t, newForwarder(t, dir, s))
+	expect(t, s, "three")
+}
+
+func TestForwarderResumesRotatedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "INFO one\nINFO two\n")
+	s := &recordSink{}
+	f := newForwarder(t, dir, s)
+	drain(t, f)
+	expect(t, s, "one", "two")
+	f.close()
+
+	// While the Forwarder is down, the file gets more lines and is rotated.
+	appendFile(t, path, "INFO three\n")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "INFO four\n")
+
+	drain(t, newForwarder(t, dir, s))
+	expect(t, s, "three", "four")
+}
+
+func TestForwarderFollowsRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	s := &recordSink{}
+	f := newForwarder(t, dir, s)
+	appendFile(t, path, "INFO one\n")
+	drain(t, f)
+	expect(t, s, "one")
+
+	// Lines written just before the rename are read from the old file.
+	appendFile(t, path, "INFO two\n")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "INFO three\n")
+	drain(t, f)
+	expect(t, s, "two", "three")
+}
+
+func TestForwarderRestartsTruncatedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	s := &recordSink{}
+	f := newForwarder(t, dir, s)
+	appendFile(t, path, "INFO one\nINFO two\n")
+	drain(t, f)
+	expect(t, s, "one", "two")
+
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "INFO three\n")
+	drain(t, f)
+	expect(t, s, "three")
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package velolipgloss converts lipgloss styles into velo styles.
+//
+// The core velo module ships its own lightweight Style type so the logging hot
+// path does not depend on lipgloss. Applications that already define their
+// theme with lipgloss can use this package to carry it over:
+//
+//	st := velo.DefaultStyles()
+//	st.Levels = velolipgloss.Levels(theme.Levels)
+//	st.Key = velolipgloss.Style(theme.Key)
+package velolipgloss
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"velo"
+)
+
+// Style converts a lipgloss.Style into a velo.Style.
+//
+// Text attributes (bold, faint, italic, underline, and reverse), colors,
+// MaxWidth, and the string set with SetString carry over. Layout properties
+// such as padding, margins, borders, and alignment have no equivalent and are
+// ignored. The result uses the color profile velo detects for standard
+// output; call Profile on it to choose another.
+func Style(s lipgloss.Style) velo.Style {
+	v := velo.NewStyle().
+		Bold(s.GetBold()).
+		Faint(s.GetFaint()).
+		Italic(s.GetItalic()).
+		Underline(s.GetUnderline()).
+		Reverse(s.GetReverse()).
+		Foreground(color(s.GetForeground())).
+		Background(color(s.GetBackground())).
+		SetString(s.Value())
+	if w := s.GetMaxWidth(); w > 0 {
+		v = v.MaxWidth(w)
+	}
+	return v
+}
+
+// Levels converts a map of per level lipgloss styles, such as the Levels of a
+// theme, into velo styles.
+func Levels(m map[velo.Level]lipgloss.Style) map[velo.Level]velo.Style {
+	out := make(map[velo.Level]velo.Style, len(m))
+	for l, s := range m {
+		out[l] = Style(s)
+	}
+	return out
+}
+
+// Keys converts a map of per key lipgloss styles, as used by Styles.Keys and
+// Styles.Values, into velo styles.
+func Keys(m map[string]lipgloss.Style) map[string]velo.Style {
+	out := make(map[string]velo.Style, len(m))
+	for k, s := range m {
+		out[k] = Style(s)
+	}
+	return out
+}
+
+// color converts a lipgloss color. Adaptive colors resolve to their dark
+// background variant, which matches the typical terminal.
+func color(c lipgloss.TerminalColor) velo.Color {
+	switch c := c.(type) {
+	case lipgloss.Color:
+		return velo.Color(c)
+	case lipgloss.ANSIColor:
+		return velo.Color(strconv.FormatUint(uint64(c), 10))
+	case lipgloss.AdaptiveColor:
+		return velo.Color(c.Dark)
+	case lipgloss.CompleteColor:
+		switch {
+		case c.TrueColor != "":
+			return velo.Color(c.TrueColor)
+		case c.ANSI256 != "":
+			return velo.Color(c.ANSI256)
+		default:
+			return velo.Color(c.ANSI)
+		}
+	case lipgloss.CompleteAdaptiveColor:
+		return color(c.Dark)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velootel
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"velo"
+)
+
+// FieldMetrics implements velo.FieldMetrics on top of OpenTelemetry
+// instruments.
+//
+// Each distinct Counter field key becomes an Int64Counter and each Gauge
+// field key a Float64Gauge, created the first time the key is logged.
+type FieldMetrics struct {
+	meter   metric.Meter
+	onError func(error)
+
+	counters sync.Map // string → metric.Int64Counter
+	gauges   sync.Map // string → metric.Float64Gauge
+}
+
+var _ velo.FieldMetrics = (*FieldMetrics)(nil)
+
+// NewFieldMetrics returns a FieldMetrics creating its instruments on a meter
+// obtained from mp. Errors from creating an instrument are passed to onError,
+// if it is not nil, and the field's value is not recorded.
+func NewFieldMetrics(mp metric.MeterProvider, onError func(error)) *FieldMetrics {
+	return &FieldMetrics{meter: mp.Meter(_scope), onError: onError}
+}
+
+// AddCounter implements velo.FieldMetrics. Negative deltas are ignored, since
+// OpenTelemetry counters are monotonic.
+func (m *FieldMetrics) AddCounter(name string, delta int64) {
+	if delta < 0 {
+		return
+	}
+	c, ok := m.counters.Load(name)
+	if !ok {
+		ctr, err := m.meter.Int64Counter(name, metric.WithDescription("Sum of the "+name+" Counter field of log entries."))
+		if err != nil {
+			m.report(err)
+			return
+		}
+		c, _ = m.counters.LoadOrStore(name, ctr)
+	}
+	c.(metric.Int64Counter).Add(context.Background(), delta)
+}
+
+// SetGauge implements velo.FieldMetrics.
+func (m *FieldMetrics) SetGauge(name string, value float64) {
+	g, ok := m.gauges.Load(name)
+	if !ok {
+		gauge, err := m.meter.Float64Gauge(name, metric.WithDescription("Last value of the "+name+" Gauge field of log entries."))
+		if err != nil {
+			m.report(err)
+			return
+		}
+		g, _ = m.gauges.LoadOrStore(name, gauge)
+	}
+	g.(metric.Float64Gauge).Record(context.Background(), value)
+}
+
+func (m *FieldMetrics) report(err error) {
+	if m.onError != nil {
+		m.onError(wrap(err))
+	}
+}
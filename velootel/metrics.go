@@ -0,0 +1,166 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package velootel exposes velo's internal logging metrics through OpenTelemetry.
+package velootel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"velo"
+)
+
+const _scope = "velo"
+
+// Metrics implements velo.MetricsHook on top of OpenTelemetry instruments.
+//
+// Alongside the hook counters it reports the saturation ratio (queue length
+// over capacity) of every asynchronous worker, and its SamplerOption records
+// each individual sampling decision.
+type Metrics struct {
+	entries       metric.Int64Counter
+	dropped       metric.Int64Counter
+	decisions     metric.Int64Counter
+	writeErrors   metric.Int64Counter
+	bytesWritten  metric.Int64Counter
+	formatLatency metric.Float64Histogram
+	saturation    metric.Float64ObservableGauge
+
+	// levelAttrs caches the attribute option for each known level so the hot
+	// path never builds attribute sets.
+	levelAttrs [velo.FatalLevel - velo.DebugLevel + 1]metric.MeasurementOption
+}
+
+var _ velo.MetricsHook = (*Metrics)(nil)
+
+// New creates the instruments on a meter obtained from mp.
+func New(mp metric.MeterProvider) (*Metrics, error) {
+	meter := mp.Meter(_scope)
+	m := &Metrics{}
+
+	var err error
+	if m.entries, err = meter.Int64Counter("velo.entries",
+		metric.WithDescription("Number of log entries written, by level.")); err != nil {
+		return nil, wrap(err)
+	}
+	if m.dropped, err = meter.Int64Counter("velo.entries.dropped",
+		metric.WithDescription("Number of log entries discarded, by level and reason.")); err != nil {
+		return nil, wrap(err)
+	}
+	if m.decisions, err = meter.Int64Counter("velo.sampler.decisions",
+		metric.WithDescription("Sampler decisions, by level and outcome.")); err != nil {
+		return nil, wrap(err)
+	}
+	if m.writeErrors, err = meter.Int64Counter("velo.write.errors",
+		metric.WithDescription("Number of failed writes to the log destination.")); err != nil {
+		return nil, wrap(err)
+	}
+	if m.bytesWritten, err = meter.Int64Counter("velo.write.bytes",
+		metric.WithDescription("Number of bytes written to the log destination."),
+		metric.WithUnit("By")); err != nil {
+		return nil, wrap(err)
+	}
+	if m.formatLatency, err = meter.Float64Histogram("velo.format.duration",
+		metric.WithDescription("Time spent formatting a single log entry."),
+		metric.WithUnit("s")); err != nil {
+		return nil, wrap(err)
+	}
+	if m.saturation, err = meter.Float64ObservableGauge("velo.queue.saturation",
+		metric.WithDescription("Ratio of queued entries to queue capacity, per asynchronous worker."),
+		metric.WithUnit("1")); err != nil {
+		return nil, wrap(err)
+	}
+	if _, err = meter.RegisterCallback(observeSaturation(m.saturation), m.saturation); err != nil {
+		return nil, wrap(err)
+	}
+
+	for lvl := velo.DebugLevel; lvl <= velo.FatalLevel; lvl++ {
+		m.levelAttrs[lvl-velo.DebugLevel] = metric.WithAttributeSet(attribute.NewSet(levelAttr(lvl)))
+	}
+	return m, nil
+}
+
+// SamplerOption returns a velo.SamplerOption that records every decision the
+// sampler makes, labeled by level and outcome.
+func (m *Metrics) SamplerOption() velo.SamplerOption {
+	return velo.SamplerHook(func(lvl velo.Level, _ string, dec velo.SamplingDecision) {
+		outcome := "sampled"
+		if dec&velo.LogDropped != 0 {
+			outcome = "dropped"
+		}
+		m.decisions.Add(context.Background(), 1, metric.WithAttributes(levelAttr(lvl), attribute.String("outcome", outcome)))
+	})
+}
+
+// EntryLogged implements velo.MetricsHook.
+func (m *Metrics) EntryLogged(level velo.Level, formatLatency time.Duration) {
+	opt := m.levelOption(level)
+	m.entries.Add(context.Background(), 1, opt)
+	m.formatLatency.Record(context.Background(), formatLatency.Seconds(), opt)
+}
+
+// EntryDropped implements velo.MetricsHook.
+func (m *Metrics) EntryDropped(level velo.Level, reason velo.DropReason) {
+	m.dropped.Add(context.Background(), 1, metric.WithAttributes(levelAttr(level), attribute.String("reason", reason.String())))
+}
+
+// BytesWritten implements velo.MetricsHook.
+func (m *Metrics) BytesWritten(n int) {
+	m.bytesWritten.Add(context.Background(), int64(n))
+}
+
+// WriteError implements velo.MetricsHook.
+func (m *Metrics) WriteError(error) {
+	m.writeErrors.Add(context.Background(), 1)
+}
+
+func (m *Metrics) levelOption(level velo.Level) metric.MeasurementOption {
+	if level >= velo.DebugLevel && level <= velo.FatalLevel {
+		return m.levelAttrs[level-velo.DebugLevel]
+	}
+	return metric.WithAttributes(levelAttr(level))
+}
+
+// observeSaturation reports queue saturation for each worker in velo.Diagnostics.
+func observeSaturation(gauge metric.Float64ObservableGauge) metric.Callback {
+	return func(_ context.Context, o metric.Observer) error {
+		for _, w := range velo.Diagnostics().Workers {
+			if w.QueueCap == 0 {
+				continue
+			}
+			o.ObserveFloat64(gauge, float64(w.QueueLen)/float64(w.QueueCap),
+				metric.WithAttributes(attribute.String("destination", w.Destination)))
+		}
+		return nil
+	}
+}
+
+func levelAttr(level velo.Level) attribute.KeyValue {
+	return attribute.String("level", level.String())
+}
+
+func wrap(err error) error {
+	return fmt.Errorf("velootel: creating instrument: %w", err)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package veloprom
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"velo"
+)
+
+// FieldMetrics implements velo.FieldMetrics on top of Prometheus collectors.
+//
+// Each distinct Counter or Gauge field key becomes a collector, created and
+// registered the first time the key is logged. Characters that Prometheus
+// does not allow in metric names, such as dots, are replaced by underscores.
+type FieldMetrics struct {
+	reg  prometheus.Registerer
+	opts FieldOptions
+
+	counters sync.Map // string → prometheus.Counter
+	gauges   sync.Map // string → prometheus.Gauge
+}
+
+var _ velo.FieldMetrics = (*FieldMetrics)(nil)
+
+// FieldOptions configures the collectors created by NewFieldMetrics.
+type FieldOptions struct {
+	// Namespace prefixes every metric name. It is empty by default, so a
+	// Counter field keyed "cache_misses" becomes the metric cache_misses.
+	Namespace string
+
+	// ConstLabels attaches static labels to every metric, such as the service name.
+	ConstLabels prometheus.Labels
+
+	// OnError receives errors from registering a collector, such as a name
+	// conflict with a collector of another type. The field's value is not
+	// recorded. Errors are ignored by default.
+	OnError func(error)
+}
+
+// NewFieldMetrics returns a FieldMetrics registering its collectors against reg.
+func NewFieldMetrics(reg prometheus.Registerer, o FieldOptions) *FieldMetrics {
+	return &FieldMetrics{reg: reg, opts: o}
+}
+
+// AddCounter implements velo.FieldMetrics. Negative deltas are ignored, since
+// Prometheus counters only increase.
+func (m *FieldMetrics) AddCounter(name string, delta int64) {
+	if delta < 0 {
+		return
+	}
+	if c, ok := m.counters.Load(name); ok {
+		c.(prometheus.Counter).Add(float64(delta))
+		return
+	}
+	c := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   m.opts.Namespace,
+		Name:        metricName(name),
+		Help:        "Sum of the " + name + " Counter field of log entries.",
+		ConstLabels: m.opts.ConstLabels,
+	})
+	if c, ok := m.register(c).(prometheus.Counter); ok {
+		actual, _ := m.counters.LoadOrStore(name, c)
+		actual.(prometheus.Counter).Add(float64(delta))
+	}
+}
+
+// SetGauge implements velo.FieldMetrics.
+func (m *FieldMetrics) SetGauge(name string, value float64) {
+	if g, ok := m.gauges.Load(name); ok {
+		g.(prometheus.Gauge).Set(value)
+		return
+	}
+	g := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   m.opts.Namespace,
+		Name:        metricName(name),
+		Help:        "Last value of the " + name + " Gauge field of log entries.",
+		ConstLabels: m.opts.ConstLabels,
+	})
+	if g, ok := m.register(g).(prometheus.Gauge); ok {
+		actual, _ := m.gauges.LoadOrStore(name, g)
+		actual.(prometheus.Gauge).Set(value)
+	}
+}
+
+// register registers c, returning the collector already registered under
+// its name if there is one, or nil after reporting a failure.
+func (m *FieldMetrics) register(c prometheus.Collector) prometheus.Collector {
+	err := m.reg.Register(c)
+	if err == nil {
+		return c
+	}
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		return are.ExistingCollector
+	}
+	if m.opts.OnError != nil {
+		m.opts.OnError(err)
+	}
+	return nil
+}
+
+// metricName replaces the characters Prometheus does not allow in a metric
+// name with underscores, and prefixes names starting with a digit with one.
+func metricName(key string) string {
+	if key != "" && key[0] >= '0' && key[0] <= '9' {
+		key = "_" + key
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == ':':
+			return r
+		}
+		return '_'
+	}, key)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package veloprom exposes velo's internal logging metrics to Prometheus.
+package veloprom
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"velo"
+)
+
+// Metrics implements velo.MetricsHook on top of Prometheus collectors.
+//
+// It tracks entries by level, dropped entries by level and reason, write
+// errors, bytes written, and a histogram of formatting latency. Share a single
+// Metrics value between every Logger that should report into the same series.
+type Metrics struct {
+	entries       *prometheus.CounterVec
+	dropped       *prometheus.CounterVec
+	writeErrors   prometheus.Counter
+	bytesWritten  prometheus.Counter
+	formatLatency prometheus.Histogram
+}
+
+var _ velo.MetricsHook = (*Metrics)(nil)
+
+// Options configures the metric names produced by New.
+type Options struct {
+	// Namespace prefixes every metric name. It defaults to "velo".
+	Namespace string
+
+	// ConstLabels attaches static labels to every metric, such as the service name.
+	ConstLabels prometheus.Labels
+
+	// LatencyBuckets overrides the formatting latency histogram buckets, in seconds.
+	LatencyBuckets []float64
+}
+
+// New creates the collectors with default options and registers them against reg.
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	return NewWithOptions(reg, Options{})
+}
+
+// NewWithOptions creates the collectors described by o and registers them against reg.
+//
+// It returns an error if any collector fails to register, for example when
+// another Metrics value already registered the same names.
+func NewWithOptions(reg prometheus.Registerer, o Options) (*Metrics, error) {
+	if o.Namespace == "" {
+		o.Namespace = "velo"
+	}
+	if o.LatencyBuckets == nil {
+		o.LatencyBuckets = []float64{1e-7, 2.5e-7, 5e-7, 1e-6, 2.5e-6, 5e-6, 1e-5, 5e-5, 1e-4, 1e-3}
+	}
+
+	m := &Metrics{
+		entries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.Namespace,
+			Name:        "entries_total",
+			Help:        "Number of log entries written, by level.",
+			ConstLabels: o.ConstLabels,
+		}, []string{"level"}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.Namespace,
+			Name:        "dropped_entries_total",
+			Help:        "Number of log entries discarded, by level and reason.",
+			ConstLabels: o.ConstLabels,
+		}, []string{"level", "reason"}),
+		writeErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   o.Namespace,
+			Name:        "write_errors_total",
+			Help:        "Number of failed writes to the log destination.",
+			ConstLabels: o.ConstLabels,
+		}),
+		bytesWritten: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   o.Namespace,
+			Name:        "bytes_written_total",
+			Help:        "Number of bytes written to the log destination.",
+			ConstLabels: o.ConstLabels,
+		}),
+		formatLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace:   o.Namespace,
+			Name:        "format_duration_seconds",
+			Help:        "Time spent formatting a single log entry.",
+			ConstLabels: o.ConstLabels,
+			Buckets:     o.LatencyBuckets,
+		}),
+	}
+
+	for _, c := range []prometheus.Collector{m.entries, m.dropped, m.writeErrors, m.bytesWritten, m.formatLatency} {
+		if err := reg.Register(c); err != nil {
+			return nil, fmt.Errorf("veloprom: registering collector: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// EntryLogged implements velo.MetricsHook.
+func (m *Metrics) EntryLogged(level velo.Level, formatLatency time.Duration) {
+	m.entries.WithLabelValues(level.String()).Inc()
+	m.formatLatency.Observe(formatLatency.Seconds())
+}
+
+// EntryDropped implements velo.MetricsHook.
+func (m *Metrics) EntryDropped(level velo.Level, reason velo.DropReason) {
+	m.dropped.WithLabelValues(level.String(), reason.String()).Inc()
+}
+
+// BytesWritten implements velo.MetricsHook.
+func (m *Metrics) BytesWritten(n int) {
+	m.bytesWritten.Add(float64(n))
+}
+
+// WriteError implements velo.MetricsHook.
+func (m *Metrics) WriteError(error) {
+	m.writeErrors.Inc()
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velotest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"velo"
+)
+
+// AssertLogged reports a test error unless logs contains at least one entry
+// at the given level whose message contains msgSubstring and which carries
+// every provided field with a matching value.
+//
+// It returns true when a matching entry exists. Pass an empty msgSubstring to
+// match on level and fields alone.
+func AssertLogged(t testing.TB, logs *ObservedLogs, level velo.Level, msgSubstring string, fields ...velo.Field) bool {
+	t.Helper()
+	if findEntry(logs, level, msgSubstring, fields) {
+		return true
+	}
+	t.Errorf("velotest: no %s entry matching %q with fields %s\n%s", level, msgSubstring, describeFields(fields), describe(logs))
+	return false
+}
+
+// RequireLogged behaves like AssertLogged but stops the test immediately on failure.
+func RequireLogged(t testing.TB, logs *ObservedLogs, level velo.Level, msgSubstring string, fields ...velo.Field) {
+	t.Helper()
+	if !findEntry(logs, level, msgSubstring, fields) {
+		t.Fatalf("velotest: no %s entry matching %q with fields %s\n%s", level, msgSubstring, describeFields(fields), describe(logs))
+	}
+}
+
+// AssertNotLogged reports a test error if logs contains any entry at the given
+// level whose message contains msgSubstring and which carries every provided field.
+func AssertNotLogged(t testing.TB, logs *ObservedLogs, level velo.Level, msgSubstring string, fields ...velo.Field) bool {
+	t.Helper()
+	if !findEntry(logs, level, msgSubstring, fields) {
+		return true
+	}
+	t.Errorf("velotest: unexpected %s entry matching %q with fields %s\n%s", level, msgSubstring, describeFields(fields), describe(logs))
+	return false
+}
+
+// RequireNoErrors stops the test immediately if logs contains any entry at ErrorLevel or above.
+func RequireNoErrors(t testing.TB, logs *ObservedLogs) {
+	t.Helper()
+	errs := logs.Filter(func(e LoggedEntry) bool { return e.Level >= velo.ErrorLevel })
+	if errs.Len() > 0 {
+		t.Fatalf("velotest: expected no error entries, found %d\n%s", errs.Len(), describe(errs))
+	}
+}
+
+// AssertCount reports a test error unless logs contains exactly n entries.
+func AssertCount(t testing.TB, logs *ObservedLogs, n int) bool {
+	t.Helper()
+	if got := logs.Len(); got != n {
+		t.Errorf("velotest: expected %d entries, found %d\n%s", n, got, describe(logs))
+		return false
+	}
+	return true
+}
+
+func findEntry(logs *ObservedLogs, level velo.Level, msgSubstring string, fields []velo.Field) bool {
+	for _, e := range logs.All() {
+		if e.Level != level || !strings.Contains(e.Message, msgSubstring) {
+			continue
+		}
+		if hasFields(e, fields) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasFields(e LoggedEntry, fields []velo.Field) bool {
+	for _, want := range fields {
+		wantVal := FieldValue(want)
+		found := false
+		for _, f := range e.Context {
+			if f.Key == want.Key && reflect.DeepEqual(FieldValue(f), wantVal) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// describe renders the observed entries one per line for failure messages.
+func describe(logs *ObservedLogs) string {
+	all := logs.All()
+	if len(all) == 0 {
+		return "observed entries: (none)"
+	}
+	var sb strings.Builder
+	sb.WriteString("observed entries:")
+	for _, e := range all {
+		fmt.Fprintf(&sb, "\n  %s %q %s", e.Level, e.Message, describeFields(e.Context))
+	}
+	return sb.String()
+}
+
+func describeFields(fields []velo.Field) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s=%v", f.Key, FieldValue(f))
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velotest
+
+import (
+	"strings"
+	"testing"
+
+	"velo"
+)
+
+// fakeTB records the failures reported to it instead of failing the test.
+type fakeTB struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, format)
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.errors = append(f.errors, format)
+	f.fatal = true
+}
+
+func TestAssertLogged(t *testing.T) {
+	l, logs := NewObserver()
+	l.Info("request served", "status", 200, "path", "/a", "cached", true)
+	l.Warn("slow request", "took_ms", int64(1500))
+
+	for _, fields := range [][]velo.Field{
+		nil,
+		{velo.Int("status", 200)},
+		{velo.Int64("status", 200), velo.String("path", "/a"), velo.Bool("cached", true)},
+	} {
+		if !AssertLogged(t, logs, velo.InfoLevel, "served", fields...) {
+			t.Errorf("AssertLogged did not match the loosely typed pairs with %v", fields)
+		}
+	}
+	AssertLogged(t, logs, velo.WarnLevel, "", velo.Int("took_ms", 1500))
+	AssertNotLogged(t, logs, velo.InfoLevel, "served", velo.Int("status", 500))
+	AssertNotLogged(t, logs, velo.ErrorLevel, "served")
+	AssertCount(t, logs, 2)
+	RequireLogged(t, logs, velo.InfoLevel, "request", velo.String("path", "/a"))
+	RequireNoErrors(t, logs)
+}
+
+func TestAssertLoggedFailures(t *testing.T) {
+	l, logs := NewObserver()
+	l.Info("request served", "status", 200)
+	l.Error("request failed")
+
+	for name, check := range map[string]func(tb *fakeTB){
+		"AssertLogged wrong value": func(tb *fakeTB) { AssertLogged(tb, logs, velo.InfoLevel, "served", velo.Int("status", 500)) },
+		"AssertLogged wrong level": func(tb *fakeTB) { AssertLogged(tb, logs, velo.WarnLevel, "served") },
+		"AssertNotLogged":          func(tb *fakeTB) { AssertNotLogged(tb, logs, velo.InfoLevel, "", velo.Int("status", 200)) },
+		"AssertCount":              func(tb *fakeTB) { AssertCount(tb, logs, 1) },
+		"RequireLogged":            func(tb *fakeTB) { RequireLogged(tb, logs, velo.InfoLevel, "missing") },
+		"RequireNoErrors":          func(tb *fakeTB) { RequireNoErrors(tb, logs) },
+	} {
+		tb := &fakeTB{TB: t}
+		check(tb)
+		if len(tb.errors) != 1 {
+			t.Errorf("%s reported %d failures, want 1", name, len(tb.errors))
+		}
+		if fatal := strings.HasPrefix(name, "Require"); tb.fatal != fatal {
+			t.Errorf("%s stopped the test: %v, want %v", name, tb.fatal, fatal)
+		}
+	}
+}
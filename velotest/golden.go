@@ -0,0 +1,110 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velotest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"velo"
+)
+
+// FixedTime is the timestamp stamped on every entry written by a deterministic Logger.
+var FixedTime = time.Date(2026, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+var _updateGolden = flag.Bool("velotest.update", false, "rewrite velotest golden files instead of comparing against them")
+
+// DeterministicOptions returns a copy of o adjusted so that output is byte for
+// byte reproducible across runs and machines.
+//
+// It pins the clock to FixedTime, disables ANSI styling, keeps caller paths
+// to the base file name, and forces synchronous writes so output is complete
+// as soon as each call returns. The JSON encoder always sorts map keys.
+func DeterministicOptions(o velo.Options) velo.Options {
+	o.ReportTimestamp = true
+	o.TimeFunction = func(time.Time) time.Time { return FixedTime }
+	o.Styles = velo.PlainStyles()
+	o.CallerFormatter = velo.ShortCallerFormatter
+	o.Async = false
+	return o
+}
+
+// NewDeterministic constructs a Logger configured by DeterministicOptions that
+// writes into the returned buffer.
+func NewDeterministic(o velo.Options) (*velo.Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return velo.NewWithOptions(buf, DeterministicOptions(o)), buf
+}
+
+// AssertGolden compares got against the golden file testdata/<name>.golden.
+//
+// Run the tests with -velotest.update, or with VELOTEST_UPDATE=1 in the
+// environment, to write got as the new golden file instead. It reports the
+// first differing line on mismatch so formatter changes read as diffs in CI.
+func AssertGolden(t testing.TB, name string, got []byte) bool {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if *_updateGolden || os.Getenv("VELOTEST_UPDATE") != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("velotest: creating golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("velotest: writing golden file: %v", err)
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("velotest: reading golden file (run with -velotest.update to create it): %v", err)
+	}
+	if bytes.Equal(got, want) {
+		return true
+	}
+	t.Errorf("velotest: output does not match %s\n%s", path, firstDiff(string(want), string(got)))
+	return false
+}
+
+// firstDiff describes the first line at which want and got diverge.
+func firstDiff(want, got string) string {
+	wl := strings.Split(want, "\n")
+	gl := strings.Split(got, "\n")
+	for i := 0; i < len(wl) || i < len(gl); i++ {
+		var w, g string
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, w, g)
+		}
+	}
+	return "outputs differ only in trailing bytes"
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velotest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"velo"
+)
+
+func TestAssertGolden(t *testing.T) {
+	t.Chdir(t.TempDir())
+	l, buf := NewDeterministic(velo.Options{})
+	l.Info("request served", "status", 200)
+
+	t.Setenv("VELOTEST_UPDATE", "1")
+	if !AssertGolden(t, "served", buf.Bytes()) {
+		t.Fatal("AssertGolden failed while updating")
+	}
+	golden, err := os.ReadFile(filepath.Join("testdata", "served.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(golden) != buf.String() {
+		t.Errorf("wrote golden file %q, want %q", golden, buf.String())
+	}
+
+	t.Setenv("VELOTEST_UPDATE", "")
+	if !AssertGolden(t, "served", buf.Bytes()) {
+		t.Error("AssertGolden rejected the output it recorded")
+	}
+	tb := &fakeTB{TB: t}
+	l.Info("request served", "status", 404)
+	if AssertGolden(tb, "served", buf.Bytes()) || len(tb.errors) != 1 {
+		t.Error("AssertGolden accepted output that differs from the golden file")
+	}
+}
+
+func TestFirstDiff(t *testing.T) {
+	for _, tt := range []struct{ want, got, diff string }{
+		{"a\nb\n", "a\nc\n", "line 2:\n  want: b\n  got:  c"},
+		{"a\n", "a\nb\n", "line 2:\n  want: \n  got:  b"},
+		{"a", "a", "outputs differ only in trailing bytes"},
+	} {
+		if diff := firstDiff(tt.want, tt.got); diff != tt.diff {
+			t.Errorf("firstDiff(%q, %q) = %q, want %q", tt.want, tt.got, diff, tt.diff)
+		}
+	}
+}
+
+func TestDeterministicOutput(t *testing.T) {
+	l, buf := NewDeterministic(velo.Options{Formatter: velo.JSONFormatter})
+	l.Info("request served", "status", 200)
+	const want = `{"time":"2026/01/02 15:04:05","level":"info","msg":"request served","status":200}` + "\n"
+	if buf.String() != want {
+		t.Errorf("wrote %q, want %q", buf.String(), want)
+	}
+}
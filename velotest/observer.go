@@ -0,0 +1,269 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package velotest provides helpers for asserting on velo output in unit tests.
+package velotest
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"velo"
+)
+
+// LoggedEntry is a structured copy of a single observed log entry.
+//
+// Loosely typed key-value pairs are converted into strongly typed Fields, so
+// tests can assert on every field through a single representation.
+type LoggedEntry struct {
+	Time     time.Time
+	Level    velo.Level
+	Sequence uint64
+	Message  string
+	Prefix   string
+	Caller   string
+	Context  []velo.Field
+}
+
+// ContextMap decodes the entry's fields into a map keyed by field name.
+//
+// Later fields overwrite earlier ones with the same key, mirroring how most
+// JSON consumers read duplicate keys.
+func (e LoggedEntry) ContextMap() map[string]any {
+	m := make(map[string]any, len(e.Context))
+	for _, f := range e.Context {
+		m[f.Key] = FieldValue(f)
+	}
+	return m
+}
+
+// ObservedLogs is a concurrency safe, ordered collection of observed entries.
+//
+// It implements velo.EntryObserver, capturing structured entries rather than
+// formatted bytes so tests never need to parse log output.
+type ObservedLogs struct {
+	mu   sync.RWMutex
+	logs []LoggedEntry
+}
+
+// NewObserver constructs a Logger that records every entry at DebugLevel or
+// above into the returned ObservedLogs.
+//
+// The Logger discards its formatted output. Use SetLevel on the returned
+// Logger to observe a narrower range of levels.
+func NewObserver() (*velo.Logger, *ObservedLogs) {
+	ol := &ObservedLogs{}
+	l := velo.NewWithOptions(io.Discard, velo.Options{
+		Level:    velo.DebugLevel,
+		Observer: ol,
+	})
+	return l, ol
+}
+
+// ObserveEntry records a copy of the provided Entry.
+func (o *ObservedLogs) ObserveEntry(e *velo.Entry) {
+	le := LoggedEntry{
+		Time:     e.Time,
+		Level:    e.Level,
+		Sequence: e.Sequence,
+		Message:  e.Message,
+		Prefix:   e.Prefix,
+		Caller:   e.Caller,
+		Context:  make([]velo.Field, 0, len(e.Fields)/2+len(e.TypedFields)),
+	}
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		le.Context = append(le.Context, keyValToField(e.Fields[i], e.Fields[i+1]))
+	}
+	for _, f := range e.TypedFields {
+		if f.Type == velo.AnyType {
+			// Loosely typed call pairs arrive as Any fields.
+			le.Context = append(le.Context, keyValToField(f.Key, f.Any))
+			continue
+		}
+		le.Context = append(le.Context, ownField(f))
+	}
+
+	o.mu.Lock()
+	o.logs = append(o.logs, le)
+	o.mu.Unlock()
+}
+
+// Len returns the number of observed entries.
+func (o *ObservedLogs) Len() int {
+	o.mu.RLock()
+	n := len(o.logs)
+	o.mu.RUnlock()
+	return n
+}
+
+// All returns a copy of all observed entries in the order they were written.
+func (o *ObservedLogs) All() []LoggedEntry {
+	o.mu.RLock()
+	ret := make([]LoggedEntry, len(o.logs))
+	copy(ret, o.logs)
+	o.mu.RUnlock()
+	return ret
+}
+
+// TakeAll returns all observed entries and resets the collection.
+func (o *ObservedLogs) TakeAll() []LoggedEntry {
+	o.mu.Lock()
+	ret := o.logs
+	o.logs = nil
+	o.mu.Unlock()
+	return ret
+}
+
+// Filter returns a new ObservedLogs containing only the entries for which keep returns true.
+func (o *ObservedLogs) Filter(keep func(LoggedEntry) bool) *ObservedLogs {
+	var filtered []LoggedEntry
+	for _, e := range o.All() {
+		if keep(e) {
+			filtered = append(filtered, e)
+		}
+	}
+	return &ObservedLogs{logs: filtered}
+}
+
+// FilterMessage returns the entries whose message exactly matches msg.
+func (o *ObservedLogs) FilterMessage(msg string) *ObservedLogs {
+	return o.Filter(func(e LoggedEntry) bool { return e.Message == msg })
+}
+
+// FilterMessageSnippet returns the entries whose message contains snippet.
+func (o *ObservedLogs) FilterMessageSnippet(snippet string) *ObservedLogs {
+	return o.Filter(func(e LoggedEntry) bool { return strings.Contains(e.Message, snippet) })
+}
+
+// FilterLevelExact returns the entries written at exactly the given level.
+func (o *ObservedLogs) FilterLevelExact(level velo.Level) *ObservedLogs {
+	return o.Filter(func(e LoggedEntry) bool { return e.Level == level })
+}
+
+// FilterField returns the entries carrying a field with the same key and value as f.
+func (o *ObservedLogs) FilterField(f velo.Field) *ObservedLogs {
+	want := FieldValue(f)
+	return o.Filter(func(e LoggedEntry) bool {
+		for _, ef := range e.Context {
+			if ef.Key == f.Key && reflect.DeepEqual(FieldValue(ef), want) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FilterFieldKey returns the entries carrying a field with the given key, regardless of value.
+func (o *ObservedLogs) FilterFieldKey(key string) *ObservedLogs {
+	return o.Filter(func(e LoggedEntry) bool {
+		for _, ef := range e.Context {
+			if ef.Key == key {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FieldValue decodes a Field back into a plain Go value.
+//
+// Integers decode to int64, booleans to bool, times to time.Time, durations to
+// time.Duration, and slice fields to freshly allocated slices. Everything else
+// returns the stored value unchanged.
+func FieldValue(f velo.Field) any {
+	switch f.Type {
+	case velo.StringType:
+		return f.Str
+	case velo.IntType:
+		return f.Int
+	case velo.BoolType:
+		return f.Int == 1
+	case velo.TimeType:
+		return time.Unix(0, f.Int)
+	case velo.DurationType:
+		return time.Duration(f.Int)
+	case velo.IntsType:
+		return append([]int{}, fieldSlice[int](f)...)
+	case velo.StringsType:
+		return append([]string{}, fieldSlice[string](f)...)
+	case velo.TimesType:
+		return append([]time.Time{}, fieldSlice[time.Time](f)...)
+	default:
+		return f.Any
+	}
+}
+
+// fieldSlice recovers the slice backing an Ints, Strings, or Times field.
+func fieldSlice[T any](f velo.Field) []T {
+	if f.Int <= 0 {
+		return nil
+	}
+	return unsafe.Slice((*T)(unsafe.Pointer(unsafe.StringData(f.Str))), int(f.Int))
+}
+
+// ownField detaches slice fields from the caller's backing arrays so later
+// mutations at the call site don't rewrite observed history.
+func ownField(f velo.Field) velo.Field {
+	switch f.Type {
+	case velo.IntsType:
+		return velo.Ints(f.Key, FieldValue(f).([]int))
+	case velo.StringsType:
+		return velo.Strings(f.Key, FieldValue(f).([]string))
+	case velo.TimesType:
+		return velo.Times(f.Key, FieldValue(f).([]time.Time))
+	default:
+		return f
+	}
+}
+
+// keyValToField converts a loosely typed key-value pair into a strongly typed Field.
+func keyValToField(key, val any) velo.Field {
+	k, ok := key.(string)
+	if !ok {
+		k = fmt.Sprint(key)
+	}
+	switch v := val.(type) {
+	case string:
+		return velo.String(k, v)
+	case int:
+		return velo.Int(k, v)
+	case int64:
+		return velo.Int64(k, v)
+	case bool:
+		return velo.Bool(k, v)
+	case time.Time:
+		return velo.Time(k, v)
+	case time.Duration:
+		return velo.Duration(k, v)
+	case error:
+		return velo.Field{Key: k, Type: velo.ErrorType, Any: v}
+	case []int:
+		return velo.Ints(k, append([]int{}, v...))
+	case []string:
+		return velo.Strings(k, append([]string{}, v...))
+	default:
+		return velo.Any(k, v)
+	}
+}
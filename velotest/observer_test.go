@@ -0,0 +1,99 @@
+// Copyright (c) 2026 blairtcg
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package velotest
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"velo"
+)
+
+func messages(logs *ObservedLogs) []string {
+	var msgs []string
+	for _, e := range logs.All() {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
+
+func TestObservedLogsFilter(t *testing.T) {
+	l, logs := NewObserver()
+	l.Debug("cache miss", "key", "a")
+	l.Info("request served", "status", 200, "path", "/a")
+	l.Info("request served", "status", 404, "path", "/b")
+	l.Error("request failed", "err", errors.New("reset"))
+	l.InfoFields("user created", velo.String("user", "ana"), velo.Duration("took", time.Second))
+
+	for _, tt := range []struct {
+		name string
+		logs *ObservedLogs
+		want []string
+	}{
+		{"FilterMessage", logs.FilterMessage("request served"), []string{"request served", "request served"}},
+		{"FilterMessageSnippet", logs.FilterMessageSnippet("request"), []string{"request served", "request served", "request failed"}},
+		{"FilterLevelExact", logs.FilterLevelExact(velo.InfoLevel), []string{"request served", "request served", "user created"}},
+		{"FilterField", logs.FilterField(velo.Int("status", 404)), []string{"request served"}},
+		{"FilterField typed", logs.FilterField(velo.Duration("took", time.Second)), []string{"user created"}},
+		{"FilterFieldKey", logs.FilterFieldKey("err"), []string{"request failed"}},
+		{"chained", logs.FilterMessage("request served").FilterField(velo.String("path", "/a")), []string{"request served"}},
+	} {
+		if got := messages(tt.logs); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if n := logs.Len(); n != 5 {
+		t.Errorf("Len() = %d after filtering, want 5", n)
+	}
+	if all := logs.TakeAll(); len(all) != 5 || logs.Len() != 0 {
+		t.Errorf("TakeAll() returned %d entries and left %d", len(all), logs.Len())
+	}
+}
+
+func TestObservedLogsContext(t *testing.T) {
+	l, logs := NewObserver()
+	ids := []int{1, 2}
+	l.With("service", "api").Info("batch", "ids", ids, "n", int64(2), "ok", true)
+	ids[0] = 9
+
+	all := logs.All()
+	if len(all) != 1 {
+		t.Fatalf("observed %d entries, want 1", len(all))
+	}
+	got := all[0].ContextMap()
+	want := map[string]any{"service": "api", "ids": []int{1, 2}, "n": int64(2), "ok": true}
+	for k, v := range want {
+		if !valuesEqual(got[k], v) {
+			t.Errorf("ContextMap()[%q] = %#v, want %#v", k, got[k], v)
+		}
+	}
+}
+
+func valuesEqual(a, b any) bool {
+	if as, ok := a.([]int); ok {
+		bs, ok := b.([]int)
+		return ok && slices.Equal(as, bs)
+	}
+	return a == b
+}
@@ -25,19 +25,29 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
+// _workers lists the running workers. It is copied on write under _workersMu,
+// so readers such as flushAllWorkers and Diagnostics never take the lock.
 var (
-	_workers   []*worker
+	_workers   atomic.Pointer[[]*worker]
 	_workersMu sync.Mutex
 )
 
+// runningWorkers returns a snapshot of the running workers.
+func runningWorkers() []*worker {
+	if ws := _workers.Load(); ws != nil {
+		return *ws
+	}
+	return nil
+}
+
 func flushAllWorkers() {
-	_workersMu.Lock()
-	defer _workersMu.Unlock()
-	for _, w := range _workers {
+	for _, w := range runningWorkers() {
 		w.sync()
 	}
 }
@@ -48,7 +58,7 @@ func flushAllWorkers() {
 // forms the core of the asynchronous logging system, ensuring the main application
 // thread is not blocked by I/O operations.
 type worker struct {
-	queue    chan *buffer
+	queue    *ringQueue
 	syncChan chan chan error
 	output   io.Writer
 	bw       *bufio.Writer
@@ -56,24 +66,62 @@ type worker struct {
 	flushed  chan struct{}
 	strategy OverflowStrategy
 	refCount atomic.Int64
-	lastErr  error
+	dropped  atomic.Uint64
+	lastErr  atomic.Pointer[error]
+	metrics  MetricsHook
+
+	// onError is Options.ErrorHandler, which receives write errors in place
+	// of os.Stderr when set.
+	onError func(error)
+
+	// spill receives the entries dropped on overflow and, through dest, those
+	// output fails to accept. dest is output when there is neither a spill
+	// file nor a write timeout.
+	spill    *SpillFile
+	dest     io.Writer
+	deadline *deadlineWriter
+	timeout  time.Duration
+
+	// onWrite and the batch statistics are only touched by the worker
+	// goroutine, except for OverflowSync direct writes, which report alone.
+	onWrite    func(WriteStats)
+	batchLen   int
+	batchBytes int
+	batchStart time.Time
 }
 
-func newWorker(output io.Writer, cap int, strategy OverflowStrategy) *worker {
+func newWorker(output io.Writer, cap int, strategy OverflowStrategy, metrics MetricsHook, onWrite func(WriteStats), onError func(error), spill *SpillFile, timeout time.Duration) *worker {
+	dest := output
+	var deadline *deadlineWriter
+	if dw, ok := output.(DeadlineWriter); ok && timeout > 0 {
+		deadline = &deadlineWriter{out: dw, timeout: timeout, spilled: spill != nil}
+		dest = deadline
+	}
+	if spill != nil {
+		dest = &spillWriter{out: dest, spill: spill}
+	}
 	w := &worker{
-		queue:    make(chan *buffer, cap),
+		queue:    newRingQueue(cap),
 		syncChan: make(chan chan error),
 		output:   output,
-		bw:       bufio.NewWriterSize(output, 64*1024), // 64KB buffer
+		bw:       bufio.NewWriterSize(dest, 64*1024), // 64KB buffer
 		stopChan: make(chan struct{}),
 		flushed:  make(chan struct{}),
 		strategy: strategy,
+		metrics:  metrics,
+		onWrite:  onWrite,
+		onError:  onError,
+		spill:    spill,
+		dest:     dest,
+		deadline: deadline,
+		timeout:  timeout,
 	}
 	w.refCount.Store(1)
 	w.start()
 
 	_workersMu.Lock()
-	_workers = append(_workers, w)
+	ws := append(slices.Clip(runningWorkers()), w)
+	_workers.Store(&ws)
 	_workersMu.Unlock()
 
 	return w
@@ -85,36 +133,56 @@ func (w *worker) start() {
 
 func (w *worker) stop() {
 	_workersMu.Lock()
-	for i, worker := range _workers {
-		if worker == w {
-			_workers = append(_workers[:i], _workers[i+1:]...)
-			break
-		}
-	}
+	ws := slices.DeleteFunc(slices.Clone(runningWorkers()), func(x *worker) bool { return x == w })
+	_workers.Store(&ws)
 	_workersMu.Unlock()
 
 	close(w.stopChan)
 	<-w.flushed
 }
 
-func (w *worker) submit(b *buffer) {
-	select {
-	case w.queue <- b:
-		return
-	default:
-		// Fall through to overflow handling
+// submit enqueues a formatted buffer, applying the overflow strategy when the
+// queue is full. It reports false if the buffer was discarded.
+func (w *worker) submit(b *buffer) bool {
+	if w.queue.push(b) {
+		return true
 	}
 
 	switch w.strategy {
 	case OverflowDrop:
+		w.dropped.Add(1)
+		if w.spill != nil {
+			b.resolveStack()
+			w.spill.Write(b.B)
+		}
 		putBuffer(b)
+		return false
 	case OverflowBlock:
-		w.queue <- b
+		w.queue.pushWait(b)
 	case OverflowSync:
 		// Write directly to output
-		w.output.Write(b.B)
+		b.resolveStack()
+		var start time.Time
+		if w.onWrite != nil {
+			start = time.Now()
+		}
+		var n int
+		var err error
+		if s, ok := w.dest.(*spillWriter); ok {
+			n, err = s.writeEntry(b.B)
+		} else {
+			n, err = w.dest.Write(b.B)
+		}
+		if err == nil {
+			err = w.spilled()
+		}
+		if w.onWrite != nil {
+			w.onWrite(WriteStats{Entries: 1, Bytes: n, Duration: time.Since(start), Err: err})
+		}
+		w.record(n, err)
 		putBuffer(b)
 	}
+	return true
 }
 
 // sync pauses the calling goroutine until the worker writes all queued logs to the underlying writer.
@@ -149,19 +217,9 @@ func (w *worker) run() {
 			w.drainAll()
 			err := w.flushBuffer()
 			errChan <- err
-		case b := <-w.queue:
-			w.write(b)
-
-			// Batching: try to drain more from the channel without blocking
-			for {
-				select {
-				case next := <-w.queue:
-					w.write(next)
-				default:
-					goto flush
-				}
-			}
-		flush:
+		case <-w.queue.ready:
+			// Write everything queued as one batch.
+			w.drainAll()
 			w.flushBuffer()
 		}
 	}
@@ -169,24 +227,79 @@ func (w *worker) run() {
 
 func (w *worker) drainAll() {
 	for {
-		select {
-		case b := <-w.queue:
-			w.write(b)
-		default:
+		b, ok := w.queue.pop()
+		if !ok {
 			return
 		}
+		w.write(b)
 	}
 }
 
 func (w *worker) write(b *buffer) {
-	if _, err := w.bw.Write(b.B); err != nil {
+	b.resolveStack()
+	// Hand the destination whole entries only, so that writers acting
+	// between writes, such as CompressWriter, never split one. An entry
+	// larger than the buffer goes straight through in a write of its own.
+	if len(b.B) > w.bw.Available() && w.bw.Buffered() > 0 {
+		w.bw.Flush()
+	}
+	if s, ok := w.dest.(*spillWriter); ok {
+		s.lens = append(s.lens, len(b.B))
+	}
+	n, err := w.bw.Write(b.B)
+	if err != nil {
 		w.handleError(err)
 	}
+	if w.metrics != nil {
+		w.metrics.BytesWritten(n)
+	}
+	if w.onWrite != nil {
+		if w.batchLen == 0 {
+			w.batchStart = time.Now()
+		}
+		w.batchLen++
+		w.batchBytes += n
+	}
 	putBuffer(b)
 }
 
+// spilled returns the error, if any, that sent entries to the spill file or
+// discarded them on a write timeout since it was last called.
+func (w *worker) spilled() error {
+	var err error
+	if w.deadline != nil {
+		err = w.deadline.take()
+	}
+	if s, ok := w.dest.(*spillWriter); ok {
+		if serr := s.take(); serr != nil {
+			err = serr
+		}
+	}
+	return err
+}
+
+// record reports the outcome of a direct write to the metrics hook.
+func (w *worker) record(n int, err error) {
+	if w.metrics == nil {
+		return
+	}
+	if err != nil {
+		w.metrics.WriteError(err)
+		return
+	}
+	w.metrics.BytesWritten(n)
+}
+
 func (w *worker) flushBuffer() error {
-	if err := w.bw.Flush(); err != nil {
+	err := w.bw.Flush()
+	if err == nil {
+		err = w.spilled()
+	}
+	if w.onWrite != nil && w.batchLen > 0 {
+		w.onWrite(WriteStats{Entries: w.batchLen, Bytes: w.batchBytes, Duration: time.Since(w.batchStart), Err: err})
+		w.batchLen, w.batchBytes = 0, 0
+	}
+	if err != nil {
 		w.handleError(err)
 		return err
 	}
@@ -194,9 +307,19 @@ func (w *worker) flushBuffer() error {
 }
 
 func (w *worker) handleError(err error) {
-	if err != nil && w.lastErr != err {
+	if err != nil && w.metrics != nil {
+		w.metrics.WriteError(err)
+	}
+	if err == nil {
+		return
+	}
+	if last := w.lastErr.Load(); last == nil || *last != err {
 		// Prevent log spam about logging errors
-		w.lastErr = err
+		w.lastErr.Store(&err)
+		if w.onError != nil {
+			w.onError(err)
+			return
+		}
 		fmt.Fprintf(os.Stderr, "velo: logging error: %v\n", err)
 	}
 }